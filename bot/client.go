@@ -12,6 +12,7 @@ import (
 	"github.com/disgoorg/disgo/httpserver"
 	"github.com/disgoorg/disgo/rest"
 	"github.com/disgoorg/disgo/sharding"
+	"github.com/disgoorg/disgo/tracing"
 	"github.com/disgoorg/disgo/voice"
 )
 
@@ -22,6 +23,7 @@ type Client struct {
 	Token                 string
 	ApplicationID         snowflake.ID
 	Logger                *slog.Logger
+	Tracer                tracing.Tracer
 	Rest                  rest.Rest
 	EventManager          EventManager
 	ShardManager          sharding.ShardManager
@@ -30,6 +32,8 @@ type Client struct {
 	VoiceManager          voice.Manager
 	Caches                cache.Caches
 	MemberChunkingManager MemberChunkingManager
+
+	cacheSnapshotPath string
 }
 
 func (c *Client) Close(ctx context.Context) {
@@ -48,6 +52,11 @@ func (c *Client) Close(ctx context.Context) {
 	if c.HTTPServer != nil {
 		c.HTTPServer.Close(ctx)
 	}
+	if c.cacheSnapshotPath != "" {
+		if err := cache.SaveSnapshot(c.cacheSnapshotPath, c.Caches); err != nil {
+			c.Logger.Error("failed to save cache snapshot", slog.Any("err", err))
+		}
+	}
 }
 
 func (c *Client) ID() snowflake.ID {
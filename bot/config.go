@@ -1,7 +1,10 @@
 package bot
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log/slog"
 
 	"github.com/disgoorg/disgo/cache"
@@ -9,24 +12,31 @@ import (
 	"github.com/disgoorg/disgo/gateway"
 	"github.com/disgoorg/disgo/httpserver"
 	"github.com/disgoorg/disgo/internal/tokenhelper"
+	"github.com/disgoorg/disgo/metrics"
 	"github.com/disgoorg/disgo/rest"
 	"github.com/disgoorg/disgo/sharding"
+	"github.com/disgoorg/disgo/tracing"
 	"github.com/disgoorg/disgo/voice"
 )
 
-func defaultConfig(gatewayHandlers map[gateway.EventType]GatewayEventHandler, httpHandler HTTPServerEventHandler) config {
+func defaultConfig(gatewayHandlers map[gateway.EventType]GatewayEventHandler, httpHandler HTTPServerEventHandler, webhookHandler WebhookEventHandler) config {
 	return config{
 		Logger:                 slog.Default(),
-		EventManagerConfigOpts: []EventManagerConfigOpt{WithGatewayHandlers(gatewayHandlers), WithHTTPServerHandler(httpHandler)},
+		EventManagerConfigOpts: []EventManagerConfigOpt{WithGatewayHandlers(gatewayHandlers), WithHTTPServerHandler(httpHandler), WithWebhookEventHandler(webhookHandler)},
 		MemberChunkingFilter:   MemberChunkingFilterNone,
+		Metrics:                metrics.Noop,
+		Tracer:                 tracing.Noop,
 	}
 }
 
 type config struct {
-	Logger *slog.Logger
+	Logger  *slog.Logger
+	Metrics metrics.Metrics
+	Tracer  tracing.Tracer
 
 	RestClient           rest.Client
 	RestClientConfigOpts []rest.ConfigOpt
+	RestPermissionChecks bool
 	Rest                 rest.Rest
 
 	EventManager           EventManager
@@ -44,9 +54,11 @@ type config struct {
 	HTTPServer           httpserver.Server
 	PublicKey            string
 	HTTPServerConfigOpts []httpserver.ConfigOpt
+	WebhookEventsURL     string
 
-	Caches          cache.Caches
-	CacheConfigOpts []cache.ConfigOpt
+	Caches            cache.Caches
+	CacheConfigOpts   []cache.ConfigOpt
+	CacheSnapshotPath string
 
 	MemberChunkingManager MemberChunkingManager
 	MemberChunkingFilter  MemberChunkingFilter
@@ -69,6 +81,20 @@ func WithLogger(logger *slog.Logger) ConfigOpt {
 	}
 }
 
+// WithMetrics sets the metrics.Metrics the default rest.Client, gateway.Gateway, sharding.ShardManager, EventManager & cache.Caches are instrumented with.
+func WithMetrics(m metrics.Metrics) ConfigOpt {
+	return func(config *config) {
+		config.Metrics = m
+	}
+}
+
+// WithTracer sets the tracing.Tracer the default rest.Client, EventManager & handler.Mux start spans with.
+func WithTracer(tracer tracing.Tracer) ConfigOpt {
+	return func(config *config) {
+		config.Tracer = tracer
+	}
+}
+
 // WithRestClient lets you inject your own rest.Client.
 func WithRestClient(restClient rest.Client) ConfigOpt {
 	return func(config *config) {
@@ -83,6 +109,14 @@ func WithRestClientConfigOpts(opts ...rest.ConfigOpt) ConfigOpt {
 	}
 }
 
+// WithRestPermissionChecks adds rest.NewPermissionCheckMiddleware to the default rest.Client, which checks the permissions of the bot in the cache.Caches
+// before making requests & returns a *rest.MissingPermissionsError or *rest.RoleHierarchyError instead of sending requests Discord would reject.
+func WithRestPermissionChecks() ConfigOpt {
+	return func(config *config) {
+		config.RestPermissionChecks = true
+	}
+}
+
 // WithRest lets you inject your own rest.Rest.
 func WithRest(rest rest.Rest) ConfigOpt {
 	return func(config *config) {
@@ -178,6 +212,14 @@ func WithHTTPServerConfigOpts(publicKey string, opts ...httpserver.ConfigOpt) Co
 	}
 }
 
+// WithWebhookEvents lets you receive Discord's Webhook Events at the given URL of the default httpserver.Server.
+// The events are dispatched to the EventManager. This requires the public key to be set with WithHTTPServerConfigOpts.
+func WithWebhookEvents(url string) ConfigOpt {
+	return func(config *config) {
+		config.WebhookEventsURL = url
+	}
+}
+
 // WithCaches lets you inject your own cache.Caches.
 func WithCaches(caches cache.Caches) ConfigOpt {
 	return func(config *config) {
@@ -192,6 +234,16 @@ func WithCacheConfigOpts(opts ...cache.ConfigOpt) ConfigOpt {
 	}
 }
 
+// WithCacheSnapshot loads the cache.Caches from the snapshot at the given path when the Client is built and saves them to it when the Client is closed.
+// Combined with gateway.WithSessionStore, the sessions are resumed after a restart and the caches are ready without waiting for every guild.
+// If a session can't be resumed, guilds which are missing in the READY event are removed and the entities of every guild are replaced when its GUILD_CREATE event is received.
+// Until then, the restored entities might be stale.
+func WithCacheSnapshot(path string) ConfigOpt {
+	return func(config *config) {
+		config.CacheSnapshotPath = path
+	}
+}
+
 // WithMemberChunkingManager lets you inject your own MemberChunkingManager.
 func WithMemberChunkingManager(memberChunkingManager MemberChunkingManager) ConfigOpt {
 	return func(config *config) {
@@ -214,12 +266,13 @@ func defaultGatewayEventHandlerFunc(client *Client) gateway.EventHandlerFunc {
 	return client.EventManager.HandleGatewayEvent
 }
 
-// BuildClient creates a new Client instance with the given Token, config, Gateway handlers, http handlers os, name, github & version.
+// BuildClient creates a new Client instance with the given Token, config, Gateway handlers, http handlers, webhook event handler, os, name, github & version.
 func BuildClient(
 	token string,
 	otps []ConfigOpt,
 	gatewayHandlers map[gateway.EventType]GatewayEventHandler,
 	httpHandler HTTPServerEventHandler,
+	webhookHandler WebhookEventHandler,
 	os string,
 	name string,
 	github string,
@@ -233,24 +286,36 @@ func BuildClient(
 		return nil, fmt.Errorf("error while getting application id from Token: %w", err)
 	}
 
-	cfg := defaultConfig(gatewayHandlers, httpHandler)
+	cfg := defaultConfig(gatewayHandlers, httpHandler, webhookHandler)
 	cfg.apply(otps)
 
 	client := &Client{
 		Token:         token,
 		Logger:        cfg.Logger,
+		Tracer:        cfg.Tracer,
 		ApplicationID: *id,
 	}
 
+	if cfg.Caches == nil {
+		cfg.Caches = cache.New(cfg.CacheConfigOpts...)
+	}
+	client.Caches = cfg.Caches
+	registerCacheMetrics(cfg.Metrics, client.Caches)
+
 	if cfg.RestClient == nil {
 		// prepend standard user-agent. this can be overridden as it's appended to the front of the slice
 		cfg.RestClientConfigOpts = append([]rest.ConfigOpt{
 			rest.WithUserAgent(fmt.Sprintf("DiscordBot (%s, %s)", github, version)),
 			rest.WithLogger(client.Logger),
+			rest.WithMetrics(cfg.Metrics),
+			rest.WithTracer(cfg.Tracer),
 			rest.WithDefaultRateLimiterConfigOpts(
 				rest.WithRateLimiterLogger(cfg.Logger),
 			),
 		}, cfg.RestClientConfigOpts...)
+		if cfg.RestPermissionChecks {
+			cfg.RestClientConfigOpts = append(cfg.RestClientConfigOpts, rest.WithMiddlewares(rest.NewPermissionCheckMiddleware(client.Caches)))
+		}
 
 		cfg.RestClient = rest.NewClient(client.Token, cfg.RestClientConfigOpts...)
 	}
@@ -266,7 +331,7 @@ func BuildClient(
 	client.VoiceManager = cfg.VoiceManager
 
 	if cfg.EventManager == nil {
-		cfg.EventManager = NewEventManager(client, append([]EventManagerConfigOpt{WithEventManagerLogger(cfg.Logger)}, cfg.EventManagerConfigOpts...)...)
+		cfg.EventManager = NewEventManager(client, append([]EventManagerConfigOpt{WithEventManagerLogger(cfg.Logger), WithEventManagerMetrics(cfg.Metrics), WithEventManagerTracer(cfg.Tracer)}, cfg.EventManagerConfigOpts...)...)
 	}
 	client.EventManager = cfg.EventManager
 
@@ -283,6 +348,7 @@ func BuildClient(
 			gateway.WithOS(os),
 			gateway.WithBrowser(name),
 			gateway.WithDevice(name),
+			gateway.WithMetrics(cfg.Metrics),
 			gateway.WithDefaultRateLimiterConfigOpts(
 				gateway.WithRateLimiterLogger(cfg.Logger),
 			),
@@ -313,8 +379,17 @@ func BuildClient(
 				gateway.WithOS(os),
 				gateway.WithBrowser(name),
 				gateway.WithDevice(name),
+				gateway.WithMetrics(cfg.Metrics),
 			),
 			sharding.WithLogger(cfg.Logger),
+			sharding.WithClusterMaxConcurrency(gatewayBotRs.SessionStartLimit.MaxConcurrency),
+			sharding.WithShardCountFunc(func(ctx context.Context) (int, error) {
+				rs, err := client.Rest.GetGatewayBot(rest.WithCtx(ctx))
+				if err != nil {
+					return 0, err
+				}
+				return rs.Shards, nil
+			}),
 			sharding.WithDefaultIdentifyRateLimiterConfigOpt(
 				gateway.WithIdentifyMaxConcurrency(gatewayBotRs.SessionStartLimit.MaxConcurrency),
 				gateway.WithIdentifyRateLimiterLogger(cfg.Logger),
@@ -329,6 +404,9 @@ func BuildClient(
 		cfg.HTTPServerConfigOpts = append([]httpserver.ConfigOpt{
 			httpserver.WithLogger(cfg.Logger),
 		}, cfg.HTTPServerConfigOpts...)
+		if cfg.WebhookEventsURL != "" {
+			cfg.HTTPServerConfigOpts = append(cfg.HTTPServerConfigOpts, httpserver.WithWebhookEvents(cfg.WebhookEventsURL, client.EventManager.HandleWebhookEvent))
+		}
 
 		cfg.HTTPServer, err = httpserver.New(cfg.PublicKey, defaultHTTPServerEventHandlerFunc(client), cfg.HTTPServerConfigOpts...)
 		if err != nil {
@@ -342,10 +420,12 @@ func BuildClient(
 	}
 	client.MemberChunkingManager = cfg.MemberChunkingManager
 
-	if cfg.Caches == nil {
-		cfg.Caches = cache.New(cfg.CacheConfigOpts...)
+	if cfg.CacheSnapshotPath != "" {
+		client.cacheSnapshotPath = cfg.CacheSnapshotPath
+		if err = cache.LoadSnapshot(cfg.CacheSnapshotPath, client.Caches); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			client.Logger.Error("failed to load cache snapshot", slog.Any("err", err))
+		}
 	}
-	client.Caches = cfg.Caches
 
 	return client, nil
 }
@@ -1,12 +1,19 @@
 package bot
 
 import (
+	"context"
 	"log/slog"
+	"reflect"
 	"runtime/debug"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/gateway"
 	"github.com/disgoorg/disgo/httpserver"
+	"github.com/disgoorg/disgo/metrics"
+	"github.com/disgoorg/disgo/tracing"
 )
 
 var _ EventManager = (*eventManagerImpl)(nil)
@@ -23,6 +30,9 @@ func NewEventManager(client *Client, opts ...EventManagerConfigOpt) EventManager
 		asyncEventsEnabled: cfg.AsyncEventsEnabled,
 		gatewayHandlers:    cfg.GatewayHandlers,
 		httpServerHandler:  cfg.HTTPServerHandler,
+		webhookHandler:     cfg.WebhookEventHandler,
+		tracer:             cfg.Tracer,
+		dispatchDuration:   cfg.Metrics.Histogram("disgo_event_dispatch_duration_seconds", "Time it takes to dispatch an event to all event listeners.", nil, "event"),
 	}
 }
 
@@ -40,6 +50,9 @@ type EventManager interface {
 	// HandleHTTPEvent calls the HTTPServerEventHandler for the payload
 	HandleHTTPEvent(respondFunc httpserver.RespondFunc, event httpserver.EventInteractionCreate)
 
+	// HandleWebhookEvent calls the WebhookEventHandler for the payload
+	HandleWebhookEvent(payload discord.WebhookEventPayload)
+
 	// DispatchEvent dispatches a new Event to the Client's EventListener(s)
 	DispatchEvent(event Event)
 }
@@ -85,6 +98,14 @@ type Event interface {
 	SequenceNumber() int
 }
 
+// ContextEvent is an Event which carries a context.Context.
+// The EventManager sets it to the context of the tracing span the event is dispatched in.
+type ContextEvent interface {
+	Event
+	DispatchContext() context.Context
+	SetDispatchContext(ctx context.Context)
+}
+
 // GatewayEventHandler is used to handle Gateway Event(s)
 type GatewayEventHandler interface {
 	EventType() gateway.EventType
@@ -116,6 +137,11 @@ type HTTPServerEventHandler interface {
 	HandleHTTPEvent(client *Client, respondFunc httpserver.RespondFunc, event httpserver.EventInteractionCreate)
 }
 
+// WebhookEventHandler is used to handle events from Discord's Webhook Events
+type WebhookEventHandler interface {
+	HandleWebhookEvent(client *Client, payload discord.WebhookEventPayload)
+}
+
 type eventManagerImpl struct {
 	mu sync.Mutex
 
@@ -126,6 +152,9 @@ type eventManagerImpl struct {
 	asyncEventsEnabled bool
 	gatewayHandlers    map[gateway.EventType]GatewayEventHandler
 	httpServerHandler  HTTPServerEventHandler
+	webhookHandler     WebhookEventHandler
+	tracer             tracing.Tracer
+	dispatchDuration   metrics.Histogram
 }
 
 func (e *eventManagerImpl) HandleGatewayEvent(gateway gateway.Gateway, eventType gateway.EventType, sequenceNumber int, event gateway.EventData) {
@@ -144,6 +173,16 @@ func (e *eventManagerImpl) HandleHTTPEvent(respondFunc httpserver.RespondFunc, e
 	e.httpServerHandler.HandleHTTPEvent(e.client, respondFunc, event)
 }
 
+func (e *eventManagerImpl) HandleWebhookEvent(payload discord.WebhookEventPayload) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.webhookHandler == nil {
+		e.logger.Warn("no handler for webhook event found", slog.Any("event_type", payload.Event.Type))
+		return
+	}
+	e.webhookHandler.HandleWebhookEvent(e.client, payload)
+}
+
 func (e *eventManagerImpl) DispatchEvent(event Event) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -153,9 +192,30 @@ func (e *eventManagerImpl) DispatchEvent(event Event) {
 	}()
 	e.eventListenerMu.Lock()
 	defer e.eventListenerMu.Unlock()
+
+	name := eventName(event)
+	defer e.observeDispatch(name, time.Now())
+
+	ctx := context.Background()
+	ce, ok := event.(ContextEvent)
+	if ok {
+		ctx = ce.DispatchContext()
+	}
+	ctx, span := e.tracer.Start(ctx, "event "+name, tracing.String("disgo.event", name))
+	if ok {
+		ce.SetDispatchContext(ctx)
+	}
+
+	if !e.asyncEventsEnabled {
+		defer span.End()
+	}
+	// async listeners keep the span open until the last one of them returned
+	var wg sync.WaitGroup
 	for _, listener := range e.eventListeners {
 		if e.asyncEventsEnabled {
+			wg.Add(1)
 			go func() {
+				defer wg.Done()
 				defer func() {
 					if r := recover(); r != nil {
 						e.logger.Error("recovered from panic in event listener", slog.Any("arg", r), slog.String("stack", string(debug.Stack())))
@@ -168,6 +228,21 @@ func (e *eventManagerImpl) DispatchEvent(event Event) {
 		}
 		listener.OnEvent(event)
 	}
+	if e.asyncEventsEnabled {
+		go func() {
+			wg.Wait()
+			span.End()
+		}()
+	}
+}
+
+func (e *eventManagerImpl) observeDispatch(name string, start time.Time) {
+	e.dispatchDuration.Observe(time.Since(start).Seconds(), name)
+}
+
+// eventName returns the type name of the given Event, e.g. events.MessageCreate.
+func eventName(event Event) string {
+	return strings.TrimPrefix(reflect.TypeOf(event).String(), "*")
 }
 
 func (e *eventManagerImpl) AddEventListeners(listeners ...EventListener) {
@@ -4,11 +4,15 @@ import (
 	"log/slog"
 
 	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/metrics"
+	"github.com/disgoorg/disgo/tracing"
 )
 
 func defaultEventManagerConfig() eventManagerConfig {
 	return eventManagerConfig{
-		Logger: slog.Default(),
+		Logger:  slog.Default(),
+		Metrics: metrics.Noop,
+		Tracer:  tracing.Noop,
 	}
 }
 
@@ -16,9 +20,12 @@ type eventManagerConfig struct {
 	Logger             *slog.Logger
 	EventListeners     []EventListener
 	AsyncEventsEnabled bool
+	Metrics            metrics.Metrics
+	Tracer             tracing.Tracer
 
-	GatewayHandlers   map[gateway.EventType]GatewayEventHandler
-	HTTPServerHandler HTTPServerEventHandler
+	GatewayHandlers     map[gateway.EventType]GatewayEventHandler
+	HTTPServerHandler   HTTPServerEventHandler
+	WebhookEventHandler WebhookEventHandler
 }
 
 // EventManagerConfigOpt is a functional option for configuring an EventManager.
@@ -38,6 +45,20 @@ func WithEventManagerLogger(logger *slog.Logger) EventManagerConfigOpt {
 	}
 }
 
+// WithEventManagerMetrics sets the metrics.Metrics the EventManager records event dispatch durations to.
+func WithEventManagerMetrics(m metrics.Metrics) EventManagerConfigOpt {
+	return func(config *eventManagerConfig) {
+		config.Metrics = m
+	}
+}
+
+// WithEventManagerTracer sets the tracing.Tracer the EventManager starts a span with for every dispatched event.
+func WithEventManagerTracer(tracer tracing.Tracer) EventManagerConfigOpt {
+	return func(config *eventManagerConfig) {
+		config.Tracer = tracer
+	}
+}
+
 // WithListeners adds the given EventListener(s) to the eventManagerConfig.
 func WithListeners(listeners ...EventListener) EventManagerConfigOpt {
 	return func(config *eventManagerConfig) {
@@ -75,3 +96,10 @@ func WithHTTPServerHandler(handler HTTPServerEventHandler) EventManagerConfigOpt
 		config.HTTPServerHandler = handler
 	}
 }
+
+// WithWebhookEventHandler overrides the given WebhookEventHandler in the eventManagerConfig.
+func WithWebhookEventHandler(handler WebhookEventHandler) EventManagerConfigOpt {
+	return func(config *eventManagerConfig) {
+		config.WebhookEventHandler = handler
+	}
+}
@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"context"
+	"iter"
 	"log/slog"
 
+	"github.com/disgoorg/snowflake/v2"
+
 	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
@@ -14,6 +17,16 @@ func gatewayHandlerGuildCreate(client *bot.Client, sequenceNumber int, shardID i
 	wasUnready := client.Caches.IsGuildUnready(event.ID)
 	wasUnavailable := client.Caches.IsGuildUnavailable(event.ID)
 
+	// the guild is already cached if it was restored from a cache snapshot or cached before a new session was identified or it became unavailable.
+	// Drop the old entities which are missing in GUILD_CREATE, as they were deleted in the meantime.
+	if _, ok := client.Caches.Guild(event.ID); ok {
+		if wasUnavailable && !wasUnready {
+			// the channels of unready guilds are already removed on READY
+			removeGuildChannels(client, map[snowflake.ID]struct{}{event.ID: {}})
+		}
+		reconcileGuildEntities(client, event)
+	}
+
 	client.Caches.AddGuild(event.Guild)
 
 	for _, channel := range event.Channels {
@@ -108,6 +121,83 @@ func gatewayHandlerGuildCreate(client *bot.Client, sequenceNumber int, shardID i
 	}
 }
 
+// removeGuildEntities removes all entities of the guild from the cache, except the guild itself, its channels & its messages.
+func removeGuildEntities(client *bot.Client, guildID snowflake.ID) {
+	client.Caches.RemoveVoiceStatesByGuildID(guildID)
+	client.Caches.RemovePresencesByGuildID(guildID)
+	client.Caches.RemoveEmojisByGuildID(guildID)
+	client.Caches.RemoveStickersByGuildID(guildID)
+	client.Caches.RemoveRolesByGuildID(guildID)
+	client.Caches.RemoveMembersByGuildID(guildID)
+	client.Caches.RemoveStageInstancesByGuildID(guildID)
+	client.Caches.RemoveGuildScheduledEventsByGuildID(guildID)
+	client.Caches.RemoveGuildSoundboardSoundsByGuildID(guildID)
+}
+
+// removeGuildChannels removes the channels & threads of the given guilds and the members of the threads in a single pass over the channel cache.
+func removeGuildChannels(client *bot.Client, guildIDs map[snowflake.ID]struct{}) {
+	if len(guildIDs) == 0 {
+		return
+	}
+	var threadIDs []snowflake.ID
+	client.Caches.ChannelCache().RemoveIf(func(channel discord.GuildChannel) bool {
+		if _, ok := guildIDs[channel.GuildID()]; !ok {
+			return false
+		}
+		if _, ok := channel.(discord.GuildThread); ok {
+			threadIDs = append(threadIDs, channel.ID())
+		}
+		return true
+	})
+	for _, threadID := range threadIDs {
+		client.Caches.RemoveThreadMembersByThreadID(threadID)
+	}
+}
+
+// reconcileGuildEntities removes the cached entities of the guild which are missing in its GUILD_CREATE.
+// Members & presences are kept, as GUILD_CREATE only contains a part of them. Channels are handled by removeGuildChannels.
+func reconcileGuildEntities(client *bot.Client, event gateway.EventGuildCreate) {
+	removeMissing(client.Caches.Roles(event.ID), event.Roles, func(role discord.Role) snowflake.ID { return role.ID }, func(id snowflake.ID) {
+		client.Caches.RemoveRole(event.ID, id)
+	})
+	removeMissing(client.Caches.Emojis(event.ID), event.Emojis, func(emoji discord.Emoji) snowflake.ID { return emoji.ID }, func(id snowflake.ID) {
+		client.Caches.RemoveEmoji(event.ID, id)
+	})
+	removeMissing(client.Caches.Stickers(event.ID), event.Stickers, func(sticker discord.Sticker) snowflake.ID { return sticker.ID }, func(id snowflake.ID) {
+		client.Caches.RemoveSticker(event.ID, id)
+	})
+	removeMissing(client.Caches.VoiceStates(event.ID), event.VoiceStates, func(voiceState discord.VoiceState) snowflake.ID { return voiceState.UserID }, func(id snowflake.ID) {
+		client.Caches.RemoveVoiceState(event.ID, id)
+	})
+	removeMissing(client.Caches.StageInstances(event.ID), event.StageInstances, func(stageInstance discord.StageInstance) snowflake.ID { return stageInstance.ID }, func(id snowflake.ID) {
+		client.Caches.RemoveStageInstance(event.ID, id)
+	})
+	removeMissing(client.Caches.GuildScheduledEvents(event.ID), event.GuildScheduledEvents, func(guildScheduledEvent discord.GuildScheduledEvent) snowflake.ID { return guildScheduledEvent.ID }, func(id snowflake.ID) {
+		client.Caches.RemoveGuildScheduledEvent(event.ID, id)
+	})
+	removeMissing(client.Caches.GuildSoundboardSounds(event.ID), event.SoundboardSounds, func(sound discord.SoundboardSound) snowflake.ID { return sound.SoundID }, func(id snowflake.ID) {
+		client.Caches.RemoveGuildSoundboardSound(event.ID, id)
+	})
+}
+
+// removeMissing calls remove with the ID of every cached entity which is not part of the given entities.
+func removeMissing[T any](cached iter.Seq[T], entities []T, id func(T) snowflake.ID, remove func(snowflake.ID)) {
+	ids := make(map[snowflake.ID]struct{}, len(entities))
+	for _, entity := range entities {
+		ids[id(entity)] = struct{}{}
+	}
+
+	var missing []snowflake.ID
+	for entity := range cached {
+		if _, ok := ids[id(entity)]; !ok {
+			missing = append(missing, id(entity))
+		}
+	}
+	for _, entityID := range missing {
+		remove(entityID)
+	}
+}
+
 func gatewayHandlerGuildUpdate(client *bot.Client, sequenceNumber int, shardID int, event gateway.EventGuildUpdate) {
 	oldGuild, _ := client.Caches.Guild(event.ID)
 	client.Caches.AddGuild(event.Guild)
@@ -128,22 +218,8 @@ func gatewayHandlerGuildDelete(client *bot.Client, sequenceNumber int, shardID i
 	}
 
 	guild, _ := client.Caches.RemoveGuild(event.ID)
-	client.Caches.RemoveVoiceStatesByGuildID(event.ID)
-	client.Caches.RemovePresencesByGuildID(event.ID)
-	// TODO: figure out a better way to remove thread members from cache via guild id without requiring cached GuildThreads
-	for channel := range client.Caches.Channels() {
-		if guildThread, ok := channel.(discord.GuildThread); ok && guildThread.GuildID() == event.ID {
-			client.Caches.RemoveThreadMembersByThreadID(guildThread.ID())
-		}
-	}
-	client.Caches.RemoveChannelsByGuildID(event.ID)
-	client.Caches.RemoveEmojisByGuildID(event.ID)
-	client.Caches.RemoveStickersByGuildID(event.ID)
-	client.Caches.RemoveRolesByGuildID(event.ID)
-	client.Caches.RemoveMembersByGuildID(event.ID)
-	client.Caches.RemoveStageInstancesByGuildID(event.ID)
-	client.Caches.RemoveGuildScheduledEventsByGuildID(event.ID)
-	client.Caches.RemoveGuildSoundboardSoundsByGuildID(event.ID)
+	removeGuildEntities(client, event.ID)
+	removeGuildChannels(client, map[snowflake.ID]struct{}{event.ID: {}})
 	client.Caches.RemoveMessagesByGuildID(event.ID)
 
 	genericGuildEvent := &events.GenericGuild{
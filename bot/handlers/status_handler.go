@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"github.com/disgoorg/snowflake/v2"
+
 	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/events"
 	"github.com/disgoorg/disgo/gateway"
@@ -23,9 +25,12 @@ func gatewayHandlerHeartbeatAck(client *bot.Client, sequenceNumber int, shardID
 func gatewayHandlerReady(client *bot.Client, sequenceNumber int, shardID int, event gateway.EventReady) {
 	client.Caches.SetSelfUser(event.User)
 
+	guildIDs := make(map[snowflake.ID]struct{}, len(event.Guilds))
 	for _, guild := range event.Guilds {
+		guildIDs[guild.ID] = struct{}{}
 		client.Caches.SetGuildUnready(guild.ID, true)
 	}
+	removeStaleGuilds(client, event.Shard[1], shardID, guildIDs)
 
 	client.EventManager.DispatchEvent(&events.Ready{
 		GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
@@ -33,6 +38,47 @@ func gatewayHandlerReady(client *bot.Client, sequenceNumber int, shardID int, ev
 	})
 }
 
+// removeStaleGuilds removes the guilds of the shard which are cached but not part of the READY event.
+// They are left over from a restored cache snapshot or a previous session, but the bot is no longer in them.
+// The channels of the cached guilds in READY are removed as well, as they are re-added by GUILD_CREATE.
+func removeStaleGuilds(client *bot.Client, shardCount int, shardID int, guildIDs map[snowflake.ID]struct{}) {
+	stale := func(guildID snowflake.ID) bool {
+		if _, ok := guildIDs[guildID]; ok {
+			return false
+		}
+		return shardCount <= 1 || gateway.ShardIDByGuild(guildID, shardCount) == shardID
+	}
+
+	// GUILD_CREATE contains all channels of a guild, so the channels of the cached guilds in READY are dropped as well and re-added on GUILD_CREATE.
+	channelGuildIDs := map[snowflake.ID]struct{}{}
+	var staleGuildIDs []snowflake.ID
+	for guild := range client.Caches.Guilds() {
+		if _, ok := guildIDs[guild.ID]; ok {
+			channelGuildIDs[guild.ID] = struct{}{}
+		} else if stale(guild.ID) {
+			staleGuildIDs = append(staleGuildIDs, guild.ID)
+			channelGuildIDs[guild.ID] = struct{}{}
+		}
+	}
+	for _, guildID := range staleGuildIDs {
+		client.Caches.RemoveGuild(guildID)
+		removeGuildEntities(client, guildID)
+		client.Caches.RemoveMessagesByGuildID(guildID)
+	}
+	removeGuildChannels(client, channelGuildIDs)
+
+	for _, guildID := range client.Caches.UnreadyGuildIDs() {
+		if stale(guildID) {
+			client.Caches.SetGuildUnready(guildID, false)
+		}
+	}
+	for _, guildID := range client.Caches.UnavailableGuildIDs() {
+		if stale(guildID) {
+			client.Caches.SetGuildUnavailable(guildID, false)
+		}
+	}
+}
+
 func gatewayHandlerResumed(client *bot.Client, sequenceNumber int, shardID int, _ gateway.EventData) {
 	client.EventManager.DispatchEvent(&events.Resumed{
 		GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+func TestReadyRefreshesSnapshot(t *testing.T) {
+	snapshot := cache.New(cache.WithCaches(cache.FlagsAll))
+	for _, guildID := range []snowflake.ID{1, 2} {
+		guild := discord.Guild{ID: guildID, Name: "guild"}
+		snapshot.AddGuild(guild)
+		snapshot.AddRole(discord.Role{ID: guildID*10 + 1, GuildID: guild.ID, Name: "kept"})
+		snapshot.AddRole(discord.Role{ID: guildID*10 + 2, GuildID: guild.ID, Name: "deleted"})
+		snapshot.AddMember(discord.Member{User: discord.User{ID: 100}, GuildID: guild.ID})
+		snapshot.AddMember(discord.Member{User: discord.User{ID: 101}, GuildID: guild.ID})
+		snapshot.AddChannel(testChannel(t, guildID*10+3, guildID))
+	}
+	var buf bytes.Buffer
+	if err := cache.WriteSnapshot(&buf, snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	client := &bot.Client{Logger: slog.New(slog.DiscardHandler), Caches: cache.New(cache.WithCaches(cache.FlagsAll))}
+	client.EventManager = bot.NewEventManager(client)
+	client.MemberChunkingManager = bot.NewMemberChunkingManager(client, client.Logger, bot.MemberChunkingFilterNone)
+	if err := cache.ReadSnapshot(&buf, client.Caches); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := client.Caches.Role(1, 12); !ok {
+		t.Fatal("expected role to be restored from the snapshot")
+	}
+
+	// the bot was removed from guild 2 while it was offline
+	gatewayHandlerReady(client, 1, 0, gateway.EventReady{
+		Guilds: []discord.UnavailableGuild{{ID: 1, Unavailable: true}},
+	})
+	if _, ok := client.Caches.Guild(2); ok {
+		t.Error("expected guild missing from READY to be removed")
+	}
+	if _, ok := client.Caches.Member(2, 100); ok {
+		t.Error("expected member of guild missing from READY to be removed")
+	}
+	if !client.Caches.IsGuildUnready(1) {
+		t.Error("expected guild from READY to be unready")
+	}
+
+	if _, ok := client.Caches.Channel(13); ok {
+		t.Error("expected channel of guild from READY to be removed until GUILD_CREATE")
+	}
+
+	// role 12 of guild 1 was deleted while the bot was offline
+	gatewayHandlerGuildCreate(client, 2, 0, gateway.EventGuildCreate{GatewayGuild: discord.GatewayGuild{
+		RestGuild: discord.RestGuild{
+			Guild: discord.Guild{ID: 1, Name: "guild"},
+			Roles: []discord.Role{{ID: 11, Name: "kept"}},
+		},
+		Members:  []discord.Member{{User: discord.User{ID: 100}}},
+		Channels: []discord.GuildChannel{testChannel(t, 13, 0)},
+	}})
+	if _, ok := client.Caches.Channel(13); !ok {
+		t.Error("expected channel from GUILD_CREATE to be cached")
+	}
+	if _, ok := client.Caches.Role(1, 11); !ok {
+		t.Error("expected role from GUILD_CREATE to be cached")
+	}
+	if _, ok := client.Caches.Role(1, 12); ok {
+		t.Error("expected restored role missing from GUILD_CREATE to be removed")
+	}
+	if _, ok := client.Caches.Member(1, 100); !ok {
+		t.Error("expected member from GUILD_CREATE to be cached")
+	}
+	if _, ok := client.Caches.Member(1, 101); !ok {
+		t.Error("expected restored member missing from GUILD_CREATE to be kept")
+	}
+	if client.Caches.IsGuildUnready(1) {
+		t.Error("expected guild to be ready after GUILD_CREATE")
+	}
+}
+
+func testChannel(t *testing.T, id snowflake.ID, guildID snowflake.ID) discord.GuildChannel {
+	t.Helper()
+	var v discord.UnmarshalChannel
+	if err := json.Unmarshal([]byte(`{"id":"`+id.String()+`","guild_id":"`+guildID.String()+`","type":0,"name":"general"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v.Channel.(discord.GuildChannel)
+}
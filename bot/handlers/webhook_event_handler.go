@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"github.com/disgoorg/json/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+// GetWebhookEventHandler returns the default handler for Discord's Webhook Events which gets passed into bot.BuildClient
+func GetWebhookEventHandler() bot.WebhookEventHandler {
+	return &webhookEventHandler{}
+}
+
+var _ bot.WebhookEventHandler = (*webhookEventHandler)(nil)
+
+type webhookEventHandler struct{}
+
+func (h *webhookEventHandler) HandleWebhookEvent(client *bot.Client, payload discord.WebhookEventPayload) {
+	if payload.Event == nil {
+		return
+	}
+
+	genericEvent := events.NewGenericEvent(client, -1, -1)
+	genericWebhookEvent := &events.GenericWebhookEvent{
+		GenericEvent:  genericEvent,
+		ApplicationID: payload.ApplicationID,
+		Timestamp:     payload.Event.Timestamp,
+	}
+
+	switch data := payload.Event.Data.(type) {
+	case discord.WebhookEventApplicationAuthorized:
+		client.EventManager.DispatchEvent(&events.ApplicationAuthorized{
+			GenericWebhookEvent:               genericWebhookEvent,
+			WebhookEventApplicationAuthorized: data,
+		})
+
+	case discord.WebhookEventApplicationDeauthorized:
+		client.EventManager.DispatchEvent(&events.ApplicationDeauthorized{
+			GenericWebhookEvent:                 genericWebhookEvent,
+			WebhookEventApplicationDeauthorized: data,
+		})
+
+	case discord.WebhookEventEntitlementCreate:
+		client.EventManager.DispatchEvent(&events.WebhookEntitlementCreate{
+			GenericWebhookEvent:           genericWebhookEvent,
+			WebhookEventEntitlementCreate: data,
+		})
+
+	case discord.WebhookEventUnknown:
+		client.EventManager.DispatchEvent(&events.UnknownWebhookEvent{
+			GenericWebhookEvent: genericWebhookEvent,
+			Type:                payload.Event.Type,
+			Data:                json.RawMessage(data),
+		})
+	}
+}
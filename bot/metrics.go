@@ -0,0 +1,30 @@
+package bot
+
+import (
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/metrics"
+)
+
+// registerCacheMetrics registers a gauge for the size of each cache in the given cache.Caches.
+func registerCacheMetrics(m metrics.Metrics, caches cache.Caches) {
+	sizes := map[string]func() int{
+		"guilds":                  caches.GuildsLen,
+		"channels":                caches.ChannelsLen,
+		"stage_instances":         caches.StageInstancesAllLen,
+		"guild_scheduled_events":  caches.GuildScheduledEventsAllLen,
+		"guild_soundboard_sounds": caches.GuildSoundboardSoundsAllLen,
+		"roles":                   caches.RolesAllLen,
+		"members":                 caches.MembersAllLen,
+		"thread_members":          caches.ThreadMembersAllLen,
+		"presences":               caches.PresencesAllLen,
+		"voice_states":            caches.VoiceStatesAllLen,
+		"messages":                caches.MessagesAllLen,
+		"emojis":                  caches.EmojisAllLen,
+		"stickers":                caches.StickersAllLen,
+	}
+	for name, size := range sizes {
+		m.GaugeFunc("disgo_cache_entries", "Number of entries in each cache.", metrics.Labels{"cache": name}, func() float64 {
+			return float64(size())
+		})
+	}
+}
@@ -25,48 +25,67 @@ func defaultConfig() config {
 }
 
 type config struct {
-	CacheFlags Flags
+	CacheFlags  Flags
+	Indexes     Indexes
+	CopyOnWrite bool
+
+	CompactMembers bool
+
+	RedisClient *RedisClient
 
 	SelfUserCache SelfUserCache
 
-	GuildCache       GuildCache
-	GuildCachePolicy Policy[discord.Guild]
+	GuildCache         GuildCache
+	GuildCachePolicy   Policy[discord.Guild]
+	GuildCacheEviction *Eviction[discord.Guild]
 
-	ChannelCache       ChannelCache
-	ChannelCachePolicy Policy[discord.GuildChannel]
+	ChannelCache         ChannelCache
+	ChannelCachePolicy   Policy[discord.GuildChannel]
+	ChannelCacheEviction *Eviction[discord.GuildChannel]
 
-	StageInstanceCache       StageInstanceCache
-	StageInstanceCachePolicy Policy[discord.StageInstance]
+	StageInstanceCache         StageInstanceCache
+	StageInstanceCachePolicy   Policy[discord.StageInstance]
+	StageInstanceCacheEviction *Eviction[discord.StageInstance]
 
-	GuildScheduledEventCache       GuildScheduledEventCache
-	GuildScheduledEventCachePolicy Policy[discord.GuildScheduledEvent]
+	GuildScheduledEventCache         GuildScheduledEventCache
+	GuildScheduledEventCachePolicy   Policy[discord.GuildScheduledEvent]
+	GuildScheduledEventCacheEviction *Eviction[discord.GuildScheduledEvent]
 
-	GuildSoundboardSoundCache       GuildSoundboardSoundCache
-	GuildSoundboardSoundCachePolicy Policy[discord.SoundboardSound]
+	GuildSoundboardSoundCache         GuildSoundboardSoundCache
+	GuildSoundboardSoundCachePolicy   Policy[discord.SoundboardSound]
+	GuildSoundboardSoundCacheEviction *Eviction[discord.SoundboardSound]
 
-	RoleCache       RoleCache
-	RoleCachePolicy Policy[discord.Role]
+	RoleCache         RoleCache
+	RoleCachePolicy   Policy[discord.Role]
+	RoleCacheEviction *Eviction[discord.Role]
 
-	MemberCache       MemberCache
-	MemberCachePolicy Policy[discord.Member]
+	MemberCache         MemberCache
+	MemberCachePolicy   Policy[discord.Member]
+	MemberCacheEviction *Eviction[discord.Member]
 
-	ThreadMemberCache       ThreadMemberCache
-	ThreadMemberCachePolicy Policy[discord.ThreadMember]
+	ThreadMemberCache         ThreadMemberCache
+	ThreadMemberCachePolicy   Policy[discord.ThreadMember]
+	ThreadMemberCacheEviction *Eviction[discord.ThreadMember]
 
-	PresenceCache       PresenceCache
-	PresenceCachePolicy Policy[discord.Presence]
+	PresenceCache         PresenceCache
+	PresenceCachePolicy   Policy[discord.Presence]
+	PresenceCacheEviction *Eviction[discord.Presence]
 
-	VoiceStateCache       VoiceStateCache
-	VoiceStateCachePolicy Policy[discord.VoiceState]
+	VoiceStateCache         VoiceStateCache
+	VoiceStateCachePolicy   Policy[discord.VoiceState]
+	VoiceStateCacheEviction *Eviction[discord.VoiceState]
 
-	MessageCache       MessageCache
-	MessageCachePolicy Policy[discord.Message]
+	MessageCache         MessageCache
+	MessageCachePolicy   Policy[discord.Message]
+	MessageCacheEviction *Eviction[discord.Message]
 
-	EmojiCache       EmojiCache
-	EmojiCachePolicy Policy[discord.Emoji]
+	EmojiCache         EmojiCache
+	EmojiCachePolicy   Policy[discord.Emoji]
+	EmojiCacheEviction *Eviction[discord.Emoji]
 
-	StickerCache       StickerCache
-	StickerCachePolicy Policy[discord.Sticker]
+	StickerCache         StickerCache
+	StickerCachePolicy   Policy[discord.Sticker]
+	StickerCacheEviction *Eviction[discord.Sticker]
 }
 
 // ConfigOpt is a type alias for a function that takes a config and is used to configure your Caches.
@@ -80,46 +99,83 @@ func (c *config) apply(opts []ConfigOpt) {
 		c.SelfUserCache = NewSelfUserCache()
 	}
 	if c.GuildCache == nil {
-		c.GuildCache = NewGuildCache(NewCache[discord.Guild](c.CacheFlags, FlagGuilds, c.GuildCachePolicy), NewSet[snowflake.ID](), NewSet[snowflake.ID]())
+		c.GuildCache = NewGuildCache(newCache(c, "guilds", FlagGuilds, c.GuildCachePolicy, c.GuildCacheEviction, func(guild discord.Guild) snowflake.ID { return guild.ID }), NewSet[snowflake.ID](), NewSet[snowflake.ID]())
 	}
 	if c.ChannelCache == nil {
-		c.ChannelCache = NewChannelCache(NewCache[discord.GuildChannel](c.CacheFlags, FlagChannels, c.ChannelCachePolicy))
+		c.ChannelCache = NewChannelCache(newCache(c, "channels", FlagChannels, c.ChannelCachePolicy, c.ChannelCacheEviction, func(channel discord.GuildChannel) snowflake.ID { return channel.ID() }))
 	}
 	if c.StageInstanceCache == nil {
-		c.StageInstanceCache = NewStageInstanceCache(NewGroupedCache[discord.StageInstance](c.CacheFlags, FlagStageInstances, c.StageInstanceCachePolicy))
+		c.StageInstanceCache = NewStageInstanceCache(newGroupedCache(c, "stage_instances", FlagStageInstances, c.StageInstanceCachePolicy, c.StageInstanceCacheEviction, func(stageInstance discord.StageInstance) snowflake.ID { return stageInstance.ID }))
 	}
 	if c.GuildScheduledEventCache == nil {
-		c.GuildScheduledEventCache = NewGuildScheduledEventCache(NewGroupedCache[discord.GuildScheduledEvent](c.CacheFlags, FlagGuildScheduledEvents, c.GuildScheduledEventCachePolicy))
+		c.GuildScheduledEventCache = NewGuildScheduledEventCache(newGroupedCache(c, "guild_scheduled_events", FlagGuildScheduledEvents, c.GuildScheduledEventCachePolicy, c.GuildScheduledEventCacheEviction, func(guildScheduledEvent discord.GuildScheduledEvent) snowflake.ID { return guildScheduledEvent.ID }))
 	}
 	if c.GuildSoundboardSoundCache == nil {
-		c.GuildSoundboardSoundCache = NewGuildSoundboardSoundCache(NewGroupedCache[discord.SoundboardSound](c.CacheFlags, FlagGuildSoundboardSounds, c.GuildSoundboardSoundCachePolicy))
+		c.GuildSoundboardSoundCache = NewGuildSoundboardSoundCache(newGroupedCache(c, "guild_soundboard_sounds", FlagGuildSoundboardSounds, c.GuildSoundboardSoundCachePolicy, c.GuildSoundboardSoundCacheEviction, func(sound discord.SoundboardSound) snowflake.ID { return sound.SoundID }))
 	}
 	if c.RoleCache == nil {
-		c.RoleCache = NewRoleCache(NewGroupedCache[discord.Role](c.CacheFlags, FlagRoles, c.RoleCachePolicy))
+		c.RoleCache = NewRoleCache(newGroupedCache(c, "roles", FlagRoles, c.RoleCachePolicy, c.RoleCacheEviction, func(role discord.Role) snowflake.ID { return role.ID }))
 	}
 	if c.MemberCache == nil {
-		c.MemberCache = NewMemberCache(NewGroupedCache[discord.Member](c.CacheFlags, FlagMembers, c.MemberCachePolicy))
+		idFunc := func(member discord.Member) snowflake.ID { return member.User.ID }
+		if c.CompactMembers && c.RedisClient == nil && c.MemberCacheEviction == nil {
+			c.MemberCache = NewMemberCache(NewObservableGroupedCache(NewCompactMemberGroupedCache(c.CacheFlags, FlagMembers, c.MemberCachePolicy), idFunc))
+		} else {
+			c.MemberCache = NewMemberCache(newGroupedCache(c, "members", FlagMembers, c.MemberCachePolicy, c.MemberCacheEviction, idFunc))
+		}
 	}
 	if c.ThreadMemberCache == nil {
-		c.ThreadMemberCache = NewThreadMemberCache(NewGroupedCache[discord.ThreadMember](c.CacheFlags, FlagThreadMembers, c.ThreadMemberCachePolicy))
+		c.ThreadMemberCache = NewThreadMemberCache(newGroupedCache(c, "thread_members", FlagThreadMembers, c.ThreadMemberCachePolicy, c.ThreadMemberCacheEviction, func(threadMember discord.ThreadMember) snowflake.ID { return threadMember.UserID }))
 	}
 	if c.PresenceCache == nil {
-		c.PresenceCache = NewPresenceCache(NewGroupedCache[discord.Presence](c.CacheFlags, FlagPresences, c.PresenceCachePolicy))
+		c.PresenceCache = NewPresenceCache(newGroupedCache(c, "presences", FlagPresences, c.PresenceCachePolicy, c.PresenceCacheEviction, func(presence discord.Presence) snowflake.ID { return presence.PresenceUser.ID }))
 	}
 	if c.VoiceStateCache == nil {
-		c.VoiceStateCache = NewVoiceStateCache(NewGroupedCache[discord.VoiceState](c.CacheFlags, FlagVoiceStates, c.VoiceStateCachePolicy))
+		c.VoiceStateCache = NewVoiceStateCache(newGroupedCache(c, "voice_states", FlagVoiceStates, c.VoiceStateCachePolicy, c.VoiceStateCacheEviction, func(voiceState discord.VoiceState) snowflake.ID { return voiceState.UserID }))
 	}
 	if c.MessageCache == nil {
-		c.MessageCache = NewMessageCache(NewGroupedCache[discord.Message](c.CacheFlags, FlagMessages, c.MessageCachePolicy))
+		c.MessageCache = NewMessageCache(newGroupedCache(c, "messages", FlagMessages, c.MessageCachePolicy, c.MessageCacheEviction, func(message discord.Message) snowflake.ID { return message.ID }))
 	}
 	if c.EmojiCache == nil {
-		c.EmojiCache = NewEmojiCache(NewGroupedCache[discord.Emoji](c.CacheFlags, FlagEmojis, c.EmojiCachePolicy))
+		c.EmojiCache = NewEmojiCache(newGroupedCache(c, "emojis", FlagEmojis, c.EmojiCachePolicy, c.EmojiCacheEviction, func(emoji discord.Emoji) snowflake.ID { return emoji.ID }))
 	}
 	if c.StickerCache == nil {
-		c.StickerCache = NewStickerCache(NewGroupedCache[discord.Sticker](c.CacheFlags, FlagStickers, c.StickerCachePolicy))
+		c.StickerCache = NewStickerCache(newGroupedCache(c, "stickers", FlagStickers, c.StickerCachePolicy, c.StickerCacheEviction, func(sticker discord.Sticker) snowflake.ID { return sticker.ID }))
 	}
 }
 
+// newCache returns an ObservableCache wrapping a redis Cache if a RedisClient is configured, an eviction Cache if an Eviction is configured, a copy-on-write Cache if enabled and the default Cache otherwise.
+func newCache[T any](c *config, name string, neededFlags Flags, policy Policy[T], eviction *Eviction[T], idFunc func(entity T) snowflake.ID) Cache[T] {
+	observable := NewObservableCache[T](nil, idFunc)
+	switch {
+	case c.RedisClient != nil:
+		observable.cache = NewRedisCache[T](c.RedisClient, name, c.CacheFlags, neededFlags, policy)
+	case eviction != nil:
+		observable.cache = NewEvictionCache[T](c.CacheFlags, neededFlags, policy, observable.evict(*eviction))
+	case c.CopyOnWrite:
+		observable.cache = NewCopyOnWriteCache[T](c.CacheFlags, neededFlags, policy)
+	default:
+		observable.cache = NewCache[T](c.CacheFlags, neededFlags, policy)
+	}
+	return observable
+}
+
+// newGroupedCache returns an ObservableGroupedCache wrapping a redis GroupedCache if a RedisClient is configured, an eviction GroupedCache if an Eviction is configured, a copy-on-write GroupedCache if enabled and the default GroupedCache otherwise.
+func newGroupedCache[T any](c *config, name string, neededFlags Flags, policy Policy[T], eviction *Eviction[T], idFunc func(entity T) snowflake.ID) GroupedCache[T] {
+	observable := NewObservableGroupedCache[T](nil, idFunc)
+	switch {
+	case c.RedisClient != nil:
+		observable.cache = NewRedisGroupedCache[T](c.RedisClient, name, c.CacheFlags, neededFlags, policy)
+	case eviction != nil:
+		observable.cache = NewEvictionGroupedCache[T](c.CacheFlags, neededFlags, policy, observable.evict(*eviction))
+	case c.CopyOnWrite:
+		observable.cache = NewCopyOnWriteGroupedCache[T](c.CacheFlags, neededFlags, policy)
+	default:
+		observable.cache = NewGroupedCache[T](c.CacheFlags, neededFlags, policy)
+	}
+	return observable
+}
+
 // WithCaches sets the Flags of the config.
 func WithCaches(flags ...Flags) ConfigOpt {
 	return func(config *config) {
@@ -127,6 +183,37 @@ func WithCaches(flags ...Flags) ConfigOpt {
 	}
 }
 
+// WithIndexes enables the given Indexes of the built-in caches. Indexes are only maintained for caches which are not configured with a custom cache.
+func WithIndexes(indexes ...Indexes) ConfigOpt {
+	return func(config *config) {
+		config.Indexes = config.Indexes.Add(indexes...)
+	}
+}
+
+// WithCopyOnWriteCaches uses NewCopyOnWriteCache & NewCopyOnWriteGroupedCache for all caches which are not configured with a custom cache, Eviction or WithRedis.
+// This makes reads lock-free & long iterations no longer block the gateway handlers, at the cost of slower writes.
+func WithCopyOnWriteCaches() ConfigOpt {
+	return func(config *config) {
+		config.CopyOnWrite = true
+	}
+}
+
+// WithCompactMemberCache uses NewCompactMemberGroupedCache for the members if no custom MemberCache, member Eviction or WithRedis is used.
+// It takes precedence over WithCopyOnWriteCaches & greatly reduces the memory used by members, at the cost of slightly slower reads & writes.
+func WithCompactMemberCache() ConfigOpt {
+	return func(config *config) {
+		config.CompactMembers = true
+	}
+}
+
+// WithRedis stores all entities which are not configured with a custom cache in a Redis compatible server using the given RedisClient.
+// This allows multiple processes to share their state. The self user, unready & unavailable guilds are still kept in memory.
+func WithRedis(client *RedisClient) ConfigOpt {
+	return func(config *config) {
+		config.RedisClient = client
+	}
+}
+
 // WithSelfUserCache sets the SelfUserCache of the config.
 func WithSelfUserCache(cache SelfUserCache) ConfigOpt {
 	return func(config *config) {
@@ -141,6 +228,13 @@ func WithGuildCachePolicy(policy Policy[discord.Guild]) ConfigOpt {
 	}
 }
 
+// WithGuildCacheEviction sets the Eviction[discord.Guild] of the config. It is ignored if a custom GuildCache is set or WithRedis is used.
+func WithGuildCacheEviction(eviction Eviction[discord.Guild]) ConfigOpt {
+	return func(config *config) {
+		config.GuildCacheEviction = &eviction
+	}
+}
+
 // WithGuildCache sets the GuildCache of the config.
 func WithGuildCache(guildCache GuildCache) ConfigOpt {
 	return func(config *config) {
@@ -155,6 +249,13 @@ func WithChannelCachePolicy(policy Policy[discord.GuildChannel]) ConfigOpt {
 	}
 }
 
+// WithChannelCacheEviction sets the Eviction[discord.GuildChannel] of the config. It is ignored if a custom ChannelCache is set or WithRedis is used.
+func WithChannelCacheEviction(eviction Eviction[discord.GuildChannel]) ConfigOpt {
+	return func(config *config) {
+		config.ChannelCacheEviction = &eviction
+	}
+}
+
 // WithChannelCache sets the ChannelCache of the config.
 func WithChannelCache(channelCache ChannelCache) ConfigOpt {
 	return func(config *config) {
@@ -169,6 +270,13 @@ func WithStageInstanceCachePolicy(policy Policy[discord.StageInstance]) ConfigOp
 	}
 }
 
+// WithStageInstanceCacheEviction sets the Eviction[discord.StageInstance] of the config. It is ignored if a custom StageInstanceCache is set or WithRedis is used.
+func WithStageInstanceCacheEviction(eviction Eviction[discord.StageInstance]) ConfigOpt {
+	return func(config *config) {
+		config.StageInstanceCacheEviction = &eviction
+	}
+}
+
 // WithStageInstanceCache sets the StageInstanceCache of the config.
 func WithStageInstanceCache(stageInstanceCache StageInstanceCache) ConfigOpt {
 	return func(config *config) {
@@ -183,6 +291,13 @@ func WithGuildScheduledEventCachePolicy(policy Policy[discord.GuildScheduledEven
 	}
 }
 
+// WithGuildScheduledEventCacheEviction sets the Eviction[discord.GuildScheduledEvent] of the config. It is ignored if a custom GuildScheduledEventCache is set or WithRedis is used.
+func WithGuildScheduledEventCacheEviction(eviction Eviction[discord.GuildScheduledEvent]) ConfigOpt {
+	return func(config *config) {
+		config.GuildScheduledEventCacheEviction = &eviction
+	}
+}
+
 // WithGuildScheduledEventCache sets the GuildScheduledEventCache of the config.
 func WithGuildScheduledEventCache(guildScheduledEventCache GuildScheduledEventCache) ConfigOpt {
 	return func(config *config) {
@@ -197,6 +312,13 @@ func WithGuildSoundboardSoundCachePolicy(policy Policy[discord.SoundboardSound])
 	}
 }
 
+// WithGuildSoundboardSoundCacheEviction sets the Eviction[discord.SoundboardSound] of the config. It is ignored if a custom GuildSoundboardSoundCache is set or WithRedis is used.
+func WithGuildSoundboardSoundCacheEviction(eviction Eviction[discord.SoundboardSound]) ConfigOpt {
+	return func(config *config) {
+		config.GuildSoundboardSoundCacheEviction = &eviction
+	}
+}
+
 // WithGuildSoundboardSoundCache sets the GuildSoundboardSoundCache of the config.
 func WithGuildSoundboardSoundCache(guildSoundboardSoundCache GuildSoundboardSoundCache) ConfigOpt {
 	return func(config *config) {
@@ -211,6 +333,13 @@ func WithRoleCachePolicy(policy Policy[discord.Role]) ConfigOpt {
 	}
 }
 
+// WithRoleCacheEviction sets the Eviction[discord.Role] of the config. It is ignored if a custom RoleCache is set or WithRedis is used.
+func WithRoleCacheEviction(eviction Eviction[discord.Role]) ConfigOpt {
+	return func(config *config) {
+		config.RoleCacheEviction = &eviction
+	}
+}
+
 // WithRoleCache sets the RoleCache of the config.
 func WithRoleCache(roleCache RoleCache) ConfigOpt {
 	return func(config *config) {
@@ -225,6 +354,13 @@ func WithMemberCachePolicy(policy Policy[discord.Member]) ConfigOpt {
 	}
 }
 
+// WithMemberCacheEviction sets the Eviction[discord.Member] of the config. It is ignored if a custom MemberCache is set or WithRedis is used.
+func WithMemberCacheEviction(eviction Eviction[discord.Member]) ConfigOpt {
+	return func(config *config) {
+		config.MemberCacheEviction = &eviction
+	}
+}
+
 // WithMemberCache sets the MemberCache of the config.
 func WithMemberCache(memberCache MemberCache) ConfigOpt {
 	return func(config *config) {
@@ -239,6 +375,13 @@ func WithThreadMemberCachePolicy(policy Policy[discord.ThreadMember]) ConfigOpt
 	}
 }
 
+// WithThreadMemberCacheEviction sets the Eviction[discord.ThreadMember] of the config. It is ignored if a custom ThreadMemberCache is set or WithRedis is used.
+func WithThreadMemberCacheEviction(eviction Eviction[discord.ThreadMember]) ConfigOpt {
+	return func(config *config) {
+		config.ThreadMemberCacheEviction = &eviction
+	}
+}
+
 // WithThreadMemberCache sets the ThreadMemberCache of the config.
 func WithThreadMemberCache(threadMemberCache ThreadMemberCache) ConfigOpt {
 	return func(config *config) {
@@ -253,6 +396,13 @@ func WithPresenceCachePolicy(policy Policy[discord.Presence]) ConfigOpt {
 	}
 }
 
+// WithPresenceCacheEviction sets the Eviction[discord.Presence] of the config. It is ignored if a custom PresenceCache is set or WithRedis is used.
+func WithPresenceCacheEviction(eviction Eviction[discord.Presence]) ConfigOpt {
+	return func(config *config) {
+		config.PresenceCacheEviction = &eviction
+	}
+}
+
 // WithPresenceCache sets the PresenceCache of the config.
 func WithPresenceCache(presenceCache PresenceCache) ConfigOpt {
 	return func(config *config) {
@@ -267,6 +417,13 @@ func WithVoiceStateCachePolicy(policy Policy[discord.VoiceState]) ConfigOpt {
 	}
 }
 
+// WithVoiceStateCacheEviction sets the Eviction[discord.VoiceState] of the config. It is ignored if a custom VoiceStateCache is set or WithRedis is used.
+func WithVoiceStateCacheEviction(eviction Eviction[discord.VoiceState]) ConfigOpt {
+	return func(config *config) {
+		config.VoiceStateCacheEviction = &eviction
+	}
+}
+
 // WithVoiceStateCache sets the VoiceStateCache of the config.
 func WithVoiceStateCache(voiceStateCache VoiceStateCache) ConfigOpt {
 	return func(config *config) {
@@ -281,6 +438,13 @@ func WithMessageCachePolicy(policy Policy[discord.Message]) ConfigOpt {
 	}
 }
 
+// WithMessageCacheEviction sets the Eviction[discord.Message] of the config. It is ignored if a custom MessageCache is set or WithRedis is used.
+func WithMessageCacheEviction(eviction Eviction[discord.Message]) ConfigOpt {
+	return func(config *config) {
+		config.MessageCacheEviction = &eviction
+	}
+}
+
 // WithMessageCache sets the MessageCache of the config.
 func WithMessageCache(messageCache MessageCache) ConfigOpt {
 	return func(config *config) {
@@ -295,6 +459,13 @@ func WithEmojiCachePolicy(policy Policy[discord.Emoji]) ConfigOpt {
 	}
 }
 
+// WithEmojiCacheEviction sets the Eviction[discord.Emoji] of the config. It is ignored if a custom EmojiCache is set or WithRedis is used.
+func WithEmojiCacheEviction(eviction Eviction[discord.Emoji]) ConfigOpt {
+	return func(config *config) {
+		config.EmojiCacheEviction = &eviction
+	}
+}
+
 // WithEmojiCache sets the EmojiCache of the config.
 func WithEmojiCache(emojiCache EmojiCache) ConfigOpt {
 	return func(config *config) {
@@ -309,6 +480,13 @@ func WithStickerCachePolicy(policy Policy[discord.Sticker]) ConfigOpt {
 	}
 }
 
+// WithStickerCacheEviction sets the Eviction[discord.Sticker] of the config. It is ignored if a custom StickerCache is set or WithRedis is used.
+func WithStickerCacheEviction(eviction Eviction[discord.Sticker]) ConfigOpt {
+	return func(config *config) {
+		config.StickerCacheEviction = &eviction
+	}
+}
+
 // WithStickerCache sets the StickerCache of the config.
 func WithStickerCache(stickerCache StickerCache) ConfigOpt {
 	return func(config *config) {
@@ -3,8 +3,8 @@ package cache
 import (
 	"iter"
 	"slices"
+	"strings"
 	"sync"
-	"time"
 
 	"github.com/disgoorg/snowflake/v2"
 
@@ -829,7 +829,13 @@ type Caches interface {
 	// This requires the FlagRoles to be set.
 	MemberPermissions(member discord.Member) discord.Permissions
 
-	// MemberPermissionsInChannel returns the calculated permissions of the given member in the given channel.
+	// MemberPermissionCalculator returns a discord.PermissionCalculator for the guild of the given member with the cached owner, @everyone role & roles of the member.
+	// It can be used to explain why the member lacks permissions.
+	// This requires the FlagGuilds and FlagRoles to be set.
+	MemberPermissionCalculator(member discord.Member) discord.PermissionCalculator
+
+	// MemberPermissionsInChannel returns the calculated permissions of the given member in the given channel using discord.PermissionCalculator.
+	// Threads inherit the permission overwrites of their cached parent channel.
 	// This requires the FlagRoles and FlagChannels to be set.
 	MemberPermissionsInChannel(channel discord.GuildChannel, member discord.Member) discord.Permissions
 
@@ -848,6 +854,22 @@ type Caches interface {
 	// GuildThreadsInChannel returns all discord.GuildThread from the ChannelCache and a bool indicating if it exists.
 	GuildThreadsInChannel(channelID snowflake.ID) []discord.GuildThread
 
+	// MembersByRole returns all members of the given guild with the given role. The @everyone role is not part of a member's roles.
+	// This uses the IndexMembersByRole if enabled.
+	MembersByRole(guildID snowflake.ID, roleID snowflake.ID) iter.Seq[discord.Member]
+
+	// MembersByUser returns the members of the given user in all guilds.
+	// This uses the IndexMembersByUser if enabled.
+	MembersByUser(userID snowflake.ID) iter.Seq[discord.Member]
+
+	// MembersByNamePrefix returns all members of the given guild whose nickname, global name or username starts with the given prefix, ignoring case.
+	// This uses the IndexMembersByName if enabled.
+	MembersByNamePrefix(guildID snowflake.ID, prefix string) iter.Seq[discord.Member]
+
+	// ChannelsByParent returns all channels & threads with the given parent ID, like all channels of a category or all threads of a forum.
+	// This uses the IndexChannelsByParent if enabled.
+	ChannelsByParent(parentID snowflake.ID) iter.Seq[discord.GuildChannel]
+
 	// GuildMessageChannel returns a discord.GuildMessageChannel from the ChannelCache and a bool indicating if it exists.
 	GuildMessageChannel(channelID snowflake.ID) (discord.GuildMessageChannel, bool)
 
@@ -893,7 +915,7 @@ func New(opts ...ConfigOpt) Caches {
 	cfg := defaultConfig()
 	cfg.apply(opts)
 
-	return &cachesImpl{
+	c := &cachesImpl{
 		config:                    cfg,
 		selfUserCache:             cfg.SelfUserCache,
 		guildCache:                cfg.GuildCache,
@@ -910,6 +932,8 @@ func New(opts ...ConfigOpt) Caches {
 		emojiCache:                cfg.EmojiCache,
 		stickerCache:              cfg.StickerCache,
 	}
+	c.createIndexes()
+	return c
 }
 
 // these type aliases are needed to allow having the GuildCache, ChannelCache, etc. as methods on the cachesImpl struct
@@ -947,74 +971,87 @@ type cachesImpl struct {
 	emojiCache
 	stickerCache
 	selfUserCache
-}
 
-func (c *cachesImpl) CacheFlags() Flags {
-	return c.config.CacheFlags
+	membersByRole    *Index[snowflake.ID, discord.Member]
+	membersByUser    *Index[snowflake.ID, discord.Member]
+	membersByName    *PrefixIndex[discord.Member]
+	channelsByParent *Index[snowflake.ID, discord.GuildChannel]
 }
 
-func (c *cachesImpl) MemberPermissions(member discord.Member) discord.Permissions {
-	if guild, ok := c.Guild(member.GuildID); ok && guild.OwnerID == member.User.ID {
-		return discord.PermissionsAll
-	}
-
-	var permissions discord.Permissions
-	if publicRole, ok := c.Role(member.GuildID, member.GuildID); ok {
-		permissions = publicRole.Permissions
-	}
-
-	for _, role := range c.MemberRoles(member) {
-		permissions = permissions.Add(role.Permissions)
-		if permissions.Has(discord.PermissionAdministrator) {
-			return discord.PermissionsAll
+// createIndexes creates the enabled Indexes for all caches which are Observable.
+func (c *cachesImpl) createIndexes() {
+	if members, ok := c.MemberCache().(Observable[discord.Member]); ok {
+		if c.config.Indexes.Has(IndexMembersByRole) {
+			c.membersByRole = NewIndex(members, func(member discord.Member) []snowflake.ID {
+				return member.RoleIDs
+			})
+		}
+		if c.config.Indexes.Has(IndexMembersByUser) {
+			c.membersByUser = NewIndex(members, func(member discord.Member) []snowflake.ID {
+				return []snowflake.ID{member.User.ID}
+			})
+		}
+		if c.config.Indexes.Has(IndexMembersByName) {
+			c.membersByName = NewPrefixIndex(members, func(member discord.Member) []string {
+				keys := []string{memberNameKey(member.GuildID, member.User.Username)}
+				if member.User.GlobalName != nil {
+					keys = append(keys, memberNameKey(member.GuildID, *member.User.GlobalName))
+				}
+				if member.Nick != nil {
+					keys = append(keys, memberNameKey(member.GuildID, *member.Nick))
+				}
+				return keys
+			})
 		}
 	}
-	if member.CommunicationDisabledUntil != nil && member.CommunicationDisabledUntil.After(time.Now()) {
-		permissions &= discord.PermissionViewChannel | discord.PermissionReadMessageHistory
+	if channels, ok := c.ChannelCache().(Observable[discord.GuildChannel]); ok && c.config.Indexes.Has(IndexChannelsByParent) {
+		c.channelsByParent = NewIndex(channels, func(channel discord.GuildChannel) []snowflake.ID {
+			if parentID := channel.ParentID(); parentID != nil {
+				return []snowflake.ID{*parentID}
+			}
+			return nil
+		})
 	}
-	return permissions
 }
 
-func (c *cachesImpl) MemberPermissionsInChannel(channel discord.GuildChannel, member discord.Member) discord.Permissions {
-	permissions := c.MemberPermissions(member)
-	if permissions.Has(discord.PermissionAdministrator) {
-		return discord.PermissionsAll
-	}
+// memberNameKey scopes the name to the guild, so a prefix lookup only returns members of one guild.
+func memberNameKey(guildID snowflake.ID, name string) string {
+	return guildID.String() + ":" + name
+}
 
-	var (
-		allow discord.Permissions
-		deny  discord.Permissions
-	)
+func (c *cachesImpl) CacheFlags() Flags {
+	return c.config.CacheFlags
+}
 
-	if overwrite, ok := channel.PermissionOverwrites().Role(channel.GuildID()); ok {
-		permissions |= overwrite.Allow
-		permissions &= ^overwrite.Deny
+func (c *cachesImpl) MemberPermissionCalculator(member discord.Member) discord.PermissionCalculator {
+	calculator := discord.PermissionCalculator{GuildID: member.GuildID}
+	if guild, ok := c.Guild(member.GuildID); ok {
+		calculator.OwnerID = guild.OwnerID
+	}
+	if publicRole, ok := c.Role(member.GuildID, member.GuildID); ok {
+		calculator.Roles = append(calculator.Roles, publicRole)
 	}
-
 	for _, roleID := range member.RoleIDs {
-		if roleID == channel.GuildID() {
-			continue
+		if role, ok := c.Role(member.GuildID, roleID); ok {
+			calculator.Roles = append(calculator.Roles, role)
 		}
-
-		if overwrite, ok := channel.PermissionOverwrites().Role(roleID); ok {
-			allow |= overwrite.Allow
-			deny |= overwrite.Deny
-		}
-	}
-
-	if overwrite, ok := channel.PermissionOverwrites().Member(member.User.ID); ok {
-		allow |= overwrite.Allow
-		deny |= overwrite.Deny
 	}
+	return calculator
+}
 
-	permissions &= ^deny
-	permissions |= allow
+func (c *cachesImpl) MemberPermissions(member discord.Member) discord.Permissions {
+	return c.MemberPermissionCalculator(member).Permissions(member)
+}
 
-	if member.CommunicationDisabledUntil != nil && member.CommunicationDisabledUntil.After(time.Now()) {
-		permissions &= discord.PermissionViewChannel | discord.PermissionReadMessageHistory
+func (c *cachesImpl) MemberPermissionsInChannel(channel discord.GuildChannel, member discord.Member) discord.Permissions {
+	calculator := c.MemberPermissionCalculator(member)
+	if thread, ok := channel.(discord.GuildThread); ok {
+		if parent, ok := c.Channel(*thread.ParentID()); ok {
+			return calculator.PermissionsInThread(member, parent)
+		}
+		return calculator.PermissionsInThread(member, thread)
 	}
-
-	return permissions
+	return calculator.PermissionsInChannel(member, channel)
 }
 
 func (c *cachesImpl) MemberRoles(member discord.Member) []discord.Role {
@@ -1048,14 +1085,72 @@ func (c *cachesImpl) SelfMember(guildID snowflake.ID) (discord.Member, bool) {
 
 func (c *cachesImpl) GuildThreadsInChannel(channelID snowflake.ID) []discord.GuildThread {
 	var threads []discord.GuildThread
-	for channel := range c.Channels() {
-		if thread, ok := channel.(discord.GuildThread); ok && *thread.ParentID() == channelID {
+	for channel := range c.ChannelsByParent(channelID) {
+		if thread, ok := channel.(discord.GuildThread); ok {
 			threads = append(threads, thread)
 		}
 	}
 	return threads
 }
 
+func (c *cachesImpl) MembersByRole(guildID snowflake.ID, roleID snowflake.ID) iter.Seq[discord.Member] {
+	if c.membersByRole != nil {
+		return lookupEntities(c.membersByRole.Lookup(roleID), c.Member)
+	}
+	return func(yield func(discord.Member) bool) {
+		for member := range c.Members(guildID) {
+			if slices.Contains(member.RoleIDs, roleID) && !yield(member) {
+				return
+			}
+		}
+	}
+}
+
+func (c *cachesImpl) MembersByUser(userID snowflake.ID) iter.Seq[discord.Member] {
+	if c.membersByUser != nil {
+		return lookupEntities(c.membersByUser.Lookup(userID), c.Member)
+	}
+	return func(yield func(discord.Member) bool) {
+		for _, member := range c.MemberCache().All() {
+			if member.User.ID == userID && !yield(member) {
+				return
+			}
+		}
+	}
+}
+
+func (c *cachesImpl) MembersByNamePrefix(guildID snowflake.ID, prefix string) iter.Seq[discord.Member] {
+	if c.membersByName != nil {
+		return lookupEntities(c.membersByName.Lookup(memberNameKey(guildID, prefix)), c.Member)
+	}
+	return func(yield func(discord.Member) bool) {
+		prefix = strings.ToLower(prefix)
+		for member := range c.Members(guildID) {
+			names := []*string{&member.User.Username, member.User.GlobalName, member.Nick}
+			if slices.ContainsFunc(names, func(name *string) bool {
+				return name != nil && strings.HasPrefix(strings.ToLower(*name), prefix)
+			}) && !yield(member) {
+				return
+			}
+		}
+	}
+}
+
+func (c *cachesImpl) ChannelsByParent(parentID snowflake.ID) iter.Seq[discord.GuildChannel] {
+	if c.channelsByParent != nil {
+		return lookupEntities(c.channelsByParent.Lookup(parentID), func(_ snowflake.ID, channelID snowflake.ID) (discord.GuildChannel, bool) {
+			return c.Channel(channelID)
+		})
+	}
+	return func(yield func(discord.GuildChannel) bool) {
+		for channel := range c.Channels() {
+			if channelParentID := channel.ParentID(); channelParentID != nil && *channelParentID == parentID && !yield(channel) {
+				return
+			}
+		}
+	}
+}
+
 func (c *cachesImpl) MessageChannel(channelID snowflake.ID) (discord.MessageChannel, bool) {
 	if ch, ok := c.Channel(channelID); ok {
 		if cCh, ok := ch.(discord.MessageChannel); ok {
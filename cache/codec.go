@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"github.com/disgoorg/json/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/internal/etf"
+)
+
+// Codec encodes & decodes the entities stored in a remote cache like the RedisCache.
+type Codec interface {
+	// Marshal encodes the given entity.
+	Marshal(v any) ([]byte, error)
+
+	// Unmarshal decodes the given data into the entity v points to.
+	Unmarshal(data []byte, v any) error
+}
+
+var (
+	// CodecJSON encodes entities as json.
+	CodecJSON Codec = jsonCodec{}
+
+	// CodecETF encodes entities in the binary Erlang External Term Format.
+	// Numbers, booleans & nulls are stored more compactly than with CodecJSON and strings don't need to be escaped.
+	CodecETF Codec = etfCodec{}
+)
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+type etfCodec struct{}
+
+func (etfCodec) Marshal(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return etf.FromJSON(data)
+}
+
+func (etfCodec) Unmarshal(data []byte, v any) error {
+	data, err := etf.ToJSON(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// decodeEntity decodes an entity with the given Codec.
+// Entities of the interface type discord.GuildChannel are decoded into their concrete channel type.
+func decodeEntity[T any](codec Codec, data []byte) (T, error) {
+	var entity T
+	if _, ok := any(&entity).(*discord.GuildChannel); !ok {
+		err := codec.Unmarshal(data, &entity)
+		return entity, err
+	}
+
+	var v discord.UnmarshalChannel
+	if err := codec.Unmarshal(data, &v); err != nil {
+		return entity, err
+	}
+	entity, _ = v.Channel.(T)
+	return entity, nil
+}
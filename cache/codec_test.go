@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/disgoorg/json/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func testCodecRoundTrip[T any](t *testing.T, codec Codec, data string) {
+	t.Helper()
+
+	var entity T
+	if _, ok := any(&entity).(*discord.GuildChannel); ok {
+		var v discord.UnmarshalChannel
+		if err := json.Unmarshal([]byte(data), &v); err != nil {
+			t.Fatalf("failed to unmarshal %T: %v", entity, err)
+		}
+		entity = v.Channel.(T)
+	} else if err := json.Unmarshal([]byte(data), &entity); err != nil {
+		t.Fatalf("failed to unmarshal %T: %v", entity, err)
+	}
+
+	encoded, err := codec.Marshal(entity)
+	if err != nil {
+		t.Fatalf("failed to encode %T: %v", entity, err)
+	}
+	decoded, err := decodeEntity[T](codec, encoded)
+	if err != nil {
+		t.Fatalf("failed to decode %T: %v", entity, err)
+	}
+
+	expected, _ := json.Marshal(entity)
+	actual, _ := json.Marshal(decoded)
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("expected %T to round trip\nexpected: %s\nactual:   %s", entity, expected, actual)
+	}
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, codec := range []Codec{CodecJSON, CodecETF} {
+		testCodecRoundTrip[discord.Presence](t, codec, `{
+			"user": {"id": "1234567891011121314"},
+			"guild_id": "81384788765712384",
+			"status": "online",
+			"activities": [{"id": "ec0b28a579ecb4bd", "name": "disgo", "type": 0, "created_at": 1700000000123, "timestamps": {"start": 1700000000000, "end": 1700003600000}}],
+			"client_status": {"desktop": "online"}
+		}`)
+		testCodecRoundTrip[discord.Member](t, codec, `{
+			"user": {"id": "1234567891011121314", "username": "user", "discriminator": "0"},
+			"guild_id": "81384788765712384",
+			"nick": "nick",
+			"roles": ["81384788765712385", "81384788765712386"],
+			"joined_at": "2017-03-13T19:19:14.040000+00:00",
+			"communication_disabled_until": "2030-01-01T00:00:00+00:00",
+			"permissions": "2147483648",
+			"flags": 0
+		}`)
+		testCodecRoundTrip[discord.Guild](t, codec, `{
+			"id": "81384788765712384",
+			"name": "disgo",
+			"owner_id": "1234567891011121314",
+			"permissions": "4398046511103",
+			"afk_timeout": 300,
+			"verification_level": 1,
+			"features": ["COMMUNITY"],
+			"max_members": 500000,
+			"premium_subscription_count": 14,
+			"preferred_locale": "en-US",
+			"joined_at": "2017-03-13T19:19:14.040000+00:00",
+			"member_count": 3000000000
+		}`)
+		testCodecRoundTrip[discord.GuildChannel](t, codec, `{
+			"id": "81384788765712385",
+			"type": 0,
+			"guild_id": "81384788765712384",
+			"name": "general",
+			"position": 1,
+			"permission_overwrites": [{"id": "81384788765712384", "type": 0, "allow": "1024", "deny": "2199023255552"}],
+			"rate_limit_per_user": 5,
+			"nsfw": false,
+			"last_message_id": "1234567891011121314"
+		}`)
+		testCodecRoundTrip[discord.Message](t, codec, `{
+			"id": "1234567891011121314",
+			"channel_id": "81384788765712385",
+			"guild_id": "81384788765712384",
+			"author": {"id": "1234567891011121315", "username": "user", "discriminator": "0"},
+			"content": "hello \"world\"",
+			"timestamp": "2017-03-13T19:19:14.040000+00:00",
+			"edited_timestamp": "2017-03-13T19:20:14.040000+00:00",
+			"mentions": [],
+			"embeds": [{"title": "embed", "timestamp": "2017-03-13T19:19:14.040000+00:00", "fields": [{"name": "a", "value": "b", "inline": true}]}],
+			"flags": 4,
+			"type": 0
+		}`)
+	}
+}
@@ -0,0 +1,366 @@
+package cache
+
+import (
+	"encoding/binary"
+	"iter"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+var _ GroupedCache[discord.Member] = (*compactMemberCache)(nil)
+
+// NewCompactMemberGroupedCache returns a new thread safe GroupedCache for members which filters the members after the given Flags and Policy.
+// It uses considerably less memory than the default GroupedCache by storing identical discord.User(s) only once across all guilds,
+// sharing identical role ID lists between members & storing timestamps without allocations.
+// The discord.Member values are reconstructed on every read, so the returned timestamps are always in UTC.
+// Shared users are never modified, so putting a member never changes the members of the same user in other guilds.
+func NewCompactMemberGroupedCache(flags Flags, neededFlags Flags, policy Policy[discord.Member]) GroupedCache[discord.Member] {
+	return &compactMemberCache{
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+		members:     make(map[snowflake.ID]map[snowflake.ID]compactMember),
+		users:       make(map[snowflake.ID][]*compactUser),
+		roleIDs:     make(map[string]*compactRoleIDs),
+	}
+}
+
+type compactMemberCache struct {
+	mu          sync.RWMutex
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[discord.Member]
+	members     map[snowflake.ID]map[snowflake.ID]compactMember
+	// users contains the distinct versions of every user, usually there is only one
+	users   map[snowflake.ID][]*compactUser
+	roleIDs map[string]*compactRoleIDs
+}
+
+// compactUser is an immutable discord.User shared by all members with an identical user.
+type compactUser struct {
+	user    discord.User
+	members int
+}
+
+// compactRoleIDs is a list of role IDs shared by all members with the same roles.
+type compactRoleIDs struct {
+	key     string
+	roleIDs []snowflake.ID
+	members int
+}
+
+type compactMemberBits uint8
+
+const (
+	compactMemberDeaf compactMemberBits = 1 << iota
+	compactMemberMute
+	compactMemberPending
+	compactMemberJoinedAt
+	compactMemberPremiumSince
+	compactMemberCommunicationDisabledUntil
+)
+
+type compactMember struct {
+	user    *compactUser
+	roleIDs *compactRoleIDs
+	// extra is only allocated if the member has any of its fields set
+	extra *compactMemberExtra
+	// joinedAt in unix nanoseconds, only valid if compactMemberJoinedAt is set
+	joinedAt int64
+	flags    uint32
+	bits     compactMemberBits
+}
+
+// compactMemberExtra contains the fields most members don't have set.
+type compactMemberExtra struct {
+	nick                 *string
+	avatar               *string
+	banner               *string
+	avatarDecorationData *discord.AvatarDecorationData
+	// timestamps in unix nanoseconds, only valid if the matching compactMemberBits are set
+	premiumSince               int64
+	communicationDisabledUntil int64
+}
+
+func compactTime(t *time.Time, bit compactMemberBits, bits *compactMemberBits) int64 {
+	if t == nil {
+		return 0
+	}
+	*bits |= bit
+	return t.UnixNano()
+}
+
+func expandTime(nanos int64, bit compactMemberBits, bits compactMemberBits) *time.Time {
+	if bits&bit == 0 {
+		return nil
+	}
+	t := time.Unix(0, nanos).UTC()
+	return &t
+}
+
+// compact converts the member into a compactMember & takes references to the shared user & role IDs. It must be called with c.mu held.
+func (c *compactMemberCache) compact(member discord.Member) compactMember {
+	user := c.internUser(member.User)
+
+	var bits compactMemberBits
+	if member.Deaf {
+		bits |= compactMemberDeaf
+	}
+	if member.Mute {
+		bits |= compactMemberMute
+	}
+	if member.Pending {
+		bits |= compactMemberPending
+	}
+
+	joinedAt := compactTime(member.JoinedAt, compactMemberJoinedAt, &bits)
+	var extra *compactMemberExtra
+	if member.Nick != nil || member.Avatar != nil || member.Banner != nil || member.AvatarDecorationData != nil || member.PremiumSince != nil || member.CommunicationDisabledUntil != nil {
+		extra = &compactMemberExtra{
+			nick:                       member.Nick,
+			avatar:                     member.Avatar,
+			banner:                     member.Banner,
+			avatarDecorationData:       member.AvatarDecorationData,
+			premiumSince:               compactTime(member.PremiumSince, compactMemberPremiumSince, &bits),
+			communicationDisabledUntil: compactTime(member.CommunicationDisabledUntil, compactMemberCommunicationDisabledUntil, &bits),
+		}
+	}
+
+	return compactMember{
+		user:     user,
+		roleIDs:  c.internRoleIDs(member.RoleIDs),
+		extra:    extra,
+		joinedAt: joinedAt,
+		flags:    uint32(member.Flags),
+		bits:     bits,
+	}
+}
+
+// internUser returns the shared compactUser which is equal to the given user. It must be called with c.mu held.
+func (c *compactMemberCache) internUser(user discord.User) *compactUser {
+	users := c.users[user.ID]
+	i := slices.IndexFunc(users, func(u *compactUser) bool {
+		return reflect.DeepEqual(u.user, user)
+	})
+	if i == -1 {
+		users = append(users, &compactUser{user: user})
+		c.users[user.ID] = users
+		i = len(users) - 1
+	}
+	users[i].members++
+	return users[i]
+}
+
+// internRoleIDs returns the shared compactRoleIDs of the given role IDs. It must be called with c.mu held.
+func (c *compactMemberCache) internRoleIDs(roleIDs []snowflake.ID) *compactRoleIDs {
+	if len(roleIDs) == 0 {
+		return nil
+	}
+	key := make([]byte, 0, len(roleIDs)*8)
+	for _, roleID := range roleIDs {
+		key = binary.LittleEndian.AppendUint64(key, uint64(roleID))
+	}
+
+	interned, ok := c.roleIDs[string(key)]
+	if !ok {
+		interned = &compactRoleIDs{
+			key:     string(key),
+			roleIDs: slices.Clone(roleIDs),
+		}
+		c.roleIDs[interned.key] = interned
+	}
+	interned.members++
+	return interned
+}
+
+// release drops the references of the compactMember to the shared user & role IDs. It must be called with c.mu held.
+func (c *compactMemberCache) release(member compactMember) {
+	if member.user.members--; member.user.members == 0 {
+		userID := member.user.user.ID
+		users := slices.DeleteFunc(c.users[userID], func(u *compactUser) bool {
+			return u == member.user
+		})
+		if len(users) == 0 {
+			delete(c.users, userID)
+		} else {
+			c.users[userID] = users
+		}
+	}
+	if member.roleIDs != nil {
+		if member.roleIDs.members--; member.roleIDs.members == 0 {
+			delete(c.roleIDs, member.roleIDs.key)
+		}
+	}
+}
+
+func (c *compactMemberCache) expand(guildID snowflake.ID, member compactMember) discord.Member {
+	var roleIDs []snowflake.ID
+	if member.roleIDs != nil {
+		// clone the shared role IDs, so modifying the returned member does not modify other members
+		roleIDs = slices.Clone(member.roleIDs.roleIDs)
+	}
+	m := discord.Member{
+		User:     member.user.user,
+		RoleIDs:  roleIDs,
+		JoinedAt: expandTime(member.joinedAt, compactMemberJoinedAt, member.bits),
+		Deaf:     member.bits&compactMemberDeaf != 0,
+		Mute:     member.bits&compactMemberMute != 0,
+		Flags:    discord.MemberFlags(member.flags),
+		Pending:  member.bits&compactMemberPending != 0,
+		GuildID:  guildID,
+	}
+	if extra := member.extra; extra != nil {
+		m.Nick = extra.nick
+		m.Avatar = extra.avatar
+		m.Banner = extra.banner
+		m.AvatarDecorationData = extra.avatarDecorationData
+		m.PremiumSince = expandTime(extra.premiumSince, compactMemberPremiumSince, member.bits)
+		m.CommunicationDisabledUntil = expandTime(extra.communicationDisabledUntil, compactMemberCommunicationDisabledUntil, member.bits)
+	}
+	return m
+}
+
+func (c *compactMemberCache) Get(groupID snowflake.ID, id snowflake.ID) (discord.Member, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if member, ok := c.members[groupID][id]; ok {
+		return c.expand(groupID, member), true
+	}
+	return discord.Member{}, false
+}
+
+func (c *compactMemberCache) Put(groupID snowflake.ID, id snowflake.ID, entity discord.Member) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	if c.policy != nil && !c.policy(entity) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	groupMembers, ok := c.members[groupID]
+	if !ok {
+		groupMembers = make(map[snowflake.ID]compactMember)
+		c.members[groupID] = groupMembers
+	}
+	// take the new references before releasing the old ones, so unchanged users & role IDs are not reallocated
+	member := c.compact(entity)
+	if old, ok := groupMembers[id]; ok {
+		c.release(old)
+	}
+	groupMembers[id] = member
+}
+
+func (c *compactMemberCache) Remove(groupID snowflake.ID, id snowflake.ID) (discord.Member, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	groupMembers, ok := c.members[groupID]
+	if !ok {
+		return discord.Member{}, false
+	}
+	member, ok := groupMembers[id]
+	if !ok {
+		return discord.Member{}, false
+	}
+	c.remove(groupID, groupMembers, id, member)
+	return c.expand(groupID, member), true
+}
+
+// remove removes the member from the group & drops its references. It must be called with c.mu held.
+func (c *compactMemberCache) remove(groupID snowflake.ID, groupMembers map[snowflake.ID]compactMember, id snowflake.ID, member compactMember) {
+	delete(groupMembers, id)
+	if len(groupMembers) == 0 {
+		delete(c.members, groupID)
+	}
+	c.release(member)
+}
+
+func (c *compactMemberCache) GroupRemove(groupID snowflake.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, member := range c.members[groupID] {
+		c.release(member)
+	}
+	delete(c.members, groupID)
+}
+
+func (c *compactMemberCache) RemoveIf(filterFunc GroupedFilterFunc[discord.Member]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for groupID := range c.members {
+		c.removeIf(groupID, filterFunc)
+	}
+}
+
+func (c *compactMemberCache) GroupRemoveIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[discord.Member]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removeIf(groupID, filterFunc)
+}
+
+// removeIf removes all members of the group which pass the filterFunc. It must be called with c.mu held.
+func (c *compactMemberCache) removeIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[discord.Member]) {
+	groupMembers := c.members[groupID]
+	for id, member := range groupMembers {
+		if filterFunc(groupID, c.expand(groupID, member)) {
+			c.remove(groupID, groupMembers, id, member)
+		}
+	}
+}
+
+func (c *compactMemberCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var totalLen int
+	for _, groupMembers := range c.members {
+		totalLen += len(groupMembers)
+	}
+	return totalLen
+}
+
+func (c *compactMemberCache) GroupLen(groupID snowflake.ID) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.members[groupID])
+}
+
+func (c *compactMemberCache) All() iter.Seq2[snowflake.ID, discord.Member] {
+	return func(yield func(snowflake.ID, discord.Member) bool) {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+
+		for groupID, groupMembers := range c.members {
+			for _, member := range groupMembers {
+				if !yield(groupID, c.expand(groupID, member)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *compactMemberCache) GroupAll(groupID snowflake.ID) iter.Seq[discord.Member] {
+	return func(yield func(discord.Member) bool) {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+
+		for _, member := range c.members[groupID] {
+			if !yield(c.expand(groupID, member)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestCompactMemberCache(t *testing.T) {
+	t.Parallel()
+
+	c := NewCompactMemberGroupedCache(FlagsAll, FlagMembers, nil).(*compactMemberCache)
+
+	nick := "nick"
+	joinedAt := time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)
+	member := discord.Member{
+		User:     discord.User{ID: 1, Username: "user"},
+		Nick:     &nick,
+		RoleIDs:  []snowflake.ID{10, 20},
+		JoinedAt: &joinedAt,
+		Pending:  true,
+		Flags:    discord.MemberFlagDidRejoin,
+		GuildID:  100,
+	}
+	c.Put(100, 1, member)
+	if got, ok := c.Get(100, 1); !ok || !reflect.DeepEqual(got, member) {
+		t.Errorf("expected %+v, got %+v (%t)", member, got, ok)
+	}
+
+	other := member
+	other.GuildID = 200
+	other.Nick = nil
+	other.User.Username = "renamed"
+	c.Put(200, 1, other)
+	c.Put(200, 2, discord.Member{User: discord.User{ID: 2}, RoleIDs: []snowflake.ID{10, 20}, GuildID: 200})
+	if len(c.users) != 2 || len(c.roleIDs) != 1 {
+		t.Errorf("expected 2 users & 1 role ID list, got %d & %d", len(c.users), len(c.roleIDs))
+	}
+	if got, _ := c.Get(100, 1); got.User.Username != "user" {
+		t.Errorf("expected user of other guilds to be unchanged, got %q", got.User.Username)
+	}
+	if got, _ := c.Get(200, 1); got.User.Username != "renamed" {
+		t.Errorf("expected renamed user, got %q", got.User.Username)
+	}
+	if len(c.users[1]) != 2 {
+		t.Errorf("expected 2 versions of user 1, got %d", len(c.users[1]))
+	}
+	c.Put(100, 1, other)
+	if len(c.users[1]) != 1 {
+		t.Errorf("expected identical users to be shared, got %d versions of user 1", len(c.users[1]))
+	}
+
+	got, _ := c.Get(200, 2)
+	got.RoleIDs[0] = 30
+	if got, _ := c.Get(200, 1); got.RoleIDs[0] != 10 {
+		t.Error("expected modifying returned role IDs to not modify other members")
+	}
+
+	c.GroupRemoveIf(200, func(_ snowflake.ID, member discord.Member) bool {
+		return member.User.ID == 2
+	})
+	if _, ok := c.Remove(100, 1); !ok {
+		t.Error("expected member to be removed")
+	}
+	c.GroupRemove(200)
+	if c.Len() != 0 || len(c.users) != 0 || len(c.roleIDs) != 0 || len(c.members) != 0 {
+		t.Errorf("expected empty cache, got %d members, %d users & %d role ID lists", c.Len(), len(c.users), len(c.roleIDs))
+	}
+}
+
+// benchmarkMemberCacheMemory reports the heap used per member after putting 1M members of 250k users, who are in 4 guilds each, into the cache.
+func benchmarkMemberCacheMemory(b *testing.B, newCache func() GroupedCache[discord.Member]) {
+	const (
+		users       = 250_000
+		guilds      = 1000
+		memberships = 4
+	)
+	joinedAt := time.Now()
+	for range b.N {
+		runtime.GC()
+		var before runtime.MemStats
+		runtime.ReadMemStats(&before)
+
+		c := newCache()
+		for userID := range snowflake.ID(users) {
+			for i := range snowflake.ID(memberships) {
+				guildID := (userID + i*guilds/memberships) % guilds
+				// every member is decoded separately by the gateway, so every member gets its own copy of the user
+				globalName := "User " + strconv.Itoa(int(userID))
+				avatar := strconv.FormatUint(uint64(userID), 16)
+				memberJoinedAt := joinedAt.Add(-time.Duration(userID) * time.Second)
+				var roleIDs []snowflake.ID
+				for roleID := range snowflake.ID(userID % 4) {
+					roleIDs = append(roleIDs, guildID*10+roleID)
+				}
+				c.Put(guildID, userID, discord.Member{
+					User: discord.User{
+						ID:            userID,
+						Username:      "user" + strconv.Itoa(int(userID)),
+						Discriminator: "0",
+						GlobalName:    &globalName,
+						Avatar:        &avatar,
+					},
+					RoleIDs:  roleIDs,
+					JoinedAt: &memberJoinedAt,
+					GuildID:  guildID,
+				})
+			}
+		}
+
+		runtime.GC()
+		var after runtime.MemStats
+		runtime.ReadMemStats(&after)
+		b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/float64(c.Len()), "B/member")
+		runtime.KeepAlive(c)
+	}
+}
+
+func BenchmarkMemberCache_Memory(b *testing.B) {
+	b.Run("Default", func(b *testing.B) {
+		benchmarkMemberCacheMemory(b, func() GroupedCache[discord.Member] {
+			return NewGroupedCache[discord.Member](FlagsAll, FlagMembers, nil)
+		})
+	})
+	b.Run("Compact", func(b *testing.B) {
+		benchmarkMemberCacheMemory(b, func() GroupedCache[discord.Member] {
+			return NewCompactMemberGroupedCache(FlagsAll, FlagMembers, nil)
+		})
+	})
+}
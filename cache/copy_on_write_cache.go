@@ -0,0 +1,233 @@
+package cache
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+var (
+	_ Cache[any]        = (*copyOnWriteCache[any])(nil)
+	_ GroupedCache[any] = (*copyOnWriteGroupedCache[any])(nil)
+)
+
+// NewCopyOnWriteCache returns a new thread safe Cache which filters the entities after the given Flags and Policy.
+// The entities are stored in an immutable trie, which is replaced on every write while sharing all unmodified parts.
+// This makes reads lock-free & iterations a consistent point-in-time snapshot, which does not block writers, at the cost of slower writes than the DefaultCache.
+func NewCopyOnWriteCache[T any](flags Flags, neededFlags Flags, policy Policy[T]) Cache[T] {
+	c := &copyOnWriteCache[T]{
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+	}
+	c.entities.Store(&hamt[T]{})
+	return c
+}
+
+type copyOnWriteCache[T any] struct {
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[T]
+	// mu serializes writers, readers only load entities
+	mu       sync.Mutex
+	entities atomic.Pointer[hamt[T]]
+}
+
+func (c *copyOnWriteCache[T]) Get(id snowflake.ID) (T, bool) {
+	return c.entities.Load().get(uint64(id))
+}
+
+func (c *copyOnWriteCache[T]) Put(id snowflake.ID, entity T) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	if c.policy != nil && !c.policy(entity) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entities := c.entities.Load().put(uint64(id), entity)
+	c.entities.Store(&entities)
+}
+
+func (c *copyOnWriteCache[T]) Remove(id snowflake.ID) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entities, entity, ok := c.entities.Load().remove(uint64(id))
+	if ok {
+		c.entities.Store(&entities)
+	}
+	return entity, ok
+}
+
+func (c *copyOnWriteCache[T]) RemoveIf(filterFunc FilterFunc[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	old := *c.entities.Load()
+	entities := old
+	for id, entity := range old.all() {
+		if filterFunc(entity) {
+			entities, _, _ = entities.remove(id)
+		}
+	}
+	c.entities.Store(&entities)
+}
+
+func (c *copyOnWriteCache[T]) Len() int {
+	return c.entities.Load().len
+}
+
+func (c *copyOnWriteCache[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, entity := range c.entities.Load().all() {
+			if !yield(entity) {
+				return
+			}
+		}
+	}
+}
+
+// NewCopyOnWriteGroupedCache returns a new thread safe GroupedCache which filters the entities after the given Flags and Policy.
+// The entities are stored in immutable tries, which are replaced on every write while sharing all unmodified parts.
+// This makes reads lock-free & iterations a consistent point-in-time snapshot, which does not block writers, at the cost of slower writes than the default GroupedCache.
+func NewCopyOnWriteGroupedCache[T any](flags Flags, neededFlags Flags, policy Policy[T]) GroupedCache[T] {
+	c := &copyOnWriteGroupedCache[T]{
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+	}
+	c.groups.Store(&copyOnWriteGroups[T]{})
+	return c
+}
+
+type copyOnWriteGroupedCache[T any] struct {
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[T]
+	// mu serializes writers, readers only load groups
+	mu     sync.Mutex
+	groups atomic.Pointer[copyOnWriteGroups[T]]
+}
+
+type copyOnWriteGroups[T any] struct {
+	groups hamt[hamt[T]]
+	len    int
+}
+
+// update applies fn to all entities of the group & stores the result. It must be called with c.mu held.
+func (c *copyOnWriteGroupedCache[T]) update(groupID snowflake.ID, fn func(entities hamt[T]) hamt[T]) {
+	old := c.groups.Load()
+	entities, _ := old.groups.get(uint64(groupID))
+	updated := fn(entities)
+	if updated.root == entities.root {
+		return
+	}
+
+	groups := copyOnWriteGroups[T]{
+		len: old.len - entities.len + updated.len,
+	}
+	if updated.len == 0 {
+		groups.groups, _, _ = old.groups.remove(uint64(groupID))
+	} else {
+		groups.groups = old.groups.put(uint64(groupID), updated)
+	}
+	c.groups.Store(&groups)
+}
+
+func (c *copyOnWriteGroupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	entities, _ := c.groups.Load().groups.get(uint64(groupID))
+	return entities.get(uint64(id))
+}
+
+func (c *copyOnWriteGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, entity T) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	if c.policy != nil && !c.policy(entity) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.update(groupID, func(entities hamt[T]) hamt[T] {
+		return entities.put(uint64(id), entity)
+	})
+}
+
+func (c *copyOnWriteGroupedCache[T]) Remove(groupID snowflake.ID, id snowflake.ID) (entity T, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.update(groupID, func(entities hamt[T]) hamt[T] {
+		entities, entity, ok = entities.remove(uint64(id))
+		return entities
+	})
+	return
+}
+
+func (c *copyOnWriteGroupedCache[T]) GroupRemove(groupID snowflake.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.update(groupID, func(hamt[T]) hamt[T] {
+		return hamt[T]{}
+	})
+}
+
+func (c *copyOnWriteGroupedCache[T]) RemoveIf(filterFunc GroupedFilterFunc[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for groupID := range c.groups.Load().groups.all() {
+		c.removeIf(snowflake.ID(groupID), filterFunc)
+	}
+}
+
+func (c *copyOnWriteGroupedCache[T]) GroupRemoveIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeIf(groupID, filterFunc)
+}
+
+// removeIf removes all entities of the group which pass the filterFunc. It must be called with c.mu held.
+func (c *copyOnWriteGroupedCache[T]) removeIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[T]) {
+	c.update(groupID, func(old hamt[T]) hamt[T] {
+		entities := old
+		for id, entity := range old.all() {
+			if filterFunc(groupID, entity) {
+				entities, _, _ = entities.remove(id)
+			}
+		}
+		return entities
+	})
+}
+
+func (c *copyOnWriteGroupedCache[T]) Len() int {
+	return c.groups.Load().len
+}
+
+func (c *copyOnWriteGroupedCache[T]) GroupLen(groupID snowflake.ID) int {
+	entities, _ := c.groups.Load().groups.get(uint64(groupID))
+	return entities.len
+}
+
+func (c *copyOnWriteGroupedCache[T]) All() iter.Seq2[snowflake.ID, T] {
+	return func(yield func(snowflake.ID, T) bool) {
+		for groupID, entities := range c.groups.Load().groups.all() {
+			for _, entity := range entities.all() {
+				if !yield(snowflake.ID(groupID), entity) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *copyOnWriteGroupedCache[T]) GroupAll(groupID snowflake.ID) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		entities, _ := c.groups.Load().groups.get(uint64(groupID))
+		for _, entity := range entities.all() {
+			if !yield(entity) {
+				return
+			}
+		}
+	}
+}
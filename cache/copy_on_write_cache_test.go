@@ -0,0 +1,219 @@
+package cache
+
+import (
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestCopyOnWriteGroupedCache(t *testing.T) {
+	t.Parallel()
+
+	c := NewCopyOnWriteGroupedCache[int](FlagsAll, FlagMembers, nil)
+	expected := map[snowflake.ID]map[snowflake.ID]int{}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for i := range 20000 {
+		groupID := snowflake.ID(r.IntN(8))
+		id := snowflake.ID(r.Uint64N(2000))
+		switch r.IntN(4) {
+		case 0:
+			_, ok := c.Remove(groupID, id)
+			if _, expectedOK := expected[groupID][id]; ok != expectedOK {
+				t.Fatalf("expected remove of %d/%d to be %t", groupID, id, expectedOK)
+			}
+			delete(expected[groupID], id)
+		default:
+			c.Put(groupID, id, i)
+			if expected[groupID] == nil {
+				expected[groupID] = map[snowflake.ID]int{}
+			}
+			expected[groupID][id] = i
+		}
+	}
+
+	c.GroupRemove(3)
+	delete(expected, 3)
+	c.GroupRemoveIf(4, func(_ snowflake.ID, entity int) bool {
+		return entity%2 == 0
+	})
+	for id, entity := range expected[4] {
+		if entity%2 == 0 {
+			delete(expected[4], id)
+		}
+	}
+
+	var l int
+	for groupID, entities := range expected {
+		l += len(entities)
+		if gl := c.GroupLen(groupID); gl != len(entities) {
+			t.Errorf("expected %d entities in group %d, got %d", len(entities), groupID, gl)
+		}
+		for id, entity := range entities {
+			if got, ok := c.Get(groupID, id); !ok || got != entity {
+				t.Errorf("expected %d for %d/%d, got %d (%t)", entity, groupID, id, got, ok)
+			}
+		}
+	}
+	if cl := c.Len(); cl != l {
+		t.Errorf("expected %d entities, got %d", l, cl)
+	}
+	var iterated int
+	for groupID, entity := range c.All() {
+		if _, ok := expected[groupID]; !ok {
+			t.Errorf("unexpected entity %d in group %d", entity, groupID)
+		}
+		iterated++
+	}
+	if iterated != l {
+		t.Errorf("expected to iterate %d entities, got %d", l, iterated)
+	}
+}
+
+func TestCopyOnWriteCache_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	c := NewCopyOnWriteCache[discord.Guild](FlagsAll, FlagGuilds, nil)
+	for id := range snowflake.ID(100) {
+		c.Put(id, discord.Guild{ID: id})
+	}
+
+	var iterated int
+	for guild := range c.All() {
+		// writes during iteration neither block nor change the iterated snapshot
+		c.Remove(guild.ID)
+		c.Put(guild.ID+1000, discord.Guild{ID: guild.ID + 1000})
+		iterated++
+	}
+	if iterated != 100 {
+		t.Errorf("expected to iterate 100 guilds, got %d", iterated)
+	}
+	if l := c.Len(); l != 100 {
+		t.Errorf("expected 100 guilds, got %d", l)
+	}
+	c.RemoveIf(func(guild discord.Guild) bool {
+		return guild.ID >= 1050
+	})
+	if l := c.Len(); l != 50 {
+		t.Errorf("expected 50 guilds after removing, got %d", l)
+	}
+}
+
+// benchmarkGroupedCache simulates gateway handlers updating members, while the other goroutines read members.
+func benchmarkGroupedCache(b *testing.B, c GroupedCache[discord.Member]) {
+	const (
+		guilds  = 100
+		members = 1000
+	)
+	for guildID := range snowflake.ID(guilds) {
+		for userID := range snowflake.ID(members) {
+			c.Put(guildID, userID, discord.Member{GuildID: guildID, User: discord.User{ID: userID}})
+		}
+	}
+
+	var (
+		stop    atomic.Bool
+		wg      sync.WaitGroup
+		started = make(chan struct{})
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		close(started)
+		r := rand.New(rand.NewPCG(1, 2))
+		for !stop.Load() {
+			guildID := snowflake.ID(r.IntN(guilds))
+			userID := snowflake.ID(r.IntN(members))
+			c.Put(guildID, userID, discord.Member{GuildID: guildID, User: discord.User{ID: userID}})
+		}
+	}()
+	<-started
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+		for pb.Next() {
+			c.Get(snowflake.ID(r.IntN(guilds)), snowflake.ID(r.IntN(members)))
+		}
+	})
+	b.StopTimer()
+	stop.Store(true)
+	wg.Wait()
+}
+
+// benchmarkGroupedCacheWrites measures how many updates the gateway handlers can apply while another goroutine iterates all members.
+func benchmarkGroupedCacheWrites(b *testing.B, c GroupedCache[discord.Member]) {
+	const members = 10000
+	for userID := range snowflake.ID(members) {
+		c.Put(1, userID, discord.Member{GuildID: 1, User: discord.User{ID: userID}})
+	}
+
+	var (
+		stop    atomic.Bool
+		wg      sync.WaitGroup
+		started = make(chan struct{})
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		close(started)
+		for !stop.Load() {
+			for range c.GroupAll(1) {
+			}
+		}
+	}()
+	<-started
+
+	b.ResetTimer()
+	for i := range b.N {
+		userID := snowflake.ID(i % members)
+		c.Put(1, userID, discord.Member{GuildID: 1, User: discord.User{ID: userID}, Nick: new(string)})
+	}
+	b.StopTimer()
+	stop.Store(true)
+	wg.Wait()
+}
+
+func BenchmarkGroupedCache(b *testing.B) {
+	for _, bc := range []struct {
+		name     string
+		newCache func() GroupedCache[discord.Member]
+	}{
+		{name: "Default", newCache: func() GroupedCache[discord.Member] {
+			return NewGroupedCache[discord.Member](FlagsAll, FlagMembers, nil)
+		}},
+		{name: "CopyOnWrite", newCache: func() GroupedCache[discord.Member] {
+			return NewCopyOnWriteGroupedCache[discord.Member](FlagsAll, FlagMembers, nil)
+		}},
+	} {
+		b.Run(bc.name+"/ReadsDuringUpdates", func(b *testing.B) {
+			benchmarkGroupedCache(b, bc.newCache())
+		})
+		b.Run(bc.name+"/UpdatesDuringIteration", func(b *testing.B) {
+			benchmarkGroupedCacheWrites(b, bc.newCache())
+		})
+	}
+}
+
+func BenchmarkCache_Put(b *testing.B) {
+	for _, bc := range []struct {
+		name  string
+		cache Cache[discord.Guild]
+	}{
+		{name: "Default", cache: NewCache[discord.Guild](FlagsAll, FlagGuilds, nil)},
+		{name: "CopyOnWrite", cache: NewCopyOnWriteCache[discord.Guild](FlagsAll, FlagGuilds, nil)},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			for i := range b.N {
+				id := snowflake.ID(i % 10000)
+				bc.cache.Put(id, discord.Guild{ID: id, Name: strconv.Itoa(i)})
+			}
+		})
+	}
+}
@@ -0,0 +1,742 @@
+package cache
+
+import (
+	"bufio"
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"iter"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+const (
+	diskOpPut byte = iota + 1
+	diskOpDelete
+	diskOpDeleteChannel
+	// diskOpCompacted is the first record of a compacted segment, all older segments are obsolete.
+	diskOpCompacted
+)
+
+const (
+	// diskRecordHeaderSize is the size of the length & checksum of every record.
+	diskRecordHeaderSize = 8
+	// diskRecordKeySize is the size of the op, channel ID & message ID of every record.
+	diskRecordKeySize = 17
+	// diskRecordMaxSize is the max size of a single record, anything bigger is treated as corrupted.
+	diskRecordMaxSize = 16 << 20
+
+	diskSegmentPrefix = "segment-"
+	diskSegmentSuffix = ".log"
+)
+
+// ErrDiskMessageCacheClosed is returned when the DiskMessageCache is used after it was closed.
+var ErrDiskMessageCacheClosed = errors.New("disk message cache closed")
+
+var _ GroupedCache[discord.Message] = (*DiskMessageCache)(nil)
+
+// NewDiskMessageCache opens or creates a DiskMessageCache in the given directory with the given DiskMessageCacheConfigOpt(s).
+// Existing segments are replayed, so messages survive restarts.
+// Use it with NewMessageCache & WithMessageCache to replace the default in-memory message cache.
+func NewDiskMessageCache(dir string, opts ...DiskMessageCacheConfigOpt) (*DiskMessageCache, error) {
+	cfg := defaultDiskMessageCacheConfig()
+	cfg.apply(opts)
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create disk message cache directory: %w", err)
+	}
+
+	c := &DiskMessageCache{
+		config:   cfg,
+		dir:      dir,
+		channels: map[snowflake.ID]*diskChannel{},
+		hot:      list.New(),
+		hotItems: map[diskKey]*list.Element{},
+	}
+	if err := c.load(); err != nil {
+		c.closeSegments()
+		return nil, err
+	}
+	return c, nil
+}
+
+// DiskMessageCache is a GroupedCache of messages grouped by their channel ID, which keeps the most recently used messages in memory and all messages on disk.
+//
+// Messages are appended to segment files in the given directory. Once a segment reaches its max size a new one is started.
+// When the share of live data in all segments drops below the compaction threshold, all live messages are rewritten into a single new segment.
+// Only an index of the messages on disk is kept in memory.
+//
+// Writes are not synced to disk until the DiskMessageCache is closed or compacted, so the last messages might be lost on a crash.
+// Unlike the default caches, it ignores the cache Flags, as it is configured explicitly.
+type DiskMessageCache struct {
+	config diskMessageCacheConfig
+	dir    string
+
+	mu       sync.Mutex
+	closed   bool
+	segments []*diskSegment
+	channels map[snowflake.ID]*diskChannel
+	len      int
+	hot      *list.List
+	hotItems map[diskKey]*list.Element
+}
+
+type diskKey struct {
+	channelID snowflake.ID
+	messageID snowflake.ID
+}
+
+type diskHotMessage struct {
+	key     diskKey
+	message discord.Message
+}
+
+type diskSegment struct {
+	id   int
+	file *os.File
+	// size is the number of bytes written to the segment
+	size int64
+	// live is the number of bytes of records which are still indexed
+	live int64
+}
+
+type diskLocation struct {
+	segment *diskSegment
+	offset  int64
+	size    int64
+}
+
+type diskChannel struct {
+	// ids are the sorted message IDs, so the oldest messages can be removed first
+	ids       []snowflake.ID
+	locations map[snowflake.ID]diskLocation
+}
+
+func (c *DiskMessageCache) Get(channelID snowflake.ID, messageID snowflake.ID) (discord.Message, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	message, ok := c.get(diskKey{channelID: channelID, messageID: messageID}, true)
+	return message, ok
+}
+
+func (c *DiskMessageCache) Put(channelID snowflake.ID, messageID snowflake.ID, message discord.Message) {
+	if c.config.Policy != nil && !c.config.Policy(message) {
+		return
+	}
+	if c.expired(messageID) {
+		return
+	}
+	data, err := json.Marshal(message)
+	if err != nil {
+		c.config.Logger.Error("failed to encode message", slog.Any("err", err), slog.String("message_id", messageID.String()))
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := diskKey{channelID: channelID, messageID: messageID}
+	loc, err := c.append(diskOpPut, key, data)
+	if err != nil {
+		c.config.Logger.Error("failed to write message", slog.Any("err", err), slog.String("message_id", messageID.String()))
+		return
+	}
+	c.index(key, loc)
+	if _, ok := c.channels[channelID].locations[messageID]; ok {
+		// the message might have been removed right away by the channel limit
+		c.addHot(key, message)
+	}
+	c.rotate()
+}
+
+func (c *DiskMessageCache) Remove(channelID snowflake.ID, messageID snowflake.ID) (discord.Message, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := diskKey{channelID: channelID, messageID: messageID}
+	message, ok := c.get(key, false)
+	if !ok {
+		return message, false
+	}
+	c.remove(key)
+	return message, true
+}
+
+func (c *DiskMessageCache) GroupRemove(channelID snowflake.ID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.channels[channelID]; !ok {
+		return
+	}
+	if _, err := c.append(diskOpDeleteChannel, diskKey{channelID: channelID}, nil); err != nil {
+		c.config.Logger.Error("failed to write channel removal", slog.Any("err", err), slog.String("channel_id", channelID.String()))
+		return
+	}
+	c.unindexChannel(channelID)
+	c.rotate()
+}
+
+func (c *DiskMessageCache) RemoveIf(filterFunc GroupedFilterFunc[discord.Message]) {
+	for _, key := range c.keys(nil) {
+		c.removeIf(key, filterFunc)
+	}
+}
+
+func (c *DiskMessageCache) GroupRemoveIf(channelID snowflake.ID, filterFunc GroupedFilterFunc[discord.Message]) {
+	for _, key := range c.keys(&channelID) {
+		c.removeIf(key, filterFunc)
+	}
+}
+
+func (c *DiskMessageCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.len
+}
+
+func (c *DiskMessageCache) GroupLen(channelID snowflake.ID) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ch, ok := c.channels[channelID]; ok {
+		return len(ch.ids)
+	}
+	return 0
+}
+
+func (c *DiskMessageCache) All() iter.Seq2[snowflake.ID, discord.Message] {
+	return func(yield func(snowflake.ID, discord.Message) bool) {
+		for _, key := range c.keys(nil) {
+			c.mu.Lock()
+			message, ok := c.get(key, false)
+			c.mu.Unlock()
+			if ok && !yield(key.channelID, message) {
+				return
+			}
+		}
+	}
+}
+
+func (c *DiskMessageCache) GroupAll(channelID snowflake.ID) iter.Seq[discord.Message] {
+	return func(yield func(discord.Message) bool) {
+		for _, key := range c.keys(&channelID) {
+			c.mu.Lock()
+			message, ok := c.get(key, false)
+			c.mu.Unlock()
+			if ok && !yield(message) {
+				return
+			}
+		}
+	}
+}
+
+// Compact removes expired messages & rewrites all live messages into a single new segment.
+// This happens automatically when the share of live data drops below the compaction threshold.
+func (c *DiskMessageCache) Compact() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrDiskMessageCacheClosed
+	}
+	return c.compact()
+}
+
+// Close syncs & closes all segment files. The DiskMessageCache can't be used afterward.
+func (c *DiskMessageCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+
+	var err error
+	for _, s := range c.segments {
+		err = errors.Join(err, s.file.Sync(), s.file.Close())
+	}
+	c.segments = nil
+	return err
+}
+
+// keys returns the keys of all messages or all messages of the given channel.
+func (c *DiskMessageCache) keys(channelID *snowflake.ID) []diskKey {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var keys []diskKey
+	for id, ch := range c.channels {
+		if channelID != nil && id != *channelID {
+			continue
+		}
+		for _, messageID := range ch.ids {
+			keys = append(keys, diskKey{channelID: id, messageID: messageID})
+		}
+	}
+	return keys
+}
+
+func (c *DiskMessageCache) removeIf(key diskKey, filterFunc GroupedFilterFunc[discord.Message]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if message, ok := c.get(key, false); ok && filterFunc(key.channelID, message) {
+		c.remove(key)
+	}
+}
+
+// get returns the message from memory or disk. It must be called with c.mu held.
+func (c *DiskMessageCache) get(key diskKey, touch bool) (discord.Message, bool) {
+	if c.expired(key.messageID) {
+		if _, ok := c.unindex(key); ok {
+			c.config.Logger.Debug("removed expired message", slog.String("message_id", key.messageID.String()))
+		}
+		return discord.Message{}, false
+	}
+
+	if element, ok := c.hotItems[key]; ok {
+		if touch {
+			c.hot.MoveToFront(element)
+		}
+		return element.Value.(*diskHotMessage).message, true
+	}
+
+	ch, ok := c.channels[key.channelID]
+	if !ok {
+		return discord.Message{}, false
+	}
+	loc, ok := ch.locations[key.messageID]
+	if !ok {
+		return discord.Message{}, false
+	}
+
+	message, err := c.read(loc)
+	if err != nil {
+		c.config.Logger.Error("failed to read message", slog.Any("err", err), slog.String("message_id", key.messageID.String()))
+		return discord.Message{}, false
+	}
+	if touch {
+		c.addHot(key, message)
+	}
+	return message, true
+}
+
+// remove writes a removal record & removes the message from the index. It must be called with c.mu held.
+func (c *DiskMessageCache) remove(key diskKey) {
+	if _, err := c.append(diskOpDelete, key, nil); err != nil {
+		c.config.Logger.Error("failed to write message removal", slog.Any("err", err), slog.String("message_id", key.messageID.String()))
+		return
+	}
+	c.unindex(key)
+	c.rotate()
+}
+
+func (c *DiskMessageCache) expired(messageID snowflake.ID) bool {
+	return c.config.MaxAge > 0 && time.Since(messageID.Time()) > c.config.MaxAge
+}
+
+func (c *DiskMessageCache) addHot(key diskKey, message discord.Message) {
+	if element, ok := c.hotItems[key]; ok {
+		element.Value.(*diskHotMessage).message = message
+		c.hot.MoveToFront(element)
+		return
+	}
+	c.hotItems[key] = c.hot.PushFront(&diskHotMessage{key: key, message: message})
+	for c.hot.Len() > c.config.MemoryLimit {
+		element := c.hot.Back()
+		c.hot.Remove(element)
+		delete(c.hotItems, element.Value.(*diskHotMessage).key)
+	}
+}
+
+func (c *DiskMessageCache) removeHot(key diskKey) {
+	if element, ok := c.hotItems[key]; ok {
+		c.hot.Remove(element)
+		delete(c.hotItems, key)
+	}
+}
+
+// index adds the message at the given location to the index & applies the channel limit. It must be called with c.mu held.
+func (c *DiskMessageCache) index(key diskKey, loc diskLocation) {
+	ch, ok := c.channels[key.channelID]
+	if !ok {
+		ch = &diskChannel{locations: map[snowflake.ID]diskLocation{}}
+		c.channels[key.channelID] = ch
+	}
+
+	if old, ok := ch.locations[key.messageID]; ok {
+		old.segment.live -= old.size
+	} else {
+		i, _ := slices.BinarySearch(ch.ids, key.messageID)
+		ch.ids = slices.Insert(ch.ids, i, key.messageID)
+		c.len++
+	}
+	ch.locations[key.messageID] = loc
+	loc.segment.live += loc.size
+
+	for c.config.ChannelLimit > 0 && len(ch.ids) > c.config.ChannelLimit {
+		c.unindex(diskKey{channelID: key.channelID, messageID: ch.ids[0]})
+	}
+}
+
+// unindex removes the message from the index. It must be called with c.mu held.
+func (c *DiskMessageCache) unindex(key diskKey) (diskLocation, bool) {
+	c.removeHot(key)
+
+	ch, ok := c.channels[key.channelID]
+	if !ok {
+		return diskLocation{}, false
+	}
+	loc, ok := ch.locations[key.messageID]
+	if !ok {
+		return diskLocation{}, false
+	}
+
+	delete(ch.locations, key.messageID)
+	if i, found := slices.BinarySearch(ch.ids, key.messageID); found {
+		ch.ids = slices.Delete(ch.ids, i, i+1)
+	}
+	if len(ch.ids) == 0 {
+		delete(c.channels, key.channelID)
+	}
+	loc.segment.live -= loc.size
+	c.len--
+	return loc, true
+}
+
+// unindexChannel removes all messages of the channel from the index. It must be called with c.mu held.
+func (c *DiskMessageCache) unindexChannel(channelID snowflake.ID) {
+	ch, ok := c.channels[channelID]
+	if !ok {
+		return
+	}
+	for _, messageID := range slices.Clone(ch.ids) {
+		c.unindex(diskKey{channelID: channelID, messageID: messageID})
+	}
+}
+
+// sweep removes all expired messages from the index. It must be called with c.mu held.
+func (c *DiskMessageCache) sweep() {
+	if c.config.MaxAge <= 0 {
+		return
+	}
+	for channelID, ch := range c.channels {
+		for len(ch.ids) > 0 && c.expired(ch.ids[0]) {
+			c.unindex(diskKey{channelID: channelID, messageID: ch.ids[0]})
+		}
+	}
+}
+
+func encodeDiskRecord(op byte, key diskKey, data []byte) []byte {
+	buf := make([]byte, diskRecordHeaderSize+diskRecordKeySize+len(data))
+	body := buf[diskRecordHeaderSize:]
+	body[0] = op
+	binary.BigEndian.PutUint64(body[1:9], uint64(key.channelID))
+	binary.BigEndian.PutUint64(body[9:17], uint64(key.messageID))
+	copy(body[diskRecordKeySize:], data)
+
+	binary.BigEndian.PutUint32(buf[0:4], uint32(len(body)))
+	binary.BigEndian.PutUint32(buf[4:8], crc32.ChecksumIEEE(body))
+	return buf
+}
+
+// append writes a record to the active segment. It must be called with c.mu held.
+func (c *DiskMessageCache) append(op byte, key diskKey, data []byte) (diskLocation, error) {
+	if c.closed {
+		return diskLocation{}, ErrDiskMessageCacheClosed
+	}
+	s := c.segments[len(c.segments)-1]
+	record := encodeDiskRecord(op, key, data)
+	if _, err := s.file.WriteAt(record, s.size); err != nil {
+		return diskLocation{}, err
+	}
+
+	loc := diskLocation{
+		segment: s,
+		offset:  s.size,
+		size:    int64(len(record)),
+	}
+	s.size += loc.size
+	return loc, nil
+}
+
+// read reads & decodes the message at the given location.
+func (c *DiskMessageCache) read(loc diskLocation) (discord.Message, error) {
+	buf := make([]byte, loc.size)
+	if _, err := loc.segment.file.ReadAt(buf, loc.offset); err != nil {
+		return discord.Message{}, err
+	}
+	body := buf[diskRecordHeaderSize:]
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(buf[4:8]) {
+		return discord.Message{}, fmt.Errorf("checksum mismatch in segment %d at offset %d", loc.segment.id, loc.offset)
+	}
+
+	var message discord.Message
+	err := json.Unmarshal(body[diskRecordKeySize:], &message)
+	return message, err
+}
+
+// rotate starts a new segment once the active one is full & compacts all segments if needed. It must be called with c.mu held.
+func (c *DiskMessageCache) rotate() {
+	if c.closed || c.segments[len(c.segments)-1].size < c.config.SegmentSize {
+		return
+	}
+	c.sweep()
+
+	var size, live int64
+	for _, s := range c.segments {
+		size += s.size
+		live += s.live
+	}
+	if float64(live) < float64(size)*c.config.CompactionThreshold {
+		if err := c.compact(); err != nil {
+			c.config.Logger.Error("failed to compact segments", slog.Any("err", err))
+		} else {
+			return
+		}
+	}
+
+	s, err := c.createSegment(c.segments[len(c.segments)-1].id + 1)
+	if err != nil {
+		c.config.Logger.Error("failed to create segment", slog.Any("err", err))
+		return
+	}
+	c.segments = append(c.segments, s)
+}
+
+// compact rewrites all live records into a new segment & removes all old segments. It must be called with c.mu held.
+func (c *DiskMessageCache) compact() error {
+	c.sweep()
+
+	id := c.segments[len(c.segments)-1].id + 1
+	path := c.segmentPath(id)
+	file, err := os.OpenFile(path+".tmp", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	abort := func(err error) error {
+		_ = file.Close()
+		_ = os.Remove(path + ".tmp")
+		return err
+	}
+
+	w := bufio.NewWriter(file)
+	marker := encodeDiskRecord(diskOpCompacted, diskKey{}, nil)
+	if _, err = w.Write(marker); err != nil {
+		return abort(err)
+	}
+	offset := int64(len(marker))
+
+	offsets := make(map[diskKey]int64, c.len)
+	for channelID, ch := range c.channels {
+		for _, messageID := range ch.ids {
+			loc := ch.locations[messageID]
+			buf := make([]byte, loc.size)
+			if _, err = loc.segment.file.ReadAt(buf, loc.offset); err != nil {
+				return abort(err)
+			}
+			if _, err = w.Write(buf); err != nil {
+				return abort(err)
+			}
+			offsets[diskKey{channelID: channelID, messageID: messageID}] = offset
+			offset += loc.size
+		}
+	}
+	if err = w.Flush(); err != nil {
+		return abort(err)
+	}
+	if err = file.Sync(); err != nil {
+		return abort(err)
+	}
+	if err = os.Rename(path+".tmp", path); err != nil {
+		return abort(err)
+	}
+
+	s := &diskSegment{
+		id:   id,
+		file: file,
+		size: offset,
+	}
+	for key, newOffset := range offsets {
+		ch := c.channels[key.channelID]
+		loc := ch.locations[key.messageID]
+		ch.locations[key.messageID] = diskLocation{
+			segment: s,
+			offset:  newOffset,
+			size:    loc.size,
+		}
+		s.live += loc.size
+	}
+
+	oldSegments := c.segments
+	c.segments = []*diskSegment{s}
+	for _, old := range oldSegments {
+		_ = old.file.Close()
+		if err = os.Remove(c.segmentPath(old.id)); err != nil {
+			c.config.Logger.Error("failed to remove compacted segment", slog.Any("err", err), slog.Int("segment", old.id))
+		}
+	}
+	c.config.Logger.Debug("compacted segments", slog.Int("segments", len(oldSegments)), slog.Int("messages", c.len), slog.Int64("size", offset))
+	return nil
+}
+
+func (c *DiskMessageCache) segmentPath(id int) string {
+	return filepath.Join(c.dir, diskSegmentPrefix+strconv.Itoa(id)+diskSegmentSuffix)
+}
+
+func (c *DiskMessageCache) createSegment(id int) (*diskSegment, error) {
+	file, err := os.OpenFile(c.segmentPath(id), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &diskSegment{
+		id:   id,
+		file: file,
+	}, nil
+}
+
+// load replays all existing segments to rebuild the index.
+func (c *DiskMessageCache) load() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read disk message cache directory: %w", err)
+	}
+
+	var ids []int
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasSuffix(name, diskSegmentSuffix+".tmp") {
+			// leftover of an interrupted compaction
+			_ = os.Remove(filepath.Join(c.dir, name))
+			continue
+		}
+		if !strings.HasPrefix(name, diskSegmentPrefix) || !strings.HasSuffix(name, diskSegmentSuffix) {
+			continue
+		}
+		id, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, diskSegmentPrefix), diskSegmentSuffix))
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	for _, id := range ids {
+		file, err := os.OpenFile(c.segmentPath(id), os.O_RDWR, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to open segment %d: %w", id, err)
+		}
+		s := &diskSegment{
+			id:   id,
+			file: file,
+		}
+
+		compacted, err := c.replay(s)
+		if err != nil {
+			_ = file.Close()
+			return fmt.Errorf("failed to replay segment %d: %w", id, err)
+		}
+		if compacted {
+			// all older segments were compacted into this one, but could not be removed
+			for _, old := range c.segments {
+				_ = old.file.Close()
+				_ = os.Remove(c.segmentPath(old.id))
+			}
+			c.segments = nil
+		}
+		c.segments = append(c.segments, s)
+	}
+
+	if len(c.segments) == 0 {
+		s, err := c.createSegment(1)
+		if err != nil {
+			return fmt.Errorf("failed to create segment: %w", err)
+		}
+		c.segments = append(c.segments, s)
+	}
+	c.sweep()
+	c.config.Logger.Debug("loaded messages", slog.Int("segments", len(c.segments)), slog.Int("messages", c.len))
+	return nil
+}
+
+// replay applies all records of the segment to the index. A corrupted tail, like from a crash during a write, is truncated.
+func (c *DiskMessageCache) replay(s *diskSegment) (bool, error) {
+	var (
+		r         = bufio.NewReader(s.file)
+		header    = make([]byte, diskRecordHeaderSize)
+		compacted bool
+		corrupted bool
+	)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if !errors.Is(err, io.EOF) {
+				corrupted = true
+			}
+			break
+		}
+		n := binary.BigEndian.Uint32(header[0:4])
+		if n < diskRecordKeySize || n > diskRecordMaxSize {
+			corrupted = true
+			break
+		}
+		body := make([]byte, n)
+		if _, err := io.ReadFull(r, body); err != nil || crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(header[4:8]) {
+			corrupted = true
+			break
+		}
+
+		key := diskKey{
+			channelID: snowflake.ID(binary.BigEndian.Uint64(body[1:9])),
+			messageID: snowflake.ID(binary.BigEndian.Uint64(body[9:17])),
+		}
+		loc := diskLocation{
+			segment: s,
+			offset:  s.size,
+			size:    int64(diskRecordHeaderSize + n),
+		}
+		switch body[0] {
+		case diskOpPut:
+			c.index(key, loc)
+		case diskOpDelete:
+			c.unindex(key)
+		case diskOpDeleteChannel:
+			c.unindexChannel(key.channelID)
+		case diskOpCompacted:
+			if s.size == 0 {
+				compacted = true
+				c.channels = map[snowflake.ID]*diskChannel{}
+				c.len = 0
+			}
+		}
+		s.size += loc.size
+	}
+
+	if corrupted {
+		c.config.Logger.Warn("truncating corrupted segment", slog.Int("segment", s.id), slog.Int64("offset", s.size))
+		if err := s.file.Truncate(s.size); err != nil {
+			return false, err
+		}
+	}
+	return compacted, nil
+}
+
+func (c *DiskMessageCache) closeSegments() {
+	for _, s := range c.segments {
+		_ = s.file.Close()
+	}
+	c.segments = nil
+}
@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func defaultDiskMessageCacheConfig() diskMessageCacheConfig {
+	return diskMessageCacheConfig{
+		Logger:              slog.Default(),
+		MemoryLimit:         1000,
+		SegmentSize:         64 << 20,
+		CompactionThreshold: 0.5,
+	}
+}
+
+type diskMessageCacheConfig struct {
+	// Logger is the logger of the DiskMessageCache. Defaults to slog.Default().
+	Logger *slog.Logger
+	// MemoryLimit is the number of recently used messages kept in memory. Defaults to 1000.
+	MemoryLimit int
+	// ChannelLimit is the number of messages kept per channel, the oldest messages are removed first. Defaults to 0 (no limit).
+	ChannelLimit int
+	// MaxAge is the duration after which messages are removed, based on their creation time. Defaults to 0 (no limit).
+	MaxAge time.Duration
+	// SegmentSize is the size in bytes after which a new segment file is started. Defaults to 64 MiB.
+	SegmentSize int64
+	// CompactionThreshold is the ratio of live to total bytes on disk below which all segments are compacted. Defaults to 0.5.
+	CompactionThreshold float64
+	// Policy filters which messages are cached. Defaults to nil (all messages).
+	Policy Policy[discord.Message]
+}
+
+// DiskMessageCacheConfigOpt is a type alias for a function that takes a diskMessageCacheConfig and is used to configure your DiskMessageCache.
+type DiskMessageCacheConfigOpt func(config *diskMessageCacheConfig)
+
+func (c *diskMessageCacheConfig) apply(opts []DiskMessageCacheConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Logger = c.Logger.With(slog.String("name", "cache_disk_messages"))
+	if c.MemoryLimit < 1 {
+		c.MemoryLimit = 1
+	}
+}
+
+// WithDiskMessageCacheLogger sets the logger of the DiskMessageCache.
+func WithDiskMessageCacheLogger(logger *slog.Logger) DiskMessageCacheConfigOpt {
+	return func(config *diskMessageCacheConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithDiskMessageCacheMemoryLimit sets the number of recently used messages kept in memory.
+func WithDiskMessageCacheMemoryLimit(memoryLimit int) DiskMessageCacheConfigOpt {
+	return func(config *diskMessageCacheConfig) {
+		config.MemoryLimit = memoryLimit
+	}
+}
+
+// WithDiskMessageCacheChannelLimit sets the number of messages kept per channel. The oldest messages are removed first.
+func WithDiskMessageCacheChannelLimit(channelLimit int) DiskMessageCacheConfigOpt {
+	return func(config *diskMessageCacheConfig) {
+		config.ChannelLimit = channelLimit
+	}
+}
+
+// WithDiskMessageCacheMaxAge sets the duration after which messages are removed, based on their creation time.
+func WithDiskMessageCacheMaxAge(maxAge time.Duration) DiskMessageCacheConfigOpt {
+	return func(config *diskMessageCacheConfig) {
+		config.MaxAge = maxAge
+	}
+}
+
+// WithDiskMessageCacheSegmentSize sets the size in bytes after which a new segment file is started.
+func WithDiskMessageCacheSegmentSize(segmentSize int64) DiskMessageCacheConfigOpt {
+	return func(config *diskMessageCacheConfig) {
+		config.SegmentSize = segmentSize
+	}
+}
+
+// WithDiskMessageCacheCompactionThreshold sets the ratio of live to total bytes on disk below which all segments are compacted.
+func WithDiskMessageCacheCompactionThreshold(compactionThreshold float64) DiskMessageCacheConfigOpt {
+	return func(config *diskMessageCacheConfig) {
+		config.CompactionThreshold = compactionThreshold
+	}
+}
+
+// WithDiskMessageCachePolicy sets the Policy which filters which messages are cached.
+func WithDiskMessageCachePolicy(policy Policy[discord.Message]) DiskMessageCacheConfigOpt {
+	return func(config *diskMessageCacheConfig) {
+		config.Policy = policy
+	}
+}
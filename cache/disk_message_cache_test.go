@@ -0,0 +1,158 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func newTestDiskMessageCache(t *testing.T, dir string, opts ...DiskMessageCacheConfigOpt) *DiskMessageCache {
+	c, err := NewDiskMessageCache(dir, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	return c
+}
+
+func testMessageID(i int) snowflake.ID {
+	return snowflake.New(time.Now()) + snowflake.ID(i)
+}
+
+func TestDiskMessageCache(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	c := newTestDiskMessageCache(t, dir, WithDiskMessageCacheMemoryLimit(2))
+
+	ids := make([]snowflake.ID, 5)
+	for i := range ids {
+		ids[i] = testMessageID(i)
+		c.Put(1, ids[i], discord.Message{ID: ids[i], ChannelID: 1, Content: "message"})
+	}
+	c.Put(2, ids[0], discord.Message{ID: ids[0], ChannelID: 2, Content: "other"})
+
+	if l := c.hot.Len(); l != 2 {
+		t.Errorf("expected 2 messages in memory, got %d", l)
+	}
+	message, ok := c.Get(1, ids[0])
+	if !ok || message.Content != "message" || message.ChannelID != 1 {
+		t.Errorf("expected message to be read from disk, got %+v (%t)", message, ok)
+	}
+	if l := c.Len(); l != 6 {
+		t.Errorf("expected 6 messages, got %d", l)
+	}
+	if l := c.GroupLen(1); l != 5 {
+		t.Errorf("expected 5 messages in channel 1, got %d", l)
+	}
+
+	if message, ok = c.Remove(1, ids[1]); !ok || message.ID != ids[1] {
+		t.Errorf("expected removed message %d, got %+v (%t)", ids[1], message, ok)
+	}
+	c.GroupRemove(2)
+	c.GroupRemoveIf(1, func(_ snowflake.ID, message discord.Message) bool {
+		return message.ID == ids[2]
+	})
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	c = newTestDiskMessageCache(t, dir)
+	if l := c.Len(); l != 3 {
+		t.Errorf("expected 3 messages after reopening, got %d", l)
+	}
+	for _, id := range []snowflake.ID{ids[1], ids[2]} {
+		if _, ok = c.Get(1, id); ok {
+			t.Errorf("expected removed message %d to stay removed", id)
+		}
+	}
+	var count int
+	for channelID, message := range c.All() {
+		if channelID != 1 || message.Content != "message" {
+			t.Errorf("expected message in channel 1, got %+v in channel %d", message, channelID)
+		}
+		count++
+	}
+	if count != 3 {
+		t.Errorf("expected 3 messages, got %d", count)
+	}
+}
+
+func TestDiskMessageCache_Retention(t *testing.T) {
+	t.Parallel()
+
+	c := newTestDiskMessageCache(t, t.TempDir(), WithDiskMessageCacheChannelLimit(2), WithDiskMessageCacheMaxAge(time.Hour))
+
+	old := snowflake.New(time.Now().Add(-2 * time.Hour))
+	c.Put(1, old, discord.Message{ID: old})
+	if _, ok := c.Get(1, old); ok {
+		t.Error("expected expired message to not be cached")
+	}
+
+	ids := []snowflake.ID{testMessageID(0), testMessageID(1), testMessageID(2)}
+	for _, id := range ids {
+		c.Put(1, id, discord.Message{ID: id})
+	}
+	if l := c.GroupLen(1); l != 2 {
+		t.Errorf("expected 2 messages in channel 1, got %d", l)
+	}
+	if _, ok := c.Get(1, ids[0]); ok {
+		t.Error("expected oldest message to be removed by the channel limit")
+	}
+}
+
+func TestDiskMessageCache_Compaction(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	c := newTestDiskMessageCache(t, dir, WithDiskMessageCacheSegmentSize(4096), WithDiskMessageCacheMemoryLimit(1))
+
+	id := testMessageID(0)
+	for i := range 100 {
+		c.Put(1, id, discord.Message{ID: id, Content: "edit " + strconv.Itoa(i)})
+	}
+	c.Put(1, id+1, discord.Message{ID: id + 1, Content: "other"})
+
+	segments, err := filepath.Glob(filepath.Join(dir, "segment-*.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) > 3 {
+		t.Errorf("expected segments to be compacted, got %d", len(segments))
+	}
+	if err = c.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate a torn write at the end of the segment
+	segments, _ = filepath.Glob(filepath.Join(dir, "segment-*.log"))
+	if len(segments) != 1 {
+		t.Fatalf("expected 1 segment after compaction, got %d", len(segments))
+	}
+	f, err := os.OpenFile(segments[0], os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.Write([]byte{0, 0, 1})
+	_ = f.Close()
+
+	c = newTestDiskMessageCache(t, dir)
+	if l := c.Len(); l != 2 {
+		t.Errorf("expected 2 messages after reopening, got %d", l)
+	}
+	message, ok := c.Get(1, id)
+	if !ok || message.Content != "edit 99" {
+		t.Errorf("expected latest edit, got %+v (%t)", message, ok)
+	}
+}
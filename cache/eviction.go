@@ -0,0 +1,318 @@
+package cache
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// EvictionStrategy decides which entities are evicted first once a cache reaches its Eviction.MaxEntries.
+type EvictionStrategy int
+
+const (
+	// EvictionStrategyLRU evicts the least recently used entities first.
+	EvictionStrategyLRU EvictionStrategy = iota
+	// EvictionStrategyLFU evicts the least frequently used entities first. Entities used equally often are evicted least recently used first.
+	EvictionStrategyLFU
+)
+
+// EvictionReason is the reason why an entity was evicted from a cache.
+type EvictionReason int
+
+const (
+	// EvictionReasonSize is used when the entity was evicted to stay within Eviction.MaxEntries.
+	EvictionReasonSize EvictionReason = iota
+	// EvictionReasonTTL is used when the entity was not written for Eviction.TTL.
+	EvictionReasonTTL
+	// EvictionReasonIdle is used when the entity was not accessed for Eviction.MaxIdle.
+	EvictionReasonIdle
+)
+
+func (r EvictionReason) String() string {
+	switch r {
+	case EvictionReasonSize:
+		return "size"
+	case EvictionReasonTTL:
+		return "ttl"
+	case EvictionReasonIdle:
+		return "idle"
+	}
+	return "unknown"
+}
+
+// EvictFunc is called after an entity was evicted from a cache. The groupID is always 0 for a Cache.
+// It is called without holding any locks of the cache, so it is safe to access the cache from it.
+type EvictFunc[T any] func(groupID snowflake.ID, id snowflake.ID, entity T, reason EvictionReason)
+
+// DefaultEvictionCleanupInterval is the default Eviction.CleanupInterval.
+const DefaultEvictionCleanupInterval = time.Minute
+
+// Eviction configures how entities are evicted from a cache created with NewEvictionCache or NewEvictionGroupedCache.
+// The zero value never evicts anything.
+type Eviction[T any] struct {
+	// Strategy decides which entities are evicted first once MaxEntries is reached. Defaults to EvictionStrategyLRU.
+	Strategy EvictionStrategy
+	// MaxEntries is the max number of entities in the cache. Defaults to 0 (no limit).
+	MaxEntries int
+	// TTL is the duration after which an entity is evicted once it was written. Defaults to 0 (no expiry).
+	TTL time.Duration
+	// MaxIdle is the duration after which an entity is evicted once it was last written or returned by Get. Defaults to 0 (no expiry).
+	MaxIdle time.Duration
+	// CleanupInterval is the min interval between sweeps of all entities for expired ones.
+	// Expired entities are always evicted when accessed. Defaults to DefaultEvictionCleanupInterval.
+	CleanupInterval time.Duration
+	// OnEvict is called for every evicted entity. Defaults to nil.
+	OnEvict EvictFunc[T]
+}
+
+type evictionKey struct {
+	groupID snowflake.ID
+	id      snowflake.ID
+}
+
+type evictionEntry[T any] struct {
+	key      evictionKey
+	entity   T
+	written  time.Time
+	accessed time.Time
+	// seq is the order of the last access, used instead of accessed as the clock might not be monotonic
+	seq   uint64
+	hits  uint64
+	index int
+}
+
+type evictionItem[T any] struct {
+	key    evictionKey
+	entity T
+	reason EvictionReason
+}
+
+func newEvictionStore[T any](eviction Eviction[T]) *evictionStore[T] {
+	if eviction.CleanupInterval <= 0 {
+		eviction.CleanupInterval = DefaultEvictionCleanupInterval
+	}
+	return &evictionStore[T]{
+		eviction: eviction,
+		now:      time.Now,
+		groups:   map[snowflake.ID]map[snowflake.ID]*evictionEntry[T]{},
+		queue:    evictionQueue[T]{lfu: eviction.Strategy == EvictionStrategyLFU},
+	}
+}
+
+// evictionStore holds the entities of a cache with eviction. All methods except do must be called from within do.
+type evictionStore[T any] struct {
+	mu          sync.Mutex
+	eviction    Eviction[T]
+	now         func() time.Time
+	groups      map[snowflake.ID]map[snowflake.ID]*evictionEntry[T]
+	queue       evictionQueue[T]
+	seq         uint64
+	lastCleanup time.Time
+	evicted     []evictionItem[T]
+}
+
+// do runs fn while holding the lock and calls Eviction.OnEvict for all entities evicted meanwhile afterward.
+func (s *evictionStore[T]) do(fn func()) {
+	s.mu.Lock()
+	s.cleanup()
+	fn()
+	evicted := s.evicted
+	s.evicted = nil
+	s.mu.Unlock()
+
+	if s.eviction.OnEvict == nil {
+		return
+	}
+	for _, e := range evicted {
+		s.eviction.OnEvict(e.key.groupID, e.key.id, e.entity, e.reason)
+	}
+}
+
+func (s *evictionStore[T]) get(key evictionKey) (T, bool) {
+	e, ok := s.groups[key.groupID][key.id]
+	if !ok {
+		var entity T
+		return entity, false
+	}
+	if reason, expired := s.expired(e, s.now()); expired {
+		s.evict(e, reason)
+		var entity T
+		return entity, false
+	}
+	s.touch(e)
+	return e.entity, true
+}
+
+func (s *evictionStore[T]) put(key evictionKey, entity T) {
+	if e, ok := s.groups[key.groupID][key.id]; ok {
+		e.entity = entity
+		e.written = s.now()
+		s.touch(e)
+		return
+	}
+
+	// evict before inserting, so new entities are not evicted right away under EvictionStrategyLFU
+	for s.eviction.MaxEntries > 0 && s.queue.Len() >= s.eviction.MaxEntries {
+		s.evict(s.queue.entries[0], EvictionReasonSize)
+	}
+
+	group, ok := s.groups[key.groupID]
+	if !ok {
+		group = map[snowflake.ID]*evictionEntry[T]{}
+		s.groups[key.groupID] = group
+	}
+	e := &evictionEntry[T]{
+		key:     key,
+		entity:  entity,
+		written: s.now(),
+	}
+	group[key.id] = e
+	heap.Push(&s.queue, e)
+	s.touch(e)
+}
+
+func (s *evictionStore[T]) remove(key evictionKey) (T, bool) {
+	e, ok := s.groups[key.groupID][key.id]
+	if !ok {
+		var entity T
+		return entity, false
+	}
+	s.delete(e)
+	return e.entity, true
+}
+
+func (s *evictionStore[T]) removeGroup(groupID snowflake.ID) {
+	for _, e := range s.groups[groupID] {
+		s.delete(e)
+	}
+}
+
+func (s *evictionStore[T]) removeIf(groupID *snowflake.ID, filterFunc GroupedFilterFunc[T]) {
+	for id, group := range s.groups {
+		if groupID != nil && id != *groupID {
+			continue
+		}
+		for _, e := range group {
+			if filterFunc(id, e.entity) {
+				s.delete(e)
+			}
+		}
+	}
+}
+
+func (s *evictionStore[T]) len() int {
+	return s.queue.Len()
+}
+
+func (s *evictionStore[T]) groupLen(groupID snowflake.ID) int {
+	return len(s.groups[groupID])
+}
+
+// all returns a snapshot of all entities which are not expired, optionally only of the given group.
+func (s *evictionStore[T]) all(groupID *snowflake.ID) []evictionItem[T] {
+	now := s.now()
+	var entries []evictionItem[T]
+	for id, group := range s.groups {
+		if groupID != nil && id != *groupID {
+			continue
+		}
+		for _, e := range group {
+			if _, expired := s.expired(e, now); !expired {
+				entries = append(entries, evictionItem[T]{key: e.key, entity: e.entity})
+			}
+		}
+	}
+	return entries
+}
+
+func (s *evictionStore[T]) touch(e *evictionEntry[T]) {
+	s.seq++
+	e.seq = s.seq
+	e.hits++
+	e.accessed = s.now()
+	heap.Fix(&s.queue, e.index)
+}
+
+func (s *evictionStore[T]) expired(e *evictionEntry[T], now time.Time) (EvictionReason, bool) {
+	if s.eviction.TTL > 0 && now.Sub(e.written) >= s.eviction.TTL {
+		return EvictionReasonTTL, true
+	}
+	if s.eviction.MaxIdle > 0 && now.Sub(e.accessed) >= s.eviction.MaxIdle {
+		return EvictionReasonIdle, true
+	}
+	return 0, false
+}
+
+func (s *evictionStore[T]) evict(e *evictionEntry[T], reason EvictionReason) {
+	s.delete(e)
+	s.evicted = append(s.evicted, evictionItem[T]{key: e.key, entity: e.entity, reason: reason})
+}
+
+func (s *evictionStore[T]) delete(e *evictionEntry[T]) {
+	heap.Remove(&s.queue, e.index)
+	group := s.groups[e.key.groupID]
+	delete(group, e.key.id)
+	if len(group) == 0 {
+		delete(s.groups, e.key.groupID)
+	}
+}
+
+// cleanup evicts all expired entities at most once per Eviction.CleanupInterval.
+func (s *evictionStore[T]) cleanup() {
+	if s.eviction.TTL <= 0 && s.eviction.MaxIdle <= 0 {
+		return
+	}
+	now := s.now()
+	if now.Sub(s.lastCleanup) < s.eviction.CleanupInterval {
+		return
+	}
+	s.lastCleanup = now
+
+	for _, group := range s.groups {
+		for _, e := range group {
+			if reason, expired := s.expired(e, now); expired {
+				s.evict(e, reason)
+			}
+		}
+	}
+}
+
+// evictionQueue is a min heap of entries ordered by which entry should be evicted first.
+type evictionQueue[T any] struct {
+	lfu     bool
+	entries []*evictionEntry[T]
+}
+
+func (q *evictionQueue[T]) Len() int {
+	return len(q.entries)
+}
+
+func (q *evictionQueue[T]) Less(i, j int) bool {
+	if q.lfu && q.entries[i].hits != q.entries[j].hits {
+		return q.entries[i].hits < q.entries[j].hits
+	}
+	return q.entries[i].seq < q.entries[j].seq
+}
+
+func (q *evictionQueue[T]) Swap(i, j int) {
+	q.entries[i], q.entries[j] = q.entries[j], q.entries[i]
+	q.entries[i].index = i
+	q.entries[j].index = j
+}
+
+func (q *evictionQueue[T]) Push(x any) {
+	e := x.(*evictionEntry[T])
+	e.index = len(q.entries)
+	q.entries = append(q.entries, e)
+}
+
+func (q *evictionQueue[T]) Pop() any {
+	n := len(q.entries)
+	e := q.entries[n-1]
+	q.entries[n-1] = nil
+	q.entries = q.entries[:n-1]
+	e.index = -1
+	return e
+}
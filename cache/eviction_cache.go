@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"iter"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+var (
+	_ Cache[any]        = (*evictionCache[any])(nil)
+	_ GroupedCache[any] = (*evictionGroupedCache[any])(nil)
+)
+
+// NewEvictionCache returns a new thread safe Cache which filters the entities after the given Flags and Policy and evicts them according to the given Eviction.
+func NewEvictionCache[T any](flags Flags, neededFlags Flags, policy Policy[T], eviction Eviction[T]) Cache[T] {
+	return &evictionCache[T]{
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+		store:       newEvictionStore(eviction),
+	}
+}
+
+type evictionCache[T any] struct {
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[T]
+	store       *evictionStore[T]
+}
+
+func (c *evictionCache[T]) Get(id snowflake.ID) (entity T, ok bool) {
+	c.store.do(func() {
+		entity, ok = c.store.get(evictionKey{id: id})
+	})
+	return
+}
+
+func (c *evictionCache[T]) Put(id snowflake.ID, entity T) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	if c.policy != nil && !c.policy(entity) {
+		return
+	}
+	c.store.do(func() {
+		c.store.put(evictionKey{id: id}, entity)
+	})
+}
+
+func (c *evictionCache[T]) Remove(id snowflake.ID) (entity T, ok bool) {
+	c.store.do(func() {
+		entity, ok = c.store.remove(evictionKey{id: id})
+	})
+	return
+}
+
+func (c *evictionCache[T]) RemoveIf(filterFunc FilterFunc[T]) {
+	c.store.do(func() {
+		c.store.removeIf(nil, func(_ snowflake.ID, entity T) bool {
+			return filterFunc(entity)
+		})
+	})
+}
+
+func (c *evictionCache[T]) Len() (l int) {
+	c.store.do(func() {
+		l = c.store.len()
+	})
+	return
+}
+
+func (c *evictionCache[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var items []evictionItem[T]
+		c.store.do(func() {
+			items = c.store.all(nil)
+		})
+		for _, item := range items {
+			if !yield(item.entity) {
+				return
+			}
+		}
+	}
+}
+
+// NewEvictionGroupedCache returns a new thread safe GroupedCache which filters the entities after the given Flags and Policy and evicts them according to the given Eviction.
+// Eviction.MaxEntries applies to all entities in the cache, not per group.
+func NewEvictionGroupedCache[T any](flags Flags, neededFlags Flags, policy Policy[T], eviction Eviction[T]) GroupedCache[T] {
+	return &evictionGroupedCache[T]{
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+		store:       newEvictionStore(eviction),
+	}
+}
+
+type evictionGroupedCache[T any] struct {
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[T]
+	store       *evictionStore[T]
+}
+
+func (c *evictionGroupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (entity T, ok bool) {
+	c.store.do(func() {
+		entity, ok = c.store.get(evictionKey{groupID: groupID, id: id})
+	})
+	return
+}
+
+func (c *evictionGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, entity T) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	if c.policy != nil && !c.policy(entity) {
+		return
+	}
+	c.store.do(func() {
+		c.store.put(evictionKey{groupID: groupID, id: id}, entity)
+	})
+}
+
+func (c *evictionGroupedCache[T]) Remove(groupID snowflake.ID, id snowflake.ID) (entity T, ok bool) {
+	c.store.do(func() {
+		entity, ok = c.store.remove(evictionKey{groupID: groupID, id: id})
+	})
+	return
+}
+
+func (c *evictionGroupedCache[T]) GroupRemove(groupID snowflake.ID) {
+	c.store.do(func() {
+		c.store.removeGroup(groupID)
+	})
+}
+
+func (c *evictionGroupedCache[T]) RemoveIf(filterFunc GroupedFilterFunc[T]) {
+	c.store.do(func() {
+		c.store.removeIf(nil, filterFunc)
+	})
+}
+
+func (c *evictionGroupedCache[T]) GroupRemoveIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[T]) {
+	c.store.do(func() {
+		c.store.removeIf(&groupID, filterFunc)
+	})
+}
+
+func (c *evictionGroupedCache[T]) Len() (l int) {
+	c.store.do(func() {
+		l = c.store.len()
+	})
+	return
+}
+
+func (c *evictionGroupedCache[T]) GroupLen(groupID snowflake.ID) (l int) {
+	c.store.do(func() {
+		l = c.store.groupLen(groupID)
+	})
+	return
+}
+
+func (c *evictionGroupedCache[T]) All() iter.Seq2[snowflake.ID, T] {
+	return func(yield func(snowflake.ID, T) bool) {
+		var items []evictionItem[T]
+		c.store.do(func() {
+			items = c.store.all(nil)
+		})
+		for _, item := range items {
+			if !yield(item.key.groupID, item.entity) {
+				return
+			}
+		}
+	}
+}
+
+func (c *evictionGroupedCache[T]) GroupAll(groupID snowflake.ID) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var items []evictionItem[T]
+		c.store.do(func() {
+			items = c.store.all(&groupID)
+		})
+		for _, item := range items {
+			if !yield(item.entity) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+type evictionRecorder struct {
+	ids     []snowflake.ID
+	reasons []EvictionReason
+}
+
+func (r *evictionRecorder) onEvict(_ snowflake.ID, id snowflake.ID, _ discord.Role, reason EvictionReason) {
+	r.ids = append(r.ids, id)
+	r.reasons = append(r.reasons, reason)
+}
+
+func TestEvictionCache_LRU(t *testing.T) {
+	t.Parallel()
+
+	var r evictionRecorder
+	c := NewEvictionCache[discord.Role](FlagsAll, FlagRoles, nil, Eviction[discord.Role]{
+		MaxEntries: 2,
+		OnEvict:    r.onEvict,
+	})
+
+	c.Put(1, discord.Role{ID: 1})
+	c.Put(2, discord.Role{ID: 2})
+	c.Get(1)
+	c.Put(3, discord.Role{ID: 3})
+
+	if _, ok := c.Get(2); ok {
+		t.Error("expected least recently used role to be evicted")
+	}
+	if l := c.Len(); l != 2 {
+		t.Errorf("expected 2 roles, got %d", l)
+	}
+	if !slices.Equal(r.ids, []snowflake.ID{2}) || r.reasons[0] != EvictionReasonSize {
+		t.Errorf("expected role 2 to be evicted by size, got %v %v", r.ids, r.reasons)
+	}
+}
+
+func TestEvictionCache_LFU(t *testing.T) {
+	t.Parallel()
+
+	c := NewEvictionCache[discord.Role](FlagsAll, FlagRoles, nil, Eviction[discord.Role]{
+		Strategy:   EvictionStrategyLFU,
+		MaxEntries: 2,
+	})
+
+	c.Put(1, discord.Role{ID: 1})
+	c.Put(2, discord.Role{ID: 2})
+	c.Get(1)
+	c.Get(1)
+	c.Get(2)
+	c.Put(3, discord.Role{ID: 3})
+
+	if _, ok := c.Get(2); ok {
+		t.Error("expected least frequently used role to be evicted")
+	}
+	if _, ok := c.Get(3); !ok {
+		t.Error("expected new role to be cached")
+	}
+}
+
+func TestEvictionGroupedCache_Expiry(t *testing.T) {
+	t.Parallel()
+
+	var r evictionRecorder
+	c := NewEvictionGroupedCache[discord.Role](FlagsAll, FlagRoles, nil, Eviction[discord.Role]{
+		TTL:             time.Hour,
+		MaxIdle:         10 * time.Minute,
+		CleanupInterval: time.Minute,
+		OnEvict:         r.onEvict,
+	}).(*evictionGroupedCache[discord.Role])
+
+	now := time.Now()
+	c.store.now = func() time.Time { return now }
+
+	c.Put(1, 1, discord.Role{ID: 1})
+	c.Put(1, 2, discord.Role{ID: 2})
+	c.Put(2, 3, discord.Role{ID: 3})
+
+	for range 6 {
+		now = now.Add(9 * time.Minute)
+		c.Get(1, 1)
+	}
+	if l := c.Len(); l != 1 {
+		t.Errorf("expected idle roles to be evicted, got %d roles", l)
+	}
+	now = now.Add(7 * time.Minute)
+	if _, ok := c.Get(1, 1); ok {
+		t.Error("expected role to be evicted after its ttl")
+	}
+
+	slices.Sort(r.ids)
+	if !slices.Equal(r.ids, []snowflake.ID{1, 2, 3}) {
+		t.Errorf("expected all roles to be evicted, got %v", r.ids)
+	}
+	if reasons := slices.Compact(slices.Sorted(slices.Values(r.reasons))); !slices.Equal(reasons, []EvictionReason{EvictionReasonTTL, EvictionReasonIdle}) {
+		t.Errorf("expected ttl & idle evictions, got %v", r.reasons)
+	}
+	if l := c.GroupLen(1); l != 0 {
+		t.Errorf("expected empty group, got %d roles", l)
+	}
+}
@@ -0,0 +1,190 @@
+package cache
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// hamtBits is the number of hash bits consumed per level of a hamt.
+const hamtBits = 6
+
+// hamt is an immutable hash array mapped trie keyed by uint64. Every modification returns a new hamt sharing all unmodified nodes with the old one,
+// so a hamt can be read & iterated from any goroutine without locking.
+type hamt[V any] struct {
+	root *hamtNode[V]
+	len  int
+}
+
+type hamtNode[V any] struct {
+	bitmap   uint64
+	children []hamtChild[V]
+}
+
+// hamtChild is either a node or a leaf.
+type hamtChild[V any] struct {
+	node *hamtNode[V]
+	leaf *hamtLeaf[V]
+}
+
+type hamtLeaf[V any] struct {
+	key   uint64
+	hash  uint64
+	value V
+}
+
+// hamtHash spreads the bits of snowflakes, which mostly differ in their low & high bits, over the whole key.
+// It is a bijection, so different keys never have the same hash.
+func hamtHash(key uint64) uint64 {
+	key = (key ^ (key >> 30)) * 0xbf58476d1ce4e5b9
+	key = (key ^ (key >> 27)) * 0x94d049bb133111eb
+	return key ^ (key >> 31)
+}
+
+func hamtBit(hash uint64, shift uint) uint64 {
+	return 1 << ((hash >> shift) & (1<<hamtBits - 1))
+}
+
+func (m hamt[V]) get(key uint64) (V, bool) {
+	hash := hamtHash(key)
+	n := m.root
+	for shift := uint(0); n != nil; shift += hamtBits {
+		bit := hamtBit(hash, shift)
+		if n.bitmap&bit == 0 {
+			break
+		}
+		child := n.children[bits.OnesCount64(n.bitmap&(bit-1))]
+		if child.leaf != nil {
+			if child.leaf.key == key {
+				return child.leaf.value, true
+			}
+			break
+		}
+		n = child.node
+	}
+	var value V
+	return value, false
+}
+
+func (m hamt[V]) put(key uint64, value V) hamt[V] {
+	root, added := m.root.put(&hamtLeaf[V]{key: key, hash: hamtHash(key), value: value}, 0)
+	if added {
+		m.len++
+	}
+	m.root = root
+	return m
+}
+
+func (m hamt[V]) remove(key uint64) (hamt[V], V, bool) {
+	root, leaf := m.root.remove(hamtHash(key), key, 0)
+	if leaf == nil {
+		var value V
+		return m, value, false
+	}
+	m.root = root
+	m.len--
+	return m, leaf.value, true
+}
+
+func (m hamt[V]) all() iter.Seq2[uint64, V] {
+	return func(yield func(uint64, V) bool) {
+		m.root.all(yield)
+	}
+}
+
+func (n *hamtNode[V]) put(leaf *hamtLeaf[V], shift uint) (*hamtNode[V], bool) {
+	bit := hamtBit(leaf.hash, shift)
+	if n == nil {
+		return &hamtNode[V]{bitmap: bit, children: []hamtChild[V]{{leaf: leaf}}}, true
+	}
+
+	pos := bits.OnesCount64(n.bitmap & (bit - 1))
+	if n.bitmap&bit == 0 {
+		return &hamtNode[V]{
+			bitmap:   n.bitmap | bit,
+			children: slices.Insert(slices.Clone(n.children), pos, hamtChild[V]{leaf: leaf}),
+		}, true
+	}
+
+	var (
+		child hamtChild[V]
+		added bool
+	)
+	switch old := n.children[pos]; {
+	case old.node != nil:
+		child.node, added = old.node.put(leaf, shift+hamtBits)
+	case old.leaf.key == leaf.key:
+		child.leaf = leaf
+	default:
+		child.node, _ = (*hamtNode[V])(nil).put(old.leaf, shift+hamtBits)
+		child.node, added = child.node.put(leaf, shift+hamtBits)
+	}
+
+	children := slices.Clone(n.children)
+	children[pos] = child
+	return &hamtNode[V]{bitmap: n.bitmap, children: children}, added
+}
+
+func (n *hamtNode[V]) remove(hash uint64, key uint64, shift uint) (*hamtNode[V], *hamtLeaf[V]) {
+	if n == nil {
+		return nil, nil
+	}
+	bit := hamtBit(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, nil
+	}
+
+	pos := bits.OnesCount64(n.bitmap & (bit - 1))
+	old := n.children[pos]
+	var (
+		child   hamtChild[V]
+		removed *hamtLeaf[V]
+	)
+	if old.leaf != nil {
+		if old.leaf.key != key {
+			return n, nil
+		}
+		removed = old.leaf
+	} else {
+		var node *hamtNode[V]
+		if node, removed = old.node.remove(hash, key, shift+hamtBits); removed == nil {
+			return n, nil
+		}
+		if node != nil && len(node.children) == 1 && node.children[0].leaf != nil {
+			// pull up single leafs to keep the trie shallow
+			child.leaf = node.children[0].leaf
+		} else {
+			child.node = node
+		}
+	}
+
+	if child.node == nil && child.leaf == nil {
+		if len(n.children) == 1 {
+			return nil, removed
+		}
+		return &hamtNode[V]{
+			bitmap:   n.bitmap &^ bit,
+			children: slices.Delete(slices.Clone(n.children), pos, pos+1),
+		}, removed
+	}
+
+	children := slices.Clone(n.children)
+	children[pos] = child
+	return &hamtNode[V]{bitmap: n.bitmap, children: children}, removed
+}
+
+func (n *hamtNode[V]) all(yield func(uint64, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	for _, child := range n.children {
+		if child.leaf != nil {
+			if !yield(child.leaf.key, child.leaf.value) {
+				return false
+			}
+		} else if !child.node.all(yield) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,285 @@
+package cache
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/internal/flags"
+)
+
+// Indexes are used to enable the secondary indexes of the built-in caches, which speed up the query methods of Caches.
+// Without the index, the query methods scan the whole cache.
+type Indexes int
+
+// values for Indexes
+const (
+	// IndexMembersByRole indexes members by their role IDs for Caches.MembersByRole.
+	IndexMembersByRole Indexes = 1 << iota
+	// IndexMembersByUser indexes members by their user ID across all guilds for Caches.MembersByUser.
+	IndexMembersByUser
+	// IndexMembersByName indexes members by their nickname, global name & username for Caches.MembersByNamePrefix.
+	IndexMembersByName
+	// IndexChannelsByParent indexes channels & threads by their parent ID for Caches.ChannelsByParent & Caches.GuildThreadsInChannel.
+	IndexChannelsByParent
+
+	IndexesNone Indexes = 0
+	IndexesAll          = IndexMembersByRole |
+		IndexMembersByUser |
+		IndexMembersByName |
+		IndexChannelsByParent
+)
+
+// Add allows you to add multiple bits together, producing a new bit
+func (i Indexes) Add(bits ...Indexes) Indexes {
+	return flags.Add(i, bits...)
+}
+
+// Remove allows you to subtract multiple bits from the first, producing a new bit
+func (i Indexes) Remove(bits ...Indexes) Indexes {
+	return flags.Remove(i, bits...)
+}
+
+// Has will ensure that the bit includes all the bits entered
+func (i Indexes) Has(bits ...Indexes) bool {
+	return flags.Has(i, bits...)
+}
+
+// Missing will check whether the bit is missing any one of the bits
+func (i Indexes) Missing(bits ...Indexes) bool {
+	return flags.Missing(i, bits...)
+}
+
+// IndexRef references an entity in a cache. The GroupID is always 0 for a Cache.
+type IndexRef struct {
+	GroupID snowflake.ID
+	ID      snowflake.ID
+}
+
+// NewIndex returns an Index of the given Observable cache which maps the keys returned by the keysFunc to the entities.
+// The Index only sees changes made after it was created, so it should be created before the cache is populated.
+func NewIndex[K comparable, T any](observable Observable[T], keysFunc func(entity T) []K) *Index[K, T] {
+	i := &Index[K, T]{
+		keysFunc: keysFunc,
+		refs:     map[K]map[IndexRef]struct{}{},
+	}
+	observable.Observe(i.observe)
+	return i
+}
+
+// Index is a secondary index of an Observable cache. It is kept up to date with every Change of the cache.
+type Index[K comparable, T any] struct {
+	mu       sync.RWMutex
+	keysFunc func(entity T) []K
+	refs     map[K]map[IndexRef]struct{}
+}
+
+func (i *Index[K, T]) observe(change Change[T]) {
+	ref := IndexRef{GroupID: change.GroupID, ID: change.ID}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if change.Old != nil {
+		for _, key := range i.keysFunc(*change.Old) {
+			refs := i.refs[key]
+			delete(refs, ref)
+			if len(refs) == 0 {
+				delete(i.refs, key)
+			}
+		}
+	}
+	if change.New != nil {
+		for _, key := range i.keysFunc(*change.New) {
+			refs, ok := i.refs[key]
+			if !ok {
+				refs = map[IndexRef]struct{}{}
+				i.refs[key] = refs
+			}
+			refs[ref] = struct{}{}
+		}
+	}
+}
+
+// Lookup returns the references to all entities with the given key.
+func (i *Index[K, T]) Lookup(key K) []IndexRef {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	refs := make([]IndexRef, 0, len(i.refs[key]))
+	for ref := range i.refs[key] {
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// Len returns the number of entities with the given key.
+func (i *Index[K, T]) Len(key K) int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.refs[key])
+}
+
+// NewPrefixIndex returns a PrefixIndex of the given Observable cache which maps the keys returned by the keysFunc to the entities.
+// Keys are compared case-insensitively. The PrefixIndex only sees changes made after it was created, so it should be created before the cache is populated.
+func NewPrefixIndex[T any](observable Observable[T], keysFunc func(entity T) []string) *PrefixIndex[T] {
+	i := &PrefixIndex[T]{
+		keysFunc: keysFunc,
+		head:     prefixIndexNode{next: make([]*prefixIndexNode, prefixIndexMaxLevel)},
+		level:    1,
+	}
+	observable.Observe(i.observe)
+	return i
+}
+
+// PrefixIndex is a secondary index of an Observable cache which allows looking up entities by a key prefix. It is kept up to date with every Change of the cache.
+// The entries are kept in a skip list sorted by key and ref, so adding & removing an entry takes O(log n) on average.
+type PrefixIndex[T any] struct {
+	mu       sync.RWMutex
+	keysFunc func(entity T) []string
+	head     prefixIndexNode
+	level    int
+}
+
+// prefixIndexMaxLevel is the maximum height of the skip list, which is enough for 4^16 entries.
+const prefixIndexMaxLevel = 16
+
+type prefixIndexEntry struct {
+	key string
+	ref IndexRef
+}
+
+type prefixIndexNode struct {
+	entry prefixIndexEntry
+	// next holds the following node for each level of this node
+	next []*prefixIndexNode
+}
+
+func comparePrefixIndexEntries(a prefixIndexEntry, b prefixIndexEntry) int {
+	if c := strings.Compare(a.key, b.key); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.ref.GroupID, b.ref.GroupID); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.ref.ID, b.ref.ID)
+}
+
+func (i *PrefixIndex[T]) keys(entity T) []string {
+	keys := slices.Clone(i.keysFunc(entity))
+	for j, key := range keys {
+		keys[j] = strings.ToLower(key)
+	}
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
+func (i *PrefixIndex[T]) observe(change Change[T]) {
+	ref := IndexRef{GroupID: change.GroupID, ID: change.ID}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if change.Old != nil {
+		for _, key := range i.keys(*change.Old) {
+			i.remove(prefixIndexEntry{key: key, ref: ref})
+		}
+	}
+	if change.New != nil {
+		for _, key := range i.keys(*change.New) {
+			i.insert(prefixIndexEntry{key: key, ref: ref})
+		}
+	}
+}
+
+// seek returns the last node before the first entry for which compare returns >= 0 on each level.
+func (i *PrefixIndex[T]) seek(compare func(entry prefixIndexEntry) int) [prefixIndexMaxLevel]*prefixIndexNode {
+	var prev [prefixIndexMaxLevel]*prefixIndexNode
+	node := &i.head
+	for level := i.level - 1; level >= 0; level-- {
+		for node.next[level] != nil && compare(node.next[level].entry) < 0 {
+			node = node.next[level]
+		}
+		prev[level] = node
+	}
+	return prev
+}
+
+func (i *PrefixIndex[T]) insert(entry prefixIndexEntry) {
+	prev := i.seek(func(e prefixIndexEntry) int {
+		return comparePrefixIndexEntries(e, entry)
+	})
+	if next := prev[0].next[0]; next != nil && next.entry == entry {
+		return
+	}
+
+	// every level is used by a quarter of the nodes of the level below
+	level := 1
+	for level < prefixIndexMaxLevel && rand.Uint32()&3 == 0 {
+		level++
+	}
+	for ; i.level < level; i.level++ {
+		prev[i.level] = &i.head
+	}
+
+	node := &prefixIndexNode{entry: entry, next: make([]*prefixIndexNode, level)}
+	for l := range level {
+		node.next[l] = prev[l].next[l]
+		prev[l].next[l] = node
+	}
+}
+
+func (i *PrefixIndex[T]) remove(entry prefixIndexEntry) {
+	prev := i.seek(func(e prefixIndexEntry) int {
+		return comparePrefixIndexEntries(e, entry)
+	})
+	node := prev[0].next[0]
+	if node == nil || node.entry != entry {
+		return
+	}
+
+	for l := range node.next {
+		prev[l].next[l] = node.next[l]
+	}
+	for i.level > 1 && i.head.next[i.level-1] == nil {
+		i.level--
+	}
+}
+
+// Lookup returns the references to all entities with a key starting with the given prefix. Every entity is only returned once.
+func (i *PrefixIndex[T]) Lookup(prefix string) []IndexRef {
+	prefix = strings.ToLower(prefix)
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	prev := i.seek(func(entry prefixIndexEntry) int {
+		return strings.Compare(entry.key, prefix)
+	})
+
+	var refs []IndexRef
+	seen := map[IndexRef]struct{}{}
+	for node := prev[0].next[0]; node != nil && strings.HasPrefix(node.entry.key, prefix); node = node.next[0] {
+		if _, ok := seen[node.entry.ref]; !ok {
+			seen[node.entry.ref] = struct{}{}
+			refs = append(refs, node.entry.ref)
+		}
+	}
+	return refs
+}
+
+// lookupEntities returns an iter.Seq of the entities referenced by refs which are still cached.
+func lookupEntities[T any](refs []IndexRef, get func(groupID snowflake.ID, id snowflake.ID) (T, bool)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, ref := range refs {
+			if entity, ok := get(ref.GroupID, ref.ID); ok && !yield(entity) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package cache
+
+import (
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func memberIDs(members iter.Seq[discord.Member]) []snowflake.ID {
+	var ids []snowflake.ID
+	for member := range members {
+		ids = append(ids, member.User.ID)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func TestCaches_Indexes(t *testing.T) {
+	t.Parallel()
+
+	nick := "Alpha"
+	globalName := "Beta"
+
+	for _, indexes := range []Indexes{IndexesNone, IndexesAll} {
+		caches := New(WithCaches(FlagsAll), WithIndexes(indexes))
+
+		caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: 1, Username: "user1"}, Nick: &nick, RoleIDs: []snowflake.ID{10, 11}})
+		caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: 2, Username: "alfred", GlobalName: &globalName}, RoleIDs: []snowflake.ID{10}})
+		caches.AddMember(discord.Member{GuildID: 2, User: discord.User{ID: 1, Username: "user1"}, RoleIDs: []snowflake.ID{20}})
+		caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: 2, Username: "alfred", GlobalName: &globalName}, RoleIDs: []snowflake.ID{11}})
+
+		if ids := memberIDs(caches.MembersByRole(1, 10)); !slices.Equal(ids, []snowflake.ID{1}) {
+			t.Errorf("indexes %d: expected members [1] with role 10, got %v", indexes, ids)
+		}
+		if ids := memberIDs(caches.MembersByRole(1, 11)); !slices.Equal(ids, []snowflake.ID{1, 2}) {
+			t.Errorf("indexes %d: expected members [1 2] with role 11, got %v", indexes, ids)
+		}
+		if ids := memberIDs(caches.MembersByUser(1)); !slices.Equal(ids, []snowflake.ID{1, 1}) {
+			t.Errorf("indexes %d: expected member 1 in 2 guilds, got %v", indexes, ids)
+		}
+		if ids := memberIDs(caches.MembersByNamePrefix(1, "AL")); !slices.Equal(ids, []snowflake.ID{1, 2}) {
+			t.Errorf("indexes %d: expected members [1 2] with prefix al, got %v", indexes, ids)
+		}
+		if ids := memberIDs(caches.MembersByNamePrefix(1, "bet")); !slices.Equal(ids, []snowflake.ID{2}) {
+			t.Errorf("indexes %d: expected members [2] with prefix bet, got %v", indexes, ids)
+		}
+		if ids := memberIDs(caches.MembersByNamePrefix(2, "al")); len(ids) != 0 {
+			t.Errorf("indexes %d: expected no members in guild 2 with prefix al, got %v", indexes, ids)
+		}
+
+		caches.RemoveMembersByGuildID(1)
+		if ids := memberIDs(caches.MembersByUser(1)); !slices.Equal(ids, []snowflake.ID{1}) {
+			t.Errorf("indexes %d: expected member 1 in 1 guild after removal, got %v", indexes, ids)
+		}
+
+		for _, data := range []string{
+			`{"id":"100","guild_id":"1","type":15,"name":"forum","available_tags":[]}`,
+			`{"id":"101","guild_id":"1","type":11,"name":"post","parent_id":"100","thread_metadata":{}}`,
+			`{"id":"102","guild_id":"1","type":11,"name":"other","parent_id":"103","thread_metadata":{}}`,
+		} {
+			var v discord.UnmarshalChannel
+			if err := json.Unmarshal([]byte(data), &v); err != nil {
+				t.Fatal(err)
+			}
+			caches.AddChannel(v.Channel.(discord.GuildChannel))
+		}
+		if threads := caches.GuildThreadsInChannel(100); len(threads) != 1 || threads[0].ID() != 101 {
+			t.Errorf("indexes %d: expected thread 101 in forum 100, got %v", indexes, threads)
+		}
+	}
+}
+
+func TestPrefixIndex(t *testing.T) {
+	t.Parallel()
+
+	members := NewObservableGroupedCache(NewGroupedCache[discord.Member](FlagsAll, FlagMembers, nil), func(member discord.Member) snowflake.ID {
+		return member.User.ID
+	})
+	index := NewPrefixIndex(members, func(member discord.Member) []string {
+		return []string{member.User.Username}
+	})
+
+	names := []string{"alice", "Alfred", "bob", "albert", "al", "carol"}
+	for i := range 1000 {
+		id := snowflake.ID(i % 100)
+		members.Put(1, id, discord.Member{GuildID: 1, User: discord.User{ID: id, Username: names[i%len(names)] + strconv.Itoa(i)}})
+		if i%7 == 0 {
+			members.Remove(1, snowflake.ID(i%13))
+		}
+	}
+
+	for _, prefix := range []string{"", "a", "AL", "alf", "bob1", "zed"} {
+		var expected []snowflake.ID
+		for _, member := range members.All() {
+			if strings.HasPrefix(strings.ToLower(member.User.Username), strings.ToLower(prefix)) {
+				expected = append(expected, member.User.ID)
+			}
+		}
+		slices.Sort(expected)
+
+		var ids []snowflake.ID
+		for _, ref := range index.Lookup(prefix) {
+			ids = append(ids, ref.ID)
+		}
+		slices.Sort(ids)
+		if !slices.Equal(expected, ids) {
+			t.Errorf("expected %v for prefix %q, got %v", expected, prefix, ids)
+		}
+	}
+}
+
+func BenchmarkPrefixIndex_Put(b *testing.B) {
+	const members = 500_000
+
+	caches := New(WithCaches(FlagMembers), WithIndexes(IndexMembersByName))
+	for i := range members {
+		userID := snowflake.ID(i)
+		caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: userID, Username: "user" + strconv.Itoa(i)}})
+	}
+
+	b.ResetTimer()
+	for i := range b.N {
+		userID := snowflake.ID(i % members)
+		nick := "nick" + strconv.Itoa(i)
+		caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: userID, Username: "user" + strconv.Itoa(int(userID))}, Nick: &nick})
+	}
+}
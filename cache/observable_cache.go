@@ -0,0 +1,415 @@
+package cache
+
+import (
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// ChangeType is the type of Change emitted by an Observable cache.
+type ChangeType int
+
+const (
+	// ChangeTypePut is emitted when an entity was put into the cache. Change.Old is nil if the entity was not cached before.
+	ChangeTypePut ChangeType = iota
+	// ChangeTypeRemove is emitted when an entity was removed from the cache.
+	ChangeTypeRemove
+	// ChangeTypeGroupRemove is emitted for every entity of a group which was removed from the cache at once.
+	ChangeTypeGroupRemove
+	// ChangeTypeEvict is emitted when an entity was evicted from the cache according to its Eviction.
+	ChangeTypeEvict
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeTypePut:
+		return "put"
+	case ChangeTypeRemove:
+		return "remove"
+	case ChangeTypeGroupRemove:
+		return "group_remove"
+	case ChangeTypeEvict:
+		return "evict"
+	}
+	return "unknown"
+}
+
+// Change describes a change of a single entity in an Observable cache.
+type Change[T any] struct {
+	Type ChangeType
+	// GroupID is the group of the entity. It is always 0 for a Cache.
+	GroupID snowflake.ID
+	ID      snowflake.ID
+	// Old is the entity before the change or nil if it was not cached.
+	Old *T
+	// New is the entity as stored after the change or nil if it was removed.
+	New *T
+}
+
+// Observer is called for every Change of an Observable cache.
+// Changes made through the cache are delivered in order while holding the write lock of the cache, so an Observer must not modify the cache it observes.
+type Observer[T any] func(change Change[T])
+
+// Observable is implemented by caches which emit a Change to their Observer(s).
+// All Cache(s) & GroupedCache(s) created by New are Observable, so a derived index can be kept in sync like this:
+//
+//	caches.MemberCache().(cache.Observable[discord.Member]).Observe(func(change cache.Change[discord.Member]) { ... })
+type Observable[T any] interface {
+	// Observe registers the Observer and returns a func to remove it again.
+	Observe(observer Observer[T]) func()
+}
+
+type observers[T any] struct {
+	mu   sync.RWMutex
+	list []*Observer[T]
+}
+
+func (o *observers[T]) Observe(observer Observer[T]) func() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	ptr := &observer
+	o.list = append(o.list, ptr)
+	return func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		o.list = slices.DeleteFunc(o.list, func(observer *Observer[T]) bool {
+			return observer == ptr
+		})
+	}
+}
+
+func (o *observers[T]) observed() bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return len(o.list) > 0
+}
+
+func (o *observers[T]) notify(change Change[T]) {
+	o.mu.RLock()
+	observers := o.list
+	o.mu.RUnlock()
+	for _, observer := range observers {
+		(*observer)(change)
+	}
+}
+
+// evict wraps the Eviction.OnEvict of the given Eviction to emit ChangeTypeEvict.
+func (o *observers[T]) evict(eviction Eviction[T]) Eviction[T] {
+	onEvict := eviction.OnEvict
+	eviction.OnEvict = func(groupID snowflake.ID, id snowflake.ID, entity T, reason EvictionReason) {
+		o.notify(Change[T]{
+			Type:    ChangeTypeEvict,
+			GroupID: groupID,
+			ID:      id,
+			Old:     &entity,
+		})
+		if onEvict != nil {
+			onEvict(groupID, id, entity, reason)
+		}
+	}
+	return eviction
+}
+
+// swapper is implemented by caches which put an entity & return the entity cached before in a single operation.
+// An observed Put uses it instead of a Get before & after the Put, which would cost two more round trips on a remote cache like Redis.
+type swapper[T any] interface {
+	// swap puts the entity and returns the entity cached before. stored is false if the entity was not put.
+	swap(id snowflake.ID, entity T) (old T, hadOld bool, stored bool)
+}
+
+// groupedSwapper is the swapper of a GroupedCache.
+type groupedSwapper[T any] interface {
+	swap(groupID snowflake.ID, id snowflake.ID, entity T) (old T, hadOld bool, stored bool)
+}
+
+var (
+	_ swapper[any]        = (*redisCache[any])(nil)
+	_ groupedSwapper[any] = (*redisGroupedCache[any])(nil)
+
+	_ Cache[any]        = (*ObservableCache[any])(nil)
+	_ Observable[any]   = (*ObservableCache[any])(nil)
+	_ GroupedCache[any] = (*ObservableGroupedCache[any])(nil)
+	_ Observable[any]   = (*ObservableGroupedCache[any])(nil)
+)
+
+// NewObservableCache wraps the given Cache to emit a Change for every modification made through it.
+// The idFunc returns the ID of an entity, which is needed for Change(s) emitted by RemoveIf.
+// Without any Observer(s), every modification only checks for Observer(s) under a read lock before it is passed to the wrapped Cache.
+// With Observer(s), modifications are serialized and Put reads the old & the stored entity with a Get before & after the Put,
+// unless the wrapped Cache returns the old entity on Put itself, like the Redis caches do.
+func NewObservableCache[T any](cache Cache[T], idFunc func(entity T) snowflake.ID) *ObservableCache[T] {
+	return &ObservableCache[T]{
+		cache:  cache,
+		idFunc: idFunc,
+	}
+}
+
+// ObservableCache is a Cache which emits a Change to its Observer(s) for every modification.
+type ObservableCache[T any] struct {
+	observers[T]
+	cache  Cache[T]
+	idFunc func(entity T) snowflake.ID
+	mu     sync.Mutex
+}
+
+func (c *ObservableCache[T]) Get(id snowflake.ID) (T, bool) {
+	return c.cache.Get(id)
+}
+
+func (c *ObservableCache[T]) Put(id snowflake.ID, entity T) {
+	if !c.observed() {
+		c.cache.Put(id, entity)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		old    T
+		hadOld bool
+		stored T
+		ok     bool
+	)
+	if s, isSwapper := c.cache.(swapper[T]); isSwapper {
+		old, hadOld, ok = s.swap(id, entity)
+		stored = entity
+	} else {
+		old, hadOld = c.cache.Get(id)
+		c.cache.Put(id, entity)
+		stored, ok = c.cache.Get(id)
+	}
+	if !ok {
+		return
+	}
+	change := Change[T]{
+		Type: ChangeTypePut,
+		ID:   id,
+		New:  &stored,
+	}
+	if hadOld {
+		change.Old = &old
+	}
+	c.notify(change)
+}
+
+func (c *ObservableCache[T]) Remove(id snowflake.ID) (T, bool) {
+	if !c.observed() {
+		return c.cache.Remove(id)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entity, ok := c.cache.Remove(id)
+	if ok {
+		c.notify(Change[T]{
+			Type: ChangeTypeRemove,
+			ID:   id,
+			Old:  &entity,
+		})
+	}
+	return entity, ok
+}
+
+func (c *ObservableCache[T]) RemoveIf(filterFunc FilterFunc[T]) {
+	if !c.observed() {
+		c.cache.RemoveIf(filterFunc)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var removed []T
+	c.cache.RemoveIf(func(entity T) bool {
+		if filterFunc(entity) {
+			removed = append(removed, entity)
+			return true
+		}
+		return false
+	})
+	for _, entity := range removed {
+		c.notify(Change[T]{
+			Type: ChangeTypeRemove,
+			ID:   c.idFunc(entity),
+			Old:  &entity,
+		})
+	}
+}
+
+func (c *ObservableCache[T]) Len() int {
+	return c.cache.Len()
+}
+
+func (c *ObservableCache[T]) All() iter.Seq[T] {
+	return c.cache.All()
+}
+
+// NewObservableGroupedCache wraps the given GroupedCache to emit a Change for every modification made through it.
+// The idFunc returns the ID of an entity, which is needed for Change(s) emitted by GroupRemove, RemoveIf & GroupRemoveIf.
+// The overhead is the same as of an ObservableCache, see NewObservableCache.
+func NewObservableGroupedCache[T any](cache GroupedCache[T], idFunc func(entity T) snowflake.ID) *ObservableGroupedCache[T] {
+	return &ObservableGroupedCache[T]{
+		cache:  cache,
+		idFunc: idFunc,
+	}
+}
+
+// ObservableGroupedCache is a GroupedCache which emits a Change to its Observer(s) for every modification.
+type ObservableGroupedCache[T any] struct {
+	observers[T]
+	cache  GroupedCache[T]
+	idFunc func(entity T) snowflake.ID
+	mu     sync.Mutex
+}
+
+func (c *ObservableGroupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	return c.cache.Get(groupID, id)
+}
+
+func (c *ObservableGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, entity T) {
+	if !c.observed() {
+		c.cache.Put(groupID, id, entity)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		old    T
+		hadOld bool
+		stored T
+		ok     bool
+	)
+	if s, isSwapper := c.cache.(groupedSwapper[T]); isSwapper {
+		old, hadOld, ok = s.swap(groupID, id, entity)
+		stored = entity
+	} else {
+		old, hadOld = c.cache.Get(groupID, id)
+		c.cache.Put(groupID, id, entity)
+		stored, ok = c.cache.Get(groupID, id)
+	}
+	if !ok {
+		return
+	}
+	change := Change[T]{
+		Type:    ChangeTypePut,
+		GroupID: groupID,
+		ID:      id,
+		New:     &stored,
+	}
+	if hadOld {
+		change.Old = &old
+	}
+	c.notify(change)
+}
+
+func (c *ObservableGroupedCache[T]) Remove(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	if !c.observed() {
+		return c.cache.Remove(groupID, id)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entity, ok := c.cache.Remove(groupID, id)
+	if ok {
+		c.notify(Change[T]{
+			Type:    ChangeTypeRemove,
+			GroupID: groupID,
+			ID:      id,
+			Old:     &entity,
+		})
+	}
+	return entity, ok
+}
+
+func (c *ObservableGroupedCache[T]) GroupRemove(groupID snowflake.ID) {
+	if !c.observed() {
+		c.cache.GroupRemove(groupID)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := slices.Collect(c.cache.GroupAll(groupID))
+	c.cache.GroupRemove(groupID)
+	for _, entity := range removed {
+		c.notify(Change[T]{
+			Type:    ChangeTypeGroupRemove,
+			GroupID: groupID,
+			ID:      c.idFunc(entity),
+			Old:     &entity,
+		})
+	}
+}
+
+func (c *ObservableGroupedCache[T]) RemoveIf(filterFunc GroupedFilterFunc[T]) {
+	if !c.observed() {
+		c.cache.RemoveIf(filterFunc)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeIf(func(filterFunc GroupedFilterFunc[T]) {
+		c.cache.RemoveIf(filterFunc)
+	}, filterFunc)
+}
+
+func (c *ObservableGroupedCache[T]) GroupRemoveIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[T]) {
+	if !c.observed() {
+		c.cache.GroupRemoveIf(groupID, filterFunc)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeIf(func(filterFunc GroupedFilterFunc[T]) {
+		c.cache.GroupRemoveIf(groupID, filterFunc)
+	}, filterFunc)
+}
+
+func (c *ObservableGroupedCache[T]) removeIf(removeIf func(filterFunc GroupedFilterFunc[T]), filterFunc GroupedFilterFunc[T]) {
+	type removedEntity struct {
+		groupID snowflake.ID
+		entity  T
+	}
+	var removed []removedEntity
+	removeIf(func(groupID snowflake.ID, entity T) bool {
+		if filterFunc(groupID, entity) {
+			removed = append(removed, removedEntity{groupID: groupID, entity: entity})
+			return true
+		}
+		return false
+	})
+	for _, r := range removed {
+		c.notify(Change[T]{
+			Type:    ChangeTypeRemove,
+			GroupID: r.groupID,
+			ID:      c.idFunc(r.entity),
+			Old:     &r.entity,
+		})
+	}
+}
+
+func (c *ObservableGroupedCache[T]) Len() int {
+	return c.cache.Len()
+}
+
+func (c *ObservableGroupedCache[T]) GroupLen(groupID snowflake.ID) int {
+	return c.cache.GroupLen(groupID)
+}
+
+func (c *ObservableGroupedCache[T]) All() iter.Seq2[snowflake.ID, T] {
+	return c.cache.All()
+}
+
+func (c *ObservableGroupedCache[T]) GroupAll(groupID snowflake.ID) iter.Seq[T] {
+	return c.cache.GroupAll(groupID)
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestObservableGroupedCache(t *testing.T) {
+	t.Parallel()
+
+	caches := New(WithCaches(FlagsAll), WithMemberCacheEviction(Eviction[discord.Member]{MaxEntries: 3}))
+
+	// members by role
+	index := map[snowflake.ID]map[snowflake.ID]struct{}{}
+	var types []ChangeType
+	remove := caches.MemberCache().(Observable[discord.Member]).Observe(func(change Change[discord.Member]) {
+		types = append(types, change.Type)
+		if change.Old != nil {
+			for _, roleID := range change.Old.RoleIDs {
+				delete(index[roleID], change.ID)
+			}
+		}
+		if change.New != nil {
+			for _, roleID := range change.New.RoleIDs {
+				if index[roleID] == nil {
+					index[roleID] = map[snowflake.ID]struct{}{}
+				}
+				index[roleID][change.ID] = struct{}{}
+			}
+		}
+	})
+
+	caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: 1}, RoleIDs: []snowflake.ID{10}})
+	caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: 2}, RoleIDs: []snowflake.ID{10}})
+	caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: 1}, RoleIDs: []snowflake.ID{20}})
+	if len(index[10]) != 1 || len(index[20]) != 1 {
+		t.Errorf("expected one member per role after update, got %v", index)
+	}
+
+	caches.AddMember(discord.Member{GuildID: 2, User: discord.User{ID: 3}, RoleIDs: []snowflake.ID{30}})
+	caches.AddMember(discord.Member{GuildID: 2, User: discord.User{ID: 4}, RoleIDs: []snowflake.ID{30}})
+	if _, ok := index[10][2]; ok {
+		t.Error("expected evicted member to be removed from the index")
+	}
+
+	caches.RemoveMembersByGuildID(2)
+	caches.RemoveMember(1, 1)
+	for roleID, members := range index {
+		if len(members) != 0 {
+			t.Errorf("expected no members with role %d, got %v", roleID, members)
+		}
+	}
+
+	expected := []ChangeType{ChangeTypePut, ChangeTypePut, ChangeTypePut, ChangeTypePut, ChangeTypeEvict, ChangeTypePut, ChangeTypeGroupRemove, ChangeTypeGroupRemove, ChangeTypeRemove}
+	if !slices.Equal(types, expected) {
+		t.Errorf("expected changes %v, got %v", expected, types)
+	}
+
+	remove()
+	caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: 5}})
+	if len(types) != len(expected) {
+		t.Error("expected removed observer to not be called")
+	}
+}
+
+func TestObservableCache_RemoveIf(t *testing.T) {
+	t.Parallel()
+
+	c := NewObservableCache[discord.Guild](NewCache[discord.Guild](FlagsAll, FlagGuilds, nil), func(guild discord.Guild) snowflake.ID {
+		return guild.ID
+	})
+	var removed []snowflake.ID
+	c.Observe(func(change Change[discord.Guild]) {
+		if change.Type == ChangeTypeRemove {
+			removed = append(removed, change.ID)
+		}
+	})
+
+	c.Put(1, discord.Guild{ID: 1, Name: "keep"})
+	c.Put(2, discord.Guild{ID: 2, Name: "remove"})
+	c.RemoveIf(func(guild discord.Guild) bool {
+		return guild.Name == "remove"
+	})
+	if !slices.Equal(removed, []snowflake.ID{2}) {
+		t.Errorf("expected guild 2 to be removed, got %v", removed)
+	}
+}
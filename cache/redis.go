@@ -0,0 +1,246 @@
+package cache
+
+import (
+	"context"
+	"iter"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgo/internal/resp"
+)
+
+// redisScanCount is the number of keys requested per SCAN & MGET.
+const redisScanCount = 1000
+
+// NewRedisClient returns a new RedisClient which connects to the Redis compatible server at the given address with the given RedisConfigOpt(s).
+// Connections are opened on the first command.
+func NewRedisClient(address string, opts ...RedisConfigOpt) *RedisClient {
+	cfg := defaultRedisConfig()
+	cfg.apply(opts)
+
+	var setup [][]string
+	if cfg.Password != "" {
+		if cfg.Username != "" {
+			setup = append(setup, []string{"AUTH", cfg.Username, cfg.Password})
+		} else {
+			setup = append(setup, []string{"AUTH", cfg.Password})
+		}
+	}
+	if cfg.DB != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(cfg.DB)})
+	}
+
+	return &RedisClient{
+		config: cfg,
+		client: resp.NewClient(cfg.Network, address, cfg.PoolSize, setup...),
+	}
+}
+
+// RedisClient is a connection pool to a Redis compatible server which is shared by all redis caches.
+// Use it with NewRedisCache, NewRedisGroupedCache or WithRedis.
+//
+// Entities are stored as strings with the key <namespace>:<cache name>:<id> or <namespace>:<cache name>:<group id>:<id>.
+// Every cache & group additionally indexes its keys in a sorted set scored by their expiry, so counting entities does not scan any keys.
+// Iterating & filtering entities scans all keys of a cache and should be avoided for large caches.
+// Removing entities uses GETDEL, so Redis 6.2 or newer is required.
+type RedisClient struct {
+	config redisConfig
+	client *resp.Client
+}
+
+// Ping checks whether the server is reachable.
+func (c *RedisClient) Ping(ctx context.Context) error {
+	_, err := c.doContext(ctx, "PING")
+	return err
+}
+
+// Close closes all connections to the server.
+func (c *RedisClient) Close() error {
+	return c.client.Close()
+}
+
+func (c *RedisClient) do(args ...string) (any, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+	defer cancel()
+	return c.doContext(ctx, args...)
+}
+
+func (c *RedisClient) doContext(ctx context.Context, args ...string) (any, error) {
+	v, err := c.client.Do(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	if err, ok := v.(resp.Error); ok {
+		return nil, err
+	}
+	return v, nil
+}
+
+// key returns the key of the given parts within the namespace.
+func (c *RedisClient) key(parts ...string) string {
+	return c.config.Namespace + ":" + strings.Join(parts, ":")
+}
+
+// keys returns an [iter.Seq2] of pages of keys which start with the given prefix.
+func (c *RedisClient) keys(prefix string) iter.Seq2[[]string, error] {
+	pattern := escapeGlob(prefix) + "*"
+	return func(yield func([]string, error) bool) {
+		cursor := "0"
+		for {
+			v, err := c.do("SCAN", cursor, "MATCH", pattern, "COUNT", strconv.Itoa(redisScanCount))
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			values, ok := v.([]any)
+			if !ok || len(values) != 2 {
+				yield(nil, resp.ErrProtocol)
+				return
+			}
+			next, _ := values[0].([]byte)
+			items, _ := values[1].([]any)
+
+			keys := make([]string, 0, len(items))
+			for _, item := range items {
+				if key, ok := item.([]byte); ok {
+					keys = append(keys, string(key))
+				}
+			}
+			if len(keys) > 0 && !yield(keys, nil) {
+				return
+			}
+
+			cursor = string(next)
+			if cursor == "0" || cursor == "" {
+				return
+			}
+		}
+	}
+}
+
+// indexedKeys returns an [iter.Seq2] of pages of the keys of all members of the given index which did not expire yet.
+// The keys are the members with the given prefix. Unlike keys this only reads the index instead of scanning the whole keyspace.
+func (c *RedisClient) indexedKeys(index string, prefix string) iter.Seq2[[]string, error] {
+	return func(yield func([]string, error) bool) {
+		v, err := c.do("ZRANGEBYSCORE", index, strconv.FormatInt(time.Now().UnixMilli(), 10), "+inf")
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		members, ok := v.([]any)
+		if !ok {
+			yield(nil, resp.ErrProtocol)
+			return
+		}
+
+		for page := range slices.Chunk(members, redisScanCount) {
+			keys := make([]string, 0, len(page))
+			for _, member := range page {
+				if member, ok := member.([]byte); ok {
+					keys = append(keys, prefix+string(member))
+				}
+			}
+			if len(keys) > 0 && !yield(keys, nil) {
+				return
+			}
+		}
+	}
+}
+
+// pipeline sends all given commands at once & returns their replies in order. The first error reply is returned as error.
+func (c *RedisClient) pipeline(cmds ...[]string) ([]any, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+	defer cancel()
+	values, err := c.client.Pipeline(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range values {
+		if err, ok := v.(resp.Error); ok {
+			return values, err
+		}
+	}
+	return values, nil
+}
+
+// count returns the number of members of the given index which did not expire yet.
+func (c *RedisClient) count(index string) int {
+	values, err := c.pipeline(
+		[]string{"ZREMRANGEBYSCORE", index, "-inf", strconv.FormatInt(time.Now().UnixMilli(), 10)},
+		[]string{"ZCARD", index},
+	)
+	if err != nil {
+		c.config.Logger.Error("failed to count keys", slog.Any("err", err), slog.String("index", index))
+		return 0
+	}
+	n, _ := values[1].(int64)
+	return int(n)
+}
+
+// remove deletes the given keys & sends the given commands to update the indexes.
+func (c *RedisClient) remove(keys []string, indexCmds ...[]string) {
+	if len(keys) == 0 {
+		return
+	}
+	if _, err := c.pipeline(append([][]string{append([]string{"DEL"}, keys...)}, indexCmds...)...); err != nil {
+		c.config.Logger.Error("failed to delete keys", slog.Any("err", err), slog.Int("keys", len(keys)))
+	}
+}
+
+// redisIndexScore returns the score of a member of an index, which is the unix time in milliseconds at which its key expires.
+func redisIndexScore(ttl time.Duration) string {
+	if ttl <= 0 {
+		return "+inf"
+	}
+	return strconv.FormatInt(time.Now().Add(ttl).UnixMilli(), 10)
+}
+
+// redisEntities returns an [iter.Seq2] of the given pages of keys and their decoded entities. Keys which expired or were removed in the meantime are skipped.
+func redisEntities[T any](c *RedisClient, codec Codec, pages iter.Seq2[[]string, error]) iter.Seq2[string, T] {
+	return func(yield func(string, T) bool) {
+		for keys, err := range pages {
+			if err != nil {
+				c.config.Logger.Error("failed to list keys", slog.Any("err", err))
+				return
+			}
+
+			v, err := c.do(append([]string{"MGET"}, keys...)...)
+			if err != nil {
+				c.config.Logger.Error("failed to get entities", slog.Any("err", err), slog.Int("keys", len(keys)))
+				return
+			}
+			values, _ := v.([]any)
+			for i, value := range values {
+				data, ok := value.([]byte)
+				if !ok || i >= len(keys) {
+					// expired or removed since the keys were listed
+					continue
+				}
+				entity, err := decodeEntity[T](codec, data)
+				if err != nil {
+					c.config.Logger.Error("failed to decode entity", slog.Any("err", err), slog.String("key", keys[i]))
+					continue
+				}
+				if !yield(keys[i], entity) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// escapeGlob escapes all characters with a special meaning in SCAN MATCH patterns.
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,340 @@
+package cache
+
+import (
+	"iter"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+var _ Cache[any] = (*redisCache[any])(nil)
+
+// NewRedisCache returns a new Cache which stores its entities in a Redis compatible server, so they can be shared by multiple processes & survive restarts.
+// The name must be unique per namespace & is used as part of the keys. Entities are filtered after the given Flags and Policy.
+// Errors of the server are logged, as the Cache interface does not return them.
+func NewRedisCache[T any](client *RedisClient, name string, flags Flags, neededFlags Flags, policy Policy[T], opts ...RedisCacheConfigOpt) Cache[T] {
+	cfg := defaultRedisCacheConfig(client)
+	cfg.apply(opts)
+
+	return &redisCache[T]{
+		client:      client,
+		config:      cfg,
+		prefix:      client.key(name) + ":",
+		index:       client.key(name) + "#",
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+	}
+}
+
+type redisCache[T any] struct {
+	client *RedisClient
+	config redisCacheConfig
+	prefix string
+	// index is the sorted set of the IDs of all entities, it doesn't start with the prefix, so it is never scanned
+	index       string
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[T]
+}
+
+func (c *redisCache[T]) key(id snowflake.ID) string {
+	return c.prefix + id.String()
+}
+
+func (c *redisCache[T]) Get(id snowflake.ID) (T, bool) {
+	return redisGet[T](c.client, c.config, c.key(id), "GET")
+}
+
+func (c *redisCache[T]) Put(id snowflake.ID, entity T) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	if c.policy != nil && !c.policy(entity) {
+		return
+	}
+	score := redisIndexScore(c.config.TTL)
+	redisPut(c.client, c.config, c.key(id), entity, []string{"ZADD", c.index, score, id.String()})
+}
+
+func (c *redisCache[T]) swap(id snowflake.ID, entity T) (T, bool, bool) {
+	if c.flags.Missing(c.neededFlags) || (c.policy != nil && !c.policy(entity)) {
+		var old T
+		return old, false, false
+	}
+	score := redisIndexScore(c.config.TTL)
+	return redisSwap(c.client, c.config, c.key(id), entity, []string{"ZADD", c.index, score, id.String()})
+}
+
+func (c *redisCache[T]) Remove(id snowflake.ID) (T, bool) {
+	return redisGet[T](c.client, c.config, c.key(id), "GETDEL", []string{"ZREM", c.index, id.String()})
+}
+
+func (c *redisCache[T]) RemoveIf(filterFunc FilterFunc[T]) {
+	var (
+		keys []string
+		ids  []string
+	)
+	for key, entity := range redisEntities[T](c.client, c.config.Codec, c.client.keys(c.prefix)) {
+		if filterFunc(entity) {
+			keys = append(keys, key)
+			ids = append(ids, strings.TrimPrefix(key, c.prefix))
+		}
+	}
+	c.client.remove(keys, append([]string{"ZREM", c.index}, ids...))
+}
+
+func (c *redisCache[T]) Len() int {
+	return c.client.count(c.index)
+}
+
+func (c *redisCache[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, entity := range redisEntities[T](c.client, c.config.Codec, c.client.keys(c.prefix)) {
+			if !yield(entity) {
+				return
+			}
+		}
+	}
+}
+
+var _ GroupedCache[any] = (*redisGroupedCache[any])(nil)
+
+// NewRedisGroupedCache returns a new GroupedCache which stores its entities in a Redis compatible server, so they can be shared by multiple processes & survive restarts.
+// The name must be unique per namespace & is used as part of the keys. Entities are filtered after the given Flags and Policy.
+// Errors of the server are logged, as the GroupedCache interface does not return them.
+func NewRedisGroupedCache[T any](client *RedisClient, name string, flags Flags, neededFlags Flags, policy Policy[T], opts ...RedisCacheConfigOpt) GroupedCache[T] {
+	cfg := defaultRedisCacheConfig(client)
+	cfg.apply(opts)
+
+	return &redisGroupedCache[T]{
+		client:      client,
+		config:      cfg,
+		prefix:      client.key(name) + ":",
+		index:       client.key(name) + "#",
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+	}
+}
+
+type redisGroupedCache[T any] struct {
+	client *RedisClient
+	config redisCacheConfig
+	prefix string
+	// index is the sorted set of the <group id>:<id> of all entities, every group has its own index of IDs with the group ID appended
+	index       string
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[T]
+}
+
+func (c *redisGroupedCache[T]) groupPrefix(groupID snowflake.ID) string {
+	return c.prefix + groupID.String() + ":"
+}
+
+func (c *redisGroupedCache[T]) key(groupID snowflake.ID, id snowflake.ID) string {
+	return c.groupPrefix(groupID) + id.String()
+}
+
+func (c *redisGroupedCache[T]) groupIndex(groupID snowflake.ID) string {
+	return c.index + groupID.String()
+}
+
+// groupKeys returns an [iter.Seq2] of pages of the keys of the group, read from the index of the group.
+func (c *redisGroupedCache[T]) groupKeys(groupID snowflake.ID) iter.Seq2[[]string, error] {
+	return c.client.indexedKeys(c.groupIndex(groupID), c.groupPrefix(groupID))
+}
+
+// groupID parses the group ID of the given key.
+func (c *redisGroupedCache[T]) groupID(key string) snowflake.ID {
+	groupID, _, _ := strings.Cut(strings.TrimPrefix(key, c.prefix), ":")
+	id, _ := strconv.ParseUint(groupID, 10, 64)
+	return snowflake.ID(id)
+}
+
+// removeKeys deletes the given keys & removes them from the indexes.
+func (c *redisGroupedCache[T]) removeKeys(keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	members := make([]string, len(keys))
+	groups := map[string][]string{}
+	for i, key := range keys {
+		members[i] = strings.TrimPrefix(key, c.prefix)
+		groupID, id, _ := strings.Cut(members[i], ":")
+		groups[groupID] = append(groups[groupID], id)
+	}
+
+	cmds := [][]string{append([]string{"ZREM", c.index}, members...)}
+	for groupID, ids := range groups {
+		cmds = append(cmds, append([]string{"ZREM", c.index + groupID}, ids...))
+	}
+	c.client.remove(keys, cmds...)
+}
+
+func (c *redisGroupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	return redisGet[T](c.client, c.config, c.key(groupID, id), "GET")
+}
+
+func (c *redisGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, entity T) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	if c.policy != nil && !c.policy(entity) {
+		return
+	}
+	score := redisIndexScore(c.config.TTL)
+	redisPut(c.client, c.config, c.key(groupID, id), entity,
+		[]string{"ZADD", c.index, score, groupID.String() + ":" + id.String()},
+		[]string{"ZADD", c.groupIndex(groupID), score, id.String()},
+	)
+}
+
+func (c *redisGroupedCache[T]) swap(groupID snowflake.ID, id snowflake.ID, entity T) (T, bool, bool) {
+	if c.flags.Missing(c.neededFlags) || (c.policy != nil && !c.policy(entity)) {
+		var old T
+		return old, false, false
+	}
+	score := redisIndexScore(c.config.TTL)
+	return redisSwap(c.client, c.config, c.key(groupID, id), entity,
+		[]string{"ZADD", c.index, score, groupID.String() + ":" + id.String()},
+		[]string{"ZADD", c.groupIndex(groupID), score, id.String()},
+	)
+}
+
+func (c *redisGroupedCache[T]) Remove(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	return redisGet[T](c.client, c.config, c.key(groupID, id), "GETDEL",
+		[]string{"ZREM", c.index, groupID.String() + ":" + id.String()},
+		[]string{"ZREM", c.groupIndex(groupID), id.String()},
+	)
+}
+
+func (c *redisGroupedCache[T]) GroupRemove(groupID snowflake.ID) {
+	for keys, err := range c.groupKeys(groupID) {
+		if err != nil {
+			c.client.config.Logger.Error("failed to list keys", slog.Any("err", err), slog.String("group_id", groupID.String()))
+			return
+		}
+		c.removeKeys(keys)
+	}
+}
+
+func (c *redisGroupedCache[T]) RemoveIf(filterFunc GroupedFilterFunc[T]) {
+	var keys []string
+	for key, entity := range redisEntities[T](c.client, c.config.Codec, c.client.keys(c.prefix)) {
+		if filterFunc(c.groupID(key), entity) {
+			keys = append(keys, key)
+		}
+	}
+	c.removeKeys(keys)
+}
+
+func (c *redisGroupedCache[T]) GroupRemoveIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[T]) {
+	var keys []string
+	for key, entity := range redisEntities[T](c.client, c.config.Codec, c.groupKeys(groupID)) {
+		if filterFunc(groupID, entity) {
+			keys = append(keys, key)
+		}
+	}
+	c.removeKeys(keys)
+}
+
+func (c *redisGroupedCache[T]) Len() int {
+	return c.client.count(c.index)
+}
+
+func (c *redisGroupedCache[T]) GroupLen(groupID snowflake.ID) int {
+	return c.client.count(c.groupIndex(groupID))
+}
+
+func (c *redisGroupedCache[T]) All() iter.Seq2[snowflake.ID, T] {
+	return func(yield func(snowflake.ID, T) bool) {
+		for key, entity := range redisEntities[T](c.client, c.config.Codec, c.client.keys(c.prefix)) {
+			if !yield(c.groupID(key), entity) {
+				return
+			}
+		}
+	}
+}
+
+func (c *redisGroupedCache[T]) GroupAll(groupID snowflake.ID) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, entity := range redisEntities[T](c.client, c.config.Codec, c.groupKeys(groupID)) {
+			if !yield(entity) {
+				return
+			}
+		}
+	}
+}
+
+// redisGet sends the given command (GET or GETDEL) for the key together with the given index commands & decodes the returned entity.
+func redisGet[T any](client *RedisClient, config redisCacheConfig, key string, cmd string, indexCmds ...[]string) (T, bool) {
+	var entity T
+	values, err := client.pipeline(append([][]string{{cmd, key}}, indexCmds...)...)
+	if err != nil {
+		client.config.Logger.Error("failed to get entity", slog.Any("err", err), slog.String("key", key))
+		return entity, false
+	}
+	data, ok := values[0].([]byte)
+	if !ok {
+		return entity, false
+	}
+
+	if entity, err = decodeEntity[T](config.Codec, data); err != nil {
+		client.config.Logger.Error("failed to decode entity", slog.Any("err", err), slog.String("key", key))
+		return entity, false
+	}
+	return entity, true
+}
+
+// redisPut encodes the entity & stores it with the TTL of the cache together with the given index commands.
+func redisPut(client *RedisClient, config redisCacheConfig, key string, entity any, indexCmds ...[]string) {
+	args, ok := redisSetArgs(client, config, key, entity)
+	if !ok {
+		return
+	}
+	if _, err := client.pipeline(append([][]string{args}, indexCmds...)...); err != nil {
+		client.config.Logger.Error("failed to put entity", slog.Any("err", err), slog.String("key", key))
+	}
+}
+
+// redisSwap works like redisPut, but also returns the entity which was stored at the key before.
+func redisSwap[T any](client *RedisClient, config redisCacheConfig, key string, entity T, indexCmds ...[]string) (T, bool, bool) {
+	var old T
+	args, ok := redisSetArgs(client, config, key, entity)
+	if !ok {
+		return old, false, false
+	}
+	values, err := client.pipeline(append([][]string{append(args, "GET")}, indexCmds...)...)
+	if err != nil {
+		client.config.Logger.Error("failed to put entity", slog.Any("err", err), slog.String("key", key))
+		return old, false, false
+	}
+	data, ok := values[0].([]byte)
+	if !ok {
+		return old, false, true
+	}
+	if old, err = decodeEntity[T](config.Codec, data); err != nil {
+		client.config.Logger.Error("failed to decode entity", slog.Any("err", err), slog.String("key", key))
+		return old, false, true
+	}
+	return old, true, true
+}
+
+// redisSetArgs encodes the entity & returns the SET command to store it with the TTL of the cache.
+func redisSetArgs(client *RedisClient, config redisCacheConfig, key string, entity any) ([]string, bool) {
+	data, err := config.Codec.Marshal(entity)
+	if err != nil {
+		client.config.Logger.Error("failed to encode entity", slog.Any("err", err), slog.String("key", key))
+		return nil, false
+	}
+
+	args := []string{"SET", key, string(data)}
+	if config.TTL > 0 {
+		args = append(args, "PX", strconv.FormatInt(max(config.TTL.Milliseconds(), 1), 10))
+	}
+	return args, true
+}
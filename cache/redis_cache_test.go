@@ -0,0 +1,196 @@
+package cache
+
+import (
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/internal/resp"
+)
+
+func newTestRedisServer(t *testing.T) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := resp.NewServer()
+	go func() {
+		_ = server.Serve(ln)
+	}()
+	t.Cleanup(func() {
+		_ = server.Close()
+	})
+	return ln.Addr().String()
+}
+
+func newTestRedisClient(t *testing.T, address string, opts ...RedisConfigOpt) *RedisClient {
+	client := NewRedisClient(address, opts...)
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	return client
+}
+
+func TestRedisCache(t *testing.T) {
+	t.Parallel()
+
+	for _, codec := range []Codec{CodecJSON, CodecETF} {
+		client := newTestRedisClient(t, newTestRedisServer(t), WithRedisCodec(codec))
+		c := NewRedisCache[discord.Role](client, "roles", FlagsAll, FlagRoles, func(role discord.Role) bool {
+			return role.Name != "ignored"
+		})
+
+		c.Put(1, discord.Role{ID: 1, Name: "admin", Permissions: discord.PermissionAdministrator})
+		c.Put(2, discord.Role{ID: 2, Name: "member"})
+		c.Put(3, discord.Role{ID: 3, Name: "ignored"})
+
+		role, ok := c.Get(1)
+		if !ok || role.Name != "admin" || role.Permissions != discord.PermissionAdministrator {
+			t.Errorf("expected role admin, got %+v (%t)", role, ok)
+		}
+		if _, ok = c.Get(3); ok {
+			t.Error("expected role filtered by the policy to not be cached")
+		}
+		if l := c.Len(); l != 2 {
+			t.Errorf("expected 2 roles, got %d", l)
+		}
+
+		var names []string
+		for role = range c.All() {
+			names = append(names, role.Name)
+		}
+		slices.Sort(names)
+		if !slices.Equal(names, []string{"admin", "member"}) {
+			t.Errorf("expected roles [admin member], got %v", names)
+		}
+
+		c.RemoveIf(func(role discord.Role) bool {
+			return role.Name == "member"
+		})
+		if role, ok = c.Remove(1); !ok || role.Name != "admin" {
+			t.Errorf("expected removed role admin, got %+v (%t)", role, ok)
+		}
+		if l := c.Len(); l != 0 {
+			t.Errorf("expected no roles after removing, got %d", l)
+		}
+	}
+}
+
+func TestRedisGroupedCache(t *testing.T) {
+	t.Parallel()
+
+	address := newTestRedisServer(t)
+	c := NewRedisGroupedCache[discord.Member](newTestRedisClient(t, address, WithRedisNamespace("test")), "members", FlagsAll, FlagMembers, nil)
+	other := NewRedisGroupedCache[discord.Member](newTestRedisClient(t, address, WithRedisNamespace("other")), "members", FlagsAll, FlagMembers, nil)
+
+	for groupID := range snowflake.ID(3) {
+		for id := range snowflake.ID(2) {
+			c.Put(groupID+1, id+1, discord.Member{GuildID: groupID + 1, User: discord.User{ID: id + 1}})
+		}
+	}
+	other.Put(1, 1, discord.Member{})
+
+	if l := c.Len(); l != 6 {
+		t.Errorf("expected 6 members, got %d", l)
+	}
+	if l := c.GroupLen(2); l != 2 {
+		t.Errorf("expected 2 members in group 2, got %d", l)
+	}
+	for groupID, member := range c.All() {
+		if member.GuildID != groupID {
+			t.Errorf("expected member of group %d, got group %d", member.GuildID, groupID)
+		}
+	}
+
+	c.GroupRemove(2)
+	c.GroupRemoveIf(3, func(_ snowflake.ID, member discord.Member) bool {
+		return member.User.ID == 1
+	})
+	if l := c.Len(); l != 3 {
+		t.Errorf("expected 3 members after removing, got %d", l)
+	}
+	if l, l3 := c.GroupLen(2), c.GroupLen(3); l != 0 || l3 != 1 {
+		t.Errorf("expected 0 members in group 2 & 1 in group 3 after removing, got %d & %d", l, l3)
+	}
+	members := slices.Collect(c.GroupAll(3))
+	if len(members) != 1 || members[0].User.ID != 2 {
+		t.Errorf("expected member 2 in group 3, got %v", members)
+	}
+	if l := other.Len(); l != 1 {
+		t.Errorf("expected other namespace to be untouched, got %d members", l)
+	}
+}
+
+func TestRedisCache_TTL(t *testing.T) {
+	t.Parallel()
+
+	client := newTestRedisClient(t, newTestRedisServer(t), WithRedisTTL(time.Hour))
+	c := NewRedisCache[discord.Guild](client, "guilds", FlagsAll, FlagGuilds, nil, WithRedisCacheTTL(50*time.Millisecond))
+
+	c.Put(1, discord.Guild{ID: 1})
+	if _, ok := c.Get(1); !ok {
+		t.Fatal("expected guild to be cached")
+	}
+	if l := c.Len(); l != 1 {
+		t.Errorf("expected 1 guild, got %d", l)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, ok := c.Get(1); ok {
+		t.Error("expected guild to be expired")
+	}
+	if l := c.Len(); l != 0 {
+		t.Errorf("expected expired guild to not be counted, got %d", l)
+	}
+}
+
+func TestRedisCache_Channel(t *testing.T) {
+	t.Parallel()
+
+	var v discord.UnmarshalChannel
+	if err := json.Unmarshal([]byte(`{"id":"1","guild_id":"2","type":0,"name":"general"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	client := newTestRedisClient(t, newTestRedisServer(t), WithRedisCodec(CodecETF))
+	c := NewRedisCache[discord.GuildChannel](client, "channels", FlagsAll, FlagChannels, nil)
+	c.Put(1, v.Channel.(discord.GuildChannel))
+
+	channel, ok := c.Get(1)
+	if !ok {
+		t.Fatal("expected channel to be cached")
+	}
+	textChannel, ok := channel.(discord.GuildTextChannel)
+	if !ok || textChannel.Name() != "general" || textChannel.GuildID() != 2 {
+		t.Errorf("expected text channel general in guild 2, got %#v", channel)
+	}
+}
+
+func TestRedisCache_Observed(t *testing.T) {
+	t.Parallel()
+
+	c := NewObservableCache(NewRedisCache[discord.Guild](newTestRedisClient(t, newTestRedisServer(t)), "guilds", FlagsAll, FlagGuilds, nil), func(guild discord.Guild) snowflake.ID {
+		return guild.ID
+	})
+	var changes []Change[discord.Guild]
+	c.Observe(func(change Change[discord.Guild]) {
+		changes = append(changes, change)
+	})
+
+	c.Put(1, discord.Guild{ID: 1, Name: "old"})
+	c.Put(1, discord.Guild{ID: 1, Name: "new"})
+
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d", len(changes))
+	}
+	if changes[0].Old != nil || changes[0].New.Name != "old" {
+		t.Errorf("expected first put without old guild, got %+v", changes[0])
+	}
+	if changes[1].Old == nil || changes[1].Old.Name != "old" || changes[1].New.Name != "new" {
+		t.Errorf("expected second put to replace old guild, got %+v", changes[1])
+	}
+}
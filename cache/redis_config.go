@@ -0,0 +1,151 @@
+package cache
+
+import (
+	"log/slog"
+	"time"
+)
+
+func defaultRedisConfig() redisConfig {
+	return redisConfig{
+		Logger:    slog.Default(),
+		Network:   "tcp",
+		Namespace: "disgo",
+		PoolSize:  10,
+		Timeout:   5 * time.Second,
+		Codec:     CodecJSON,
+	}
+}
+
+type redisConfig struct {
+	// Logger is the logger of the RedisClient. Defaults to slog.Default().
+	Logger *slog.Logger
+	// Network is the network of the server address. Defaults to "tcp".
+	Network string
+	// Username is the username used to authenticate. Leave this empty to authenticate with only the Password.
+	Username string
+	// Password is the password used to authenticate. Leave this empty to not authenticate.
+	Password string
+	// DB is the database to select. Defaults to 0.
+	DB int
+	// Namespace is prepended to all keys, so multiple bots can share the same database. Defaults to "disgo".
+	Namespace string
+	// PoolSize is the number of idle connections kept open. Defaults to 10.
+	PoolSize int
+	// Timeout is the timeout of a single command. Defaults to 5 seconds.
+	Timeout time.Duration
+	// Codec is the default Codec of all caches. Defaults to CodecJSON.
+	Codec Codec
+	// TTL is the default duration after which entities expire. Defaults to 0 (no expiry).
+	TTL time.Duration
+}
+
+// RedisConfigOpt is a type alias for a function that takes a redisConfig and is used to configure your RedisClient.
+type RedisConfigOpt func(config *redisConfig)
+
+func (c *redisConfig) apply(opts []RedisConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Logger = c.Logger.With(slog.String("name", "cache_redis"))
+}
+
+// WithRedisLogger sets the logger of the RedisClient.
+func WithRedisLogger(logger *slog.Logger) RedisConfigOpt {
+	return func(config *redisConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithRedisNetwork sets the network of the server address, like "tcp" or "unix".
+func WithRedisNetwork(network string) RedisConfigOpt {
+	return func(config *redisConfig) {
+		config.Network = network
+	}
+}
+
+// WithRedisAuth sets the username & password used to authenticate. Leave the username empty to authenticate with only the password.
+func WithRedisAuth(username string, password string) RedisConfigOpt {
+	return func(config *redisConfig) {
+		config.Username = username
+		config.Password = password
+	}
+}
+
+// WithRedisDB sets the database to select.
+func WithRedisDB(db int) RedisConfigOpt {
+	return func(config *redisConfig) {
+		config.DB = db
+	}
+}
+
+// WithRedisNamespace sets the namespace which is prepended to all keys.
+func WithRedisNamespace(namespace string) RedisConfigOpt {
+	return func(config *redisConfig) {
+		config.Namespace = namespace
+	}
+}
+
+// WithRedisPoolSize sets the number of idle connections kept open.
+func WithRedisPoolSize(poolSize int) RedisConfigOpt {
+	return func(config *redisConfig) {
+		config.PoolSize = poolSize
+	}
+}
+
+// WithRedisTimeout sets the timeout of a single command.
+func WithRedisTimeout(timeout time.Duration) RedisConfigOpt {
+	return func(config *redisConfig) {
+		config.Timeout = timeout
+	}
+}
+
+// WithRedisCodec sets the default Codec of all caches.
+func WithRedisCodec(codec Codec) RedisConfigOpt {
+	return func(config *redisConfig) {
+		config.Codec = codec
+	}
+}
+
+// WithRedisTTL sets the default duration after which entities expire.
+func WithRedisTTL(ttl time.Duration) RedisConfigOpt {
+	return func(config *redisConfig) {
+		config.TTL = ttl
+	}
+}
+
+func defaultRedisCacheConfig(client *RedisClient) redisCacheConfig {
+	return redisCacheConfig{
+		Codec: client.config.Codec,
+		TTL:   client.config.TTL,
+	}
+}
+
+type redisCacheConfig struct {
+	// Codec is the Codec of the cache. Defaults to the Codec of the RedisClient.
+	Codec Codec
+	// TTL is the duration after which entities expire. Defaults to the TTL of the RedisClient.
+	TTL time.Duration
+}
+
+// RedisCacheConfigOpt is a type alias for a function that takes a redisCacheConfig and is used to configure a single redis cache.
+type RedisCacheConfigOpt func(config *redisCacheConfig)
+
+func (c *redisCacheConfig) apply(opts []RedisCacheConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithRedisCacheCodec sets the Codec of a single redis cache.
+func WithRedisCacheCodec(codec Codec) RedisCacheConfigOpt {
+	return func(config *redisCacheConfig) {
+		config.Codec = codec
+	}
+}
+
+// WithRedisCacheTTL sets the duration after which entities of a single redis cache expire.
+func WithRedisCacheTTL(ttl time.Duration) RedisCacheConfigOpt {
+	return func(config *redisCacheConfig) {
+		config.TTL = ttl
+	}
+}
@@ -0,0 +1,283 @@
+package cache
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// SnapshotVersion is the version of the snapshot format written by WriteSnapshot.
+const SnapshotVersion = 1
+
+// ErrUnsupportedSnapshotVersion is returned by ReadSnapshot when the snapshot was written with a different SnapshotVersion.
+var ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot version")
+
+type snapshotRecordType string
+
+const (
+	snapshotRecordTypeSelfUser             snapshotRecordType = "self_user"
+	snapshotRecordTypeGuild                snapshotRecordType = "guild"
+	snapshotRecordTypeUnreadyGuild         snapshotRecordType = "unready_guild"
+	snapshotRecordTypeUnavailableGuild     snapshotRecordType = "unavailable_guild"
+	snapshotRecordTypeChannel              snapshotRecordType = "channel"
+	snapshotRecordTypeStageInstance        snapshotRecordType = "stage_instance"
+	snapshotRecordTypeGuildScheduledEvent  snapshotRecordType = "guild_scheduled_event"
+	snapshotRecordTypeGuildSoundboardSound snapshotRecordType = "guild_soundboard_sound"
+	snapshotRecordTypeRole                 snapshotRecordType = "role"
+	snapshotRecordTypeMember               snapshotRecordType = "member"
+	snapshotRecordTypeThreadMember         snapshotRecordType = "thread_member"
+	snapshotRecordTypePresence             snapshotRecordType = "presence"
+	snapshotRecordTypeVoiceState           snapshotRecordType = "voice_state"
+	snapshotRecordTypeEmoji                snapshotRecordType = "emoji"
+	snapshotRecordTypeSticker              snapshotRecordType = "sticker"
+)
+
+type snapshotHeader struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type snapshotRecord struct {
+	Type snapshotRecordType `json:"t"`
+	Data json.RawMessage    `json:"d"`
+}
+
+// SaveSnapshot writes a snapshot of the given Caches to the file at the given path.
+// The snapshot is written to a temporary file first, so an existing snapshot is never left partially written.
+func SaveSnapshot(path string, caches Caches) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if err = WriteSnapshot(f, caches); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return err
+	}
+	if err = f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// LoadSnapshot reads the snapshot from the file at the given path into the given Caches.
+// The restored entities are as old as the snapshot, until they are replaced by received gateway events.
+// If the file does not exist, an error wrapping fs.ErrNotExist is returned.
+func LoadSnapshot(path string, caches Caches) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return ReadSnapshot(f, caches)
+}
+
+// WriteSnapshot writes a gzip compressed snapshot of the given Caches to the writer.
+// It contains the self user, guilds, unready & unavailable guilds, channels, stage instances, guild scheduled events, guild soundboard sounds,
+// roles, members, thread members, presences, voice states, emojis & stickers. Messages are not part of the snapshot.
+func WriteSnapshot(w io.Writer, caches Caches) error {
+	gw := gzip.NewWriter(w)
+	encode := json.NewEncoder(gw).Encode
+
+	if err := encode(snapshotHeader{
+		Version:   SnapshotVersion,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	if selfUser, ok := caches.SelfUser(); ok {
+		if err := writeSnapshotRecord(encode, snapshotRecordTypeSelfUser, selfUser); err != nil {
+			return err
+		}
+	}
+	if err := writeSnapshotRecords(encode, snapshotRecordTypeGuild, caches.Guilds()); err != nil {
+		return err
+	}
+	for _, guildID := range caches.UnreadyGuildIDs() {
+		if err := writeSnapshotRecord(encode, snapshotRecordTypeUnreadyGuild, guildID); err != nil {
+			return err
+		}
+	}
+	for _, guildID := range caches.UnavailableGuildIDs() {
+		if err := writeSnapshotRecord(encode, snapshotRecordTypeUnavailableGuild, guildID); err != nil {
+			return err
+		}
+	}
+	if err := writeSnapshotRecords(encode, snapshotRecordTypeChannel, caches.Channels()); err != nil {
+		return err
+	}
+	if err := writeSnapshotGroupedRecords(encode, snapshotRecordTypeStageInstance, caches.StageInstanceCache().All()); err != nil {
+		return err
+	}
+	if err := writeSnapshotGroupedRecords(encode, snapshotRecordTypeGuildScheduledEvent, caches.GuildScheduledEventCache().All()); err != nil {
+		return err
+	}
+	if err := writeSnapshotGroupedRecords(encode, snapshotRecordTypeGuildSoundboardSound, caches.GuildSoundboardSoundCache().All()); err != nil {
+		return err
+	}
+	if err := writeSnapshotGroupedRecords(encode, snapshotRecordTypeRole, caches.RoleCache().All()); err != nil {
+		return err
+	}
+	if err := writeSnapshotGroupedRecords(encode, snapshotRecordTypeMember, caches.MemberCache().All()); err != nil {
+		return err
+	}
+	if err := writeSnapshotGroupedRecords(encode, snapshotRecordTypeThreadMember, caches.ThreadMemberCache().All()); err != nil {
+		return err
+	}
+	if err := writeSnapshotGroupedRecords(encode, snapshotRecordTypePresence, caches.PresenceCache().All()); err != nil {
+		return err
+	}
+	if err := writeSnapshotGroupedRecords(encode, snapshotRecordTypeVoiceState, caches.VoiceStateCache().All()); err != nil {
+		return err
+	}
+	if err := writeSnapshotGroupedRecords(encode, snapshotRecordTypeEmoji, caches.EmojiCache().All()); err != nil {
+		return err
+	}
+	if err := writeSnapshotGroupedRecords(encode, snapshotRecordTypeSticker, caches.StickerCache().All()); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func writeSnapshotRecord(encode func(v any) error, recordType snapshotRecordType, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", recordType, err)
+	}
+	return encode(snapshotRecord{
+		Type: recordType,
+		Data: data,
+	})
+}
+
+func writeSnapshotRecords[T any](encode func(v any) error, recordType snapshotRecordType, entities iter.Seq[T]) error {
+	for entity := range entities {
+		if err := writeSnapshotRecord(encode, recordType, entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeSnapshotGroupedRecords[T any](encode func(v any) error, recordType snapshotRecordType, entities iter.Seq2[snowflake.ID, T]) error {
+	for _, entity := range entities {
+		if err := writeSnapshotRecord(encode, recordType, entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadSnapshot reads a snapshot written by WriteSnapshot into the given Caches. Entities are added like any other entity, so the Flags and Policy(s) of the Caches apply.
+// It returns ErrUnsupportedSnapshotVersion if the snapshot was written with a different SnapshotVersion.
+func ReadSnapshot(r io.Reader, caches Caches) error {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	defer gr.Close()
+	dec := json.NewDecoder(gr)
+
+	var header snapshotHeader
+	if err = dec.Decode(&header); err != nil {
+		return fmt.Errorf("failed to decode snapshot header: %w", err)
+	}
+	if header.Version != SnapshotVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedSnapshotVersion, header.Version)
+	}
+
+	for {
+		var record snapshotRecord
+		if err = dec.Decode(&record); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to decode snapshot record: %w", err)
+		}
+		if err = readSnapshotRecord(record, caches); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", record.Type, err)
+		}
+	}
+}
+
+func readSnapshotRecord(record snapshotRecord, caches Caches) error {
+	switch record.Type {
+	case snapshotRecordTypeSelfUser:
+		return readSnapshotEntity(record, caches.SetSelfUser)
+
+	case snapshotRecordTypeGuild:
+		return readSnapshotEntity(record, caches.AddGuild)
+
+	case snapshotRecordTypeUnreadyGuild:
+		return readSnapshotEntity(record, func(guildID snowflake.ID) {
+			caches.SetGuildUnready(guildID, true)
+		})
+
+	case snapshotRecordTypeUnavailableGuild:
+		return readSnapshotEntity(record, func(guildID snowflake.ID) {
+			caches.SetGuildUnavailable(guildID, true)
+		})
+
+	case snapshotRecordTypeChannel:
+		var v discord.UnmarshalChannel
+		if err := json.Unmarshal(record.Data, &v); err != nil {
+			return err
+		}
+		if channel, ok := v.Channel.(discord.GuildChannel); ok {
+			caches.AddChannel(channel)
+		}
+		return nil
+
+	case snapshotRecordTypeStageInstance:
+		return readSnapshotEntity(record, caches.AddStageInstance)
+
+	case snapshotRecordTypeGuildScheduledEvent:
+		return readSnapshotEntity(record, caches.AddGuildScheduledEvent)
+
+	case snapshotRecordTypeGuildSoundboardSound:
+		return readSnapshotEntity(record, caches.AddGuildSoundboardSound)
+
+	case snapshotRecordTypeRole:
+		return readSnapshotEntity(record, caches.AddRole)
+
+	case snapshotRecordTypeMember:
+		return readSnapshotEntity(record, caches.AddMember)
+
+	case snapshotRecordTypeThreadMember:
+		return readSnapshotEntity(record, caches.AddThreadMember)
+
+	case snapshotRecordTypePresence:
+		return readSnapshotEntity(record, caches.AddPresence)
+
+	case snapshotRecordTypeVoiceState:
+		return readSnapshotEntity(record, caches.AddVoiceState)
+
+	case snapshotRecordTypeEmoji:
+		return readSnapshotEntity(record, caches.AddEmoji)
+
+	case snapshotRecordTypeSticker:
+		return readSnapshotEntity(record, caches.AddSticker)
+	}
+	// ignore unknown records, so snapshots with additional entities can still be read
+	return nil
+}
+
+func readSnapshotEntity[T any](record snapshotRecord, add func(T)) error {
+	var entity T
+	if err := json.Unmarshal(record.Data, &entity); err != nil {
+		return err
+	}
+	add(entity)
+	return nil
+}
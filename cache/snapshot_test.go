@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	var channel discord.UnmarshalChannel
+	if err := json.Unmarshal([]byte(`{"id":"10","guild_id":"1","type":0,"name":"general"}`), &channel); err != nil {
+		t.Fatal(err)
+	}
+
+	caches := New(WithCaches(FlagsAll))
+	caches.SetSelfUser(discord.OAuth2User{User: discord.User{ID: 100, Username: "bot"}})
+	caches.AddGuild(discord.Guild{ID: 1, Name: "guild"})
+	caches.SetGuildUnready(2, true)
+	caches.SetGuildUnavailable(3, true)
+	caches.AddChannel(channel.Channel.(discord.GuildChannel))
+	caches.AddRole(discord.Role{ID: 1, GuildID: 1, Name: "@everyone"})
+	caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: 100}, RoleIDs: []snowflake.ID{1}})
+	caches.AddVoiceState(discord.VoiceState{GuildID: 1, UserID: 100})
+	caches.AddEmoji(discord.Emoji{ID: 20, GuildID: 1, Name: "emoji"})
+	caches.AddMessage(discord.Message{ID: 30, ChannelID: 10})
+
+	path := filepath.Join(t.TempDir(), "snapshot")
+	if err := SaveSnapshot(path, caches); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := New(WithCaches(FlagsAll))
+	if err := LoadSnapshot(path, restored); err != nil {
+		t.Fatal(err)
+	}
+
+	if selfUser, ok := restored.SelfUser(); !ok || selfUser.Username != "bot" {
+		t.Errorf("expected self user bot, got %+v (%t)", selfUser, ok)
+	}
+	if guild, ok := restored.Guild(1); !ok || guild.Name != "guild" {
+		t.Errorf("expected guild, got %+v (%t)", guild, ok)
+	}
+	if !restored.IsGuildUnready(2) || !restored.IsGuildUnavailable(3) {
+		t.Error("expected unready & unavailable guilds to be restored")
+	}
+	if channel, ok := restored.GuildTextChannel(10); !ok || channel.Name() != "general" {
+		t.Errorf("expected text channel general, got %+v (%t)", channel, ok)
+	}
+	if member, ok := restored.Member(1, 100); !ok || len(member.RoleIDs) != 1 {
+		t.Errorf("expected member with role, got %+v (%t)", member, ok)
+	}
+	if _, ok := restored.Role(1, 1); !ok {
+		t.Error("expected role to be restored")
+	}
+	if _, ok := restored.VoiceState(1, 100); !ok {
+		t.Error("expected voice state to be restored")
+	}
+	if emoji, ok := restored.Emoji(1, 20); !ok || emoji.Name != "emoji" {
+		t.Errorf("expected emoji, got %+v (%t)", emoji, ok)
+	}
+	if l := restored.MessagesAllLen(); l != 0 {
+		t.Errorf("expected messages to not be part of the snapshot, got %d", l)
+	}
+}
+
+func TestSnapshot_Version(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, _ = gw.Write([]byte(`{"version":0}`))
+	_ = gw.Close()
+
+	if err := ReadSnapshot(&buf, New()); !errors.Is(err, ErrUnsupportedSnapshotVersion) {
+		t.Errorf("expected ErrUnsupportedSnapshotVersion, got %v", err)
+	}
+}
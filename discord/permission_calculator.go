@@ -0,0 +1,274 @@
+package discord
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// PermissionsTimedOut are the only permissions a timed out member keeps.
+const PermissionsTimedOut = PermissionViewChannel | PermissionReadMessageHistory
+
+// PermissionsRequireSendMessages are the permissions a member implicitly loses in a channel without PermissionSendMessages.
+const PermissionsRequireSendMessages = PermissionMentionEveryone |
+	PermissionSendTTSMessages |
+	PermissionAttachFiles |
+	PermissionEmbedLinks
+
+// PermissionCalculator calculates the permissions of members the same way Discord does, including all implicit permission rules.
+// It only needs the owner & roles of the guild, so it can be used with data from the cache, REST or interaction payloads.
+type PermissionCalculator struct {
+	GuildID snowflake.ID
+	OwnerID snowflake.ID
+	// Roles are the roles of the guild. Only the @everyone role & the roles of the member are needed.
+	Roles []Role
+	// Now is used to check whether a member is timed out. If zero, time.Now is used.
+	Now time.Time
+}
+
+// Permissions returns the guild wide permissions of the member.
+func (c PermissionCalculator) Permissions(member Member) Permissions {
+	return c.calculate(member, nil, false, false).permissions
+}
+
+// PermissionsInChannel returns the permissions of the member in the channel. For threads use PermissionsInThread.
+func (c PermissionCalculator) PermissionsInChannel(member Member, channel GuildChannel) Permissions {
+	return c.calculate(member, channel.PermissionOverwrites(), true, false).permissions
+}
+
+// PermissionsInThread returns the permissions of the member in a thread of the parent channel.
+// Threads inherit the permission overwrites of their parent & use PermissionSendMessagesInThreads instead of PermissionSendMessages.
+func (c PermissionCalculator) PermissionsInThread(member Member, parent GuildChannel) Permissions {
+	return c.calculate(member, parent.PermissionOverwrites(), true, true).permissions
+}
+
+// Explain returns a PermissionDenial for every one of the given permissions the member lacks guild wide.
+func (c PermissionCalculator) Explain(member Member, permissions Permissions) []PermissionDenial {
+	return c.calculate(member, nil, false, false).explain(permissions)
+}
+
+// ExplainInChannel returns a PermissionDenial for every one of the given permissions the member lacks in the channel. For threads use ExplainInThread.
+func (c PermissionCalculator) ExplainInChannel(member Member, channel GuildChannel, permissions Permissions) []PermissionDenial {
+	return c.calculate(member, channel.PermissionOverwrites(), true, false).explain(permissions)
+}
+
+// ExplainInThread returns a PermissionDenial for every one of the given permissions the member lacks in a thread of the parent channel.
+func (c PermissionCalculator) ExplainInThread(member Member, parent GuildChannel, permissions Permissions) []PermissionDenial {
+	return c.calculate(member, parent.PermissionOverwrites(), true, true).explain(permissions)
+}
+
+func (c PermissionCalculator) role(id snowflake.ID) (Role, bool) {
+	if i := slices.IndexFunc(c.Roles, func(role Role) bool { return role.ID == id }); i >= 0 {
+		return c.Roles[i], true
+	}
+	return Role{}, false
+}
+
+func (c PermissionCalculator) timedOut(member Member) bool {
+	if member.CommunicationDisabledUntil == nil {
+		return false
+	}
+	now := c.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return member.CommunicationDisabledUntil.After(now)
+}
+
+// permissionCalculation contains all steps of a permission calculation, which are needed to explain the result.
+type permissionCalculation struct {
+	timedOut bool
+	channel  bool
+	thread   bool
+
+	// base are the permissions granted by the roles
+	base Permissions
+
+	everyoneOverwrite RolePermissionOverwrite
+	roleOverwrites    []RolePermissionOverwrite
+	memberOverwrite   MemberPermissionOverwrite
+
+	// overwritten are the permissions after applying all overwrites
+	overwritten Permissions
+	permissions Permissions
+}
+
+func (c PermissionCalculator) calculate(member Member, overwrites PermissionOverwrites, channel bool, thread bool) permissionCalculation {
+	calc := permissionCalculation{
+		timedOut: c.timedOut(member),
+		channel:  channel,
+		thread:   thread,
+	}
+	if member.User.ID == c.OwnerID {
+		calc.permissions = PermissionsAll
+		return calc
+	}
+
+	if everyone, ok := c.role(c.GuildID); ok {
+		calc.base = everyone.Permissions
+	}
+	for _, roleID := range member.RoleIDs {
+		if role, ok := c.role(roleID); ok {
+			calc.base = calc.base.Add(role.Permissions)
+		}
+	}
+	if calc.base.Has(PermissionAdministrator) {
+		calc.permissions = PermissionsAll
+		return calc
+	}
+
+	permissions := calc.base
+	if channel {
+		calc.everyoneOverwrite, _ = overwrites.Role(c.GuildID)
+		permissions = permissions.Remove(calc.everyoneOverwrite.Deny).Add(calc.everyoneOverwrite.Allow)
+
+		var allow, deny Permissions
+		for _, roleID := range member.RoleIDs {
+			if roleID == c.GuildID {
+				continue
+			}
+			if overwrite, ok := overwrites.Role(roleID); ok {
+				calc.roleOverwrites = append(calc.roleOverwrites, overwrite)
+				allow = allow.Add(overwrite.Allow)
+				deny = deny.Add(overwrite.Deny)
+			}
+		}
+		permissions = permissions.Remove(deny).Add(allow)
+
+		calc.memberOverwrite, _ = overwrites.Member(member.User.ID)
+		permissions = permissions.Remove(calc.memberOverwrite.Deny).Add(calc.memberOverwrite.Allow)
+	}
+	calc.overwritten = permissions
+
+	if channel && permissions.Missing(PermissionViewChannel) {
+		permissions = PermissionsNone
+	}
+	if calc.timedOut {
+		permissions &= PermissionsTimedOut
+	}
+	if thread {
+		if permissions.Has(PermissionSendMessagesInThreads) {
+			permissions = permissions.Add(PermissionSendMessages)
+		} else {
+			permissions = permissions.Remove(PermissionSendMessages)
+		}
+	}
+	if channel && permissions.Missing(PermissionSendMessages) {
+		permissions = permissions.Remove(PermissionsRequireSendMessages)
+	}
+	calc.permissions = permissions
+	return calc
+}
+
+func (c permissionCalculation) explain(permissions Permissions) []PermissionDenial {
+	var denials []PermissionDenial
+	for i := range 64 {
+		permission := Permissions(1) << i
+		if !permissions.Has(permission) || c.permissions.Has(permission) {
+			continue
+		}
+		denials = append(denials, c.deny(permission))
+	}
+	return denials
+}
+
+func (c permissionCalculation) deny(permission Permissions) PermissionDenial {
+	denial := PermissionDenial{Permission: permission}
+	switch {
+	// in threads, PermissionSendMessages only depends on PermissionSendMessagesInThreads
+	case c.overwritten.Missing(permission) && !(c.thread && permission == PermissionSendMessages):
+		denial.Reason = c.overwriteReason(permission, &denial)
+	case c.channel && c.overwritten.Missing(PermissionViewChannel):
+		denial.Reason = PermissionDenialReasonNoViewChannel
+	case c.timedOut:
+		denial.Reason = PermissionDenialReasonTimedOut
+	case c.thread:
+		denial.Reason = PermissionDenialReasonNoSendMessagesInThreads
+	default:
+		denial.Reason = PermissionDenialReasonNoSendMessages
+	}
+	return denial
+}
+
+func (c permissionCalculation) overwriteReason(permission Permissions, denial *PermissionDenial) PermissionDenialReason {
+	if c.memberOverwrite.Deny.Has(permission) {
+		return PermissionDenialReasonMemberOverwrite
+	}
+	for _, overwrite := range c.roleOverwrites {
+		if overwrite.Deny.Has(permission) {
+			denial.RoleIDs = append(denial.RoleIDs, overwrite.RoleID)
+		}
+	}
+	if len(denial.RoleIDs) > 0 {
+		return PermissionDenialReasonRoleOverwrite
+	}
+	if c.everyoneOverwrite.Deny.Has(permission) {
+		return PermissionDenialReasonEveryoneOverwrite
+	}
+	return PermissionDenialReasonNotGranted
+}
+
+// PermissionDenialReason is the reason a member lacks a permission.
+type PermissionDenialReason int
+
+// Constants for PermissionDenialReason
+const (
+	// PermissionDenialReasonNotGranted means neither the @everyone role, the roles of the member nor any overwrite grant the permission.
+	PermissionDenialReasonNotGranted PermissionDenialReason = iota
+	// PermissionDenialReasonEveryoneOverwrite means the @everyone overwrite of the channel denies the permission.
+	PermissionDenialReasonEveryoneOverwrite
+	// PermissionDenialReasonRoleOverwrite means the overwrites of PermissionDenial.RoleIDs deny the permission & no role overwrite allows it.
+	PermissionDenialReasonRoleOverwrite
+	// PermissionDenialReasonMemberOverwrite means the overwrite of the member denies the permission.
+	PermissionDenialReasonMemberOverwrite
+	// PermissionDenialReasonNoViewChannel means the member can't view the channel, which implicitly denies all other permissions.
+	PermissionDenialReasonNoViewChannel
+	// PermissionDenialReasonTimedOut means the member is timed out, which denies all permissions except PermissionsTimedOut.
+	PermissionDenialReasonTimedOut
+	// PermissionDenialReasonNoSendMessagesInThreads means the member lacks PermissionSendMessagesInThreads, which replaces PermissionSendMessages in threads.
+	// Like PermissionSendMessages in other channels, it implicitly denies PermissionsRequireSendMessages.
+	PermissionDenialReasonNoSendMessagesInThreads
+	// PermissionDenialReasonNoSendMessages means the member lacks PermissionSendMessages, which implicitly denies PermissionsRequireSendMessages.
+	PermissionDenialReasonNoSendMessages
+)
+
+// PermissionDenial explains why a member lacks a permission.
+type PermissionDenial struct {
+	Permission Permissions
+	Reason     PermissionDenialReason
+	// RoleIDs are the roles whose overwrites deny the permission. Only set for PermissionDenialReasonRoleOverwrite.
+	RoleIDs []snowflake.ID
+}
+
+// String returns a human-readable explanation of the PermissionDenial.
+func (d PermissionDenial) String() string {
+	var reason string
+	switch d.Reason {
+	case PermissionDenialReasonNotGranted:
+		reason = "no role of the member grants it"
+	case PermissionDenialReasonEveryoneOverwrite:
+		reason = "the channel overwrite of @everyone denies it"
+	case PermissionDenialReasonRoleOverwrite:
+		roles := make([]string, len(d.RoleIDs))
+		for i, roleID := range d.RoleIDs {
+			roles[i] = RoleMention(roleID)
+		}
+		reason = "the channel overwrites of " + strings.Join(roles, ", ") + " deny it"
+	case PermissionDenialReasonMemberOverwrite:
+		reason = "the channel overwrite of the member denies it"
+	case PermissionDenialReasonNoViewChannel:
+		reason = "the member can't view the channel"
+	case PermissionDenialReasonTimedOut:
+		reason = "the member is timed out"
+	case PermissionDenialReasonNoSendMessagesInThreads:
+		reason = "the member lacks Send Messages in Threads"
+	case PermissionDenialReasonNoSendMessages:
+		reason = "the member lacks Send Messages"
+	default:
+		reason = fmt.Sprintf("unknown reason %d", d.Reason)
+	}
+	return fmt.Sprintf("missing %s: %s", d.Permission, reason)
+}
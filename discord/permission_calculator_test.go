@@ -0,0 +1,214 @@
+package discord
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+const (
+	testGuildID     snowflake.ID = 1
+	testOwnerID     snowflake.ID = 2
+	testMemberID    snowflake.ID = 3
+	testModRoleID   snowflake.ID = 10
+	testMutedRoleID snowflake.ID = 11
+	testAdminRoleID snowflake.ID = 12
+)
+
+func testPermissionCalculator() PermissionCalculator {
+	return PermissionCalculator{
+		GuildID: testGuildID,
+		OwnerID: testOwnerID,
+		Roles: []Role{
+			{ID: testGuildID, Permissions: PermissionViewChannel | PermissionSendMessages | PermissionReadMessageHistory | PermissionAttachFiles | PermissionSendMessagesInThreads},
+			{ID: testModRoleID, Permissions: PermissionKickMembers | PermissionManageMessages},
+			{ID: testMutedRoleID},
+			{ID: testAdminRoleID, Permissions: PermissionAdministrator},
+		},
+		Now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func testChannel(overwrites ...PermissionOverwrite) GuildChannel {
+	return GuildTextChannel{guildID: testGuildID, permissionOverwrites: overwrites}
+}
+
+func TestPermissionCalculator(t *testing.T) {
+	timedOutUntil := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	timedOutBefore := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		member   Member
+		channel  GuildChannel
+		thread   bool
+		has      Permissions
+		notHas   Permissions
+		denials  []PermissionDenialReason
+		roleIDs  []snowflake.ID
+		explains Permissions
+	}{
+		{
+			name:   "owner",
+			member: Member{User: User{ID: testOwnerID}},
+			has:    PermissionsAll,
+		},
+		{
+			name:    "administrator ignores overwrites & timeout",
+			member:  Member{User: User{ID: testMemberID}, RoleIDs: []snowflake.ID{testAdminRoleID}, CommunicationDisabledUntil: &timedOutUntil},
+			channel: testChannel(RolePermissionOverwrite{RoleID: testGuildID, Deny: PermissionViewChannel}),
+			has:     PermissionsAll,
+		},
+		{
+			name:     "roles",
+			member:   Member{User: User{ID: testMemberID}, RoleIDs: []snowflake.ID{testModRoleID}},
+			has:      PermissionKickMembers | PermissionManageMessages | PermissionSendMessages,
+			notHas:   PermissionBanMembers,
+			denials:  []PermissionDenialReason{PermissionDenialReasonNotGranted},
+			explains: PermissionBanMembers | PermissionKickMembers,
+		},
+		{
+			name:     "timed out",
+			member:   Member{User: User{ID: testMemberID}, RoleIDs: []snowflake.ID{testModRoleID}, CommunicationDisabledUntil: &timedOutUntil},
+			has:      PermissionViewChannel | PermissionReadMessageHistory,
+			notHas:   PermissionKickMembers | PermissionSendMessages,
+			denials:  []PermissionDenialReason{PermissionDenialReasonTimedOut},
+			explains: PermissionKickMembers,
+		},
+		{
+			name:   "expired timeout",
+			member: Member{User: User{ID: testMemberID}, RoleIDs: []snowflake.ID{testModRoleID}, CommunicationDisabledUntil: &timedOutBefore},
+			has:    PermissionKickMembers | PermissionSendMessages,
+		},
+		{
+			name:     "everyone overwrite",
+			member:   Member{User: User{ID: testMemberID}},
+			channel:  testChannel(RolePermissionOverwrite{RoleID: testGuildID, Deny: PermissionReadMessageHistory}),
+			has:      PermissionViewChannel | PermissionSendMessages,
+			notHas:   PermissionReadMessageHistory,
+			denials:  []PermissionDenialReason{PermissionDenialReasonEveryoneOverwrite},
+			explains: PermissionReadMessageHistory,
+		},
+		{
+			name:   "role overwrite allow beats everyone overwrite deny",
+			member: Member{User: User{ID: testMemberID}, RoleIDs: []snowflake.ID{testModRoleID}},
+			channel: testChannel(
+				RolePermissionOverwrite{RoleID: testGuildID, Deny: PermissionReadMessageHistory},
+				RolePermissionOverwrite{RoleID: testModRoleID, Allow: PermissionReadMessageHistory},
+			),
+			has: PermissionReadMessageHistory,
+		},
+		{
+			name:   "role overwrite allow beats role overwrite deny",
+			member: Member{User: User{ID: testMemberID}, RoleIDs: []snowflake.ID{testModRoleID, testMutedRoleID}},
+			channel: testChannel(
+				RolePermissionOverwrite{RoleID: testMutedRoleID, Deny: PermissionSendMessages | PermissionAddReactions},
+				RolePermissionOverwrite{RoleID: testModRoleID, Allow: PermissionSendMessages},
+			),
+			has:      PermissionSendMessages,
+			notHas:   PermissionAddReactions,
+			denials:  []PermissionDenialReason{PermissionDenialReasonRoleOverwrite},
+			roleIDs:  []snowflake.ID{testMutedRoleID},
+			explains: PermissionAddReactions,
+		},
+		{
+			name:   "member overwrite beats role overwrite",
+			member: Member{User: User{ID: testMemberID}, RoleIDs: []snowflake.ID{testModRoleID}},
+			channel: testChannel(
+				RolePermissionOverwrite{RoleID: testModRoleID, Allow: PermissionAddReactions},
+				MemberPermissionOverwrite{UserID: testMemberID, Deny: PermissionAddReactions},
+			),
+			notHas:   PermissionAddReactions,
+			denials:  []PermissionDenialReason{PermissionDenialReasonMemberOverwrite},
+			explains: PermissionAddReactions,
+		},
+		{
+			name:     "no view channel denies everything",
+			member:   Member{User: User{ID: testMemberID}, RoleIDs: []snowflake.ID{testModRoleID}},
+			channel:  testChannel(RolePermissionOverwrite{RoleID: testGuildID, Deny: PermissionViewChannel}),
+			notHas:   PermissionViewChannel | PermissionSendMessages | PermissionManageMessages,
+			denials:  []PermissionDenialReason{PermissionDenialReasonEveryoneOverwrite, PermissionDenialReasonNoViewChannel},
+			explains: PermissionViewChannel | PermissionSendMessages,
+		},
+		{
+			name:     "no send messages denies attachments",
+			member:   Member{User: User{ID: testMemberID}},
+			channel:  testChannel(MemberPermissionOverwrite{UserID: testMemberID, Deny: PermissionSendMessages}),
+			has:      PermissionViewChannel,
+			notHas:   PermissionSendMessages | PermissionAttachFiles,
+			denials:  []PermissionDenialReason{PermissionDenialReasonNoSendMessages},
+			explains: PermissionAttachFiles,
+		},
+		{
+			name:    "threads inherit overwrites of the parent",
+			member:  Member{User: User{ID: testMemberID}},
+			channel: testChannel(RolePermissionOverwrite{RoleID: testGuildID, Deny: PermissionReadMessageHistory}),
+			thread:  true,
+			has:     PermissionSendMessages | PermissionAttachFiles,
+			notHas:  PermissionReadMessageHistory,
+		},
+		{
+			name:     "threads use send messages in threads",
+			member:   Member{User: User{ID: testMemberID}},
+			channel:  testChannel(RolePermissionOverwrite{RoleID: testGuildID, Allow: PermissionSendMessages, Deny: PermissionSendMessagesInThreads}),
+			thread:   true,
+			notHas:   PermissionSendMessages | PermissionAttachFiles,
+			denials:  []PermissionDenialReason{PermissionDenialReasonNoSendMessagesInThreads, PermissionDenialReasonNoSendMessagesInThreads},
+			explains: PermissionSendMessages | PermissionAttachFiles,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testPermissionCalculator()
+
+			var (
+				permissions Permissions
+				denials     []PermissionDenial
+			)
+			switch {
+			case tt.channel == nil:
+				permissions = c.Permissions(tt.member)
+				denials = c.Explain(tt.member, tt.explains)
+			case tt.thread:
+				permissions = c.PermissionsInThread(tt.member, tt.channel)
+				denials = c.ExplainInThread(tt.member, tt.channel, tt.explains)
+			default:
+				permissions = c.PermissionsInChannel(tt.member, tt.channel)
+				denials = c.ExplainInChannel(tt.member, tt.channel, tt.explains)
+			}
+
+			if !permissions.Has(tt.has) {
+				t.Errorf("expected %s, got %s", tt.has, permissions)
+			}
+			if tt.notHas != 0 && permissions&tt.notHas != 0 {
+				t.Errorf("expected not %s, got %s", tt.notHas, permissions&tt.notHas)
+			}
+
+			reasons := make([]PermissionDenialReason, len(denials))
+			for i, denial := range denials {
+				reasons[i] = denial.Reason
+				if !slices.Equal(denial.RoleIDs, tt.roleIDs) {
+					t.Errorf("expected role IDs %v, got %v", tt.roleIDs, denial.RoleIDs)
+				}
+			}
+			if !slices.Equal(reasons, tt.denials) {
+				t.Errorf("expected denials %v, got %v (%v)", tt.denials, reasons, denials)
+			}
+		})
+	}
+}
+
+func TestPermissionDenial_String(t *testing.T) {
+	denial := PermissionDenial{
+		Permission: PermissionSendMessages,
+		Reason:     PermissionDenialReasonRoleOverwrite,
+		RoleIDs:    []snowflake.ID{10, 11},
+	}
+	expected := "missing Send Messages: the channel overwrites of <@&10>, <@&11> deny it"
+	if s := denial.String(); s != expected {
+		t.Errorf("expected %q, got %q", expected, s)
+	}
+}
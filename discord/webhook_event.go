@@ -0,0 +1,140 @@
+package discord
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// WebhookEventPayloadType is the type of WebhookEventPayload sent by Discord to the webhook events url of an application.
+type WebhookEventPayloadType int
+
+const (
+	// WebhookEventPayloadTypePing is sent by Discord to check whether the url is valid.
+	WebhookEventPayloadTypePing WebhookEventPayloadType = iota
+	// WebhookEventPayloadTypeEvent is sent by Discord for every WebhookEvent the application is subscribed to.
+	WebhookEventPayloadTypeEvent
+)
+
+// WebhookEventPayload is sent by Discord to the webhook events url of an application.
+// See https://discord.com/developers/docs/events/webhook-events#payload-structure
+type WebhookEventPayload struct {
+	Version       int                     `json:"version"`
+	ApplicationID snowflake.ID            `json:"application_id"`
+	Type          WebhookEventPayloadType `json:"type"`
+	Event         *WebhookEvent           `json:"event,omitempty"`
+}
+
+// WebhookEventType is the type of WebhookEvent.
+type WebhookEventType string
+
+const (
+	WebhookEventTypeApplicationAuthorized   WebhookEventType = "APPLICATION_AUTHORIZED"
+	WebhookEventTypeApplicationDeauthorized WebhookEventType = "APPLICATION_DEAUTHORIZED"
+	WebhookEventTypeEntitlementCreate       WebhookEventType = "ENTITLEMENT_CREATE"
+	WebhookEventTypeQuestUserEnrollment     WebhookEventType = "QUEST_USER_ENROLLMENT"
+)
+
+// WebhookEvent is the event of a WebhookEventPayload with the type WebhookEventPayloadTypeEvent.
+// See https://discord.com/developers/docs/events/webhook-events#event-body-object
+type WebhookEvent struct {
+	Type      WebhookEventType `json:"type"`
+	Timestamp time.Time        `json:"timestamp"`
+	Data      WebhookEventData `json:"data,omitempty"`
+}
+
+// webhookEventTimestampLayout is the layout of the WebhookEvent timestamp, which is sent without a time zone.
+const webhookEventTimestampLayout = "2006-01-02T15:04:05.999999"
+
+func (e *WebhookEvent) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Type      WebhookEventType `json:"type"`
+		Timestamp string           `json:"timestamp"`
+		Data      json.RawMessage  `json:"data"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var timestamp time.Time
+	if v.Timestamp != "" {
+		var err error
+		if timestamp, err = time.Parse(time.RFC3339Nano, v.Timestamp); err != nil {
+			if timestamp, err = time.Parse(webhookEventTimestampLayout, v.Timestamp); err != nil {
+				return fmt.Errorf("invalid webhook event timestamp: %w", err)
+			}
+		}
+	}
+
+	var (
+		eventData WebhookEventData
+		err       error
+	)
+	switch v.Type {
+	case WebhookEventTypeApplicationAuthorized:
+		var d WebhookEventApplicationAuthorized
+		err = json.Unmarshal(v.Data, &d)
+		eventData = d
+
+	case WebhookEventTypeApplicationDeauthorized:
+		var d WebhookEventApplicationDeauthorized
+		err = json.Unmarshal(v.Data, &d)
+		eventData = d
+
+	case WebhookEventTypeEntitlementCreate:
+		var d WebhookEventEntitlementCreate
+		err = json.Unmarshal(v.Data, &d)
+		eventData = d
+
+	default:
+		eventData = WebhookEventUnknown(v.Data)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.Type = v.Type
+	e.Timestamp = timestamp
+	e.Data = eventData
+	return nil
+}
+
+// WebhookEventData is the data of a WebhookEvent.
+type WebhookEventData interface {
+	webhookEventData()
+}
+
+// WebhookEventApplicationAuthorized is sent when the application was added to a server or user account.
+type WebhookEventApplicationAuthorized struct {
+	IntegrationType *ApplicationIntegrationType `json:"integration_type,omitempty"`
+	User            User                        `json:"user"`
+	Scopes          []OAuth2Scope               `json:"scopes"`
+	Guild           *Guild                      `json:"guild,omitempty"`
+}
+
+func (WebhookEventApplicationAuthorized) webhookEventData() {}
+
+// WebhookEventApplicationDeauthorized is sent when the application was deauthorized by a user.
+type WebhookEventApplicationDeauthorized struct {
+	User User `json:"user"`
+}
+
+func (WebhookEventApplicationDeauthorized) webhookEventData() {}
+
+// WebhookEventEntitlementCreate is sent when an Entitlement was created.
+type WebhookEventEntitlementCreate struct {
+	Entitlement
+}
+
+func (WebhookEventEntitlementCreate) webhookEventData() {}
+
+// WebhookEventUnknown is the raw data of a WebhookEvent which has no typed data, like WebhookEventTypeQuestUserEnrollment.
+type WebhookEventUnknown json.RawMessage
+
+func (e WebhookEventUnknown) MarshalJSON() ([]byte, error) {
+	return json.RawMessage(e).MarshalJSON()
+}
+
+func (WebhookEventUnknown) webhookEventData() {}
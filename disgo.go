@@ -22,7 +22,7 @@
 //
 // # HTTPServer
 //
-// Package httpserver is used to interact with the Discord outgoing webhooks for interactions.
+// Package httpserver is used to interact with the Discord outgoing webhooks for interactions and webhook events.
 //
 // # Events
 //
@@ -43,6 +43,14 @@
 // # Voice
 //
 // Package voice provides a high level client interface for interacting with Discord voice.
+//
+// # Metrics
+//
+// Package metrics provides a metrics interface and a Prometheus exporter used to instrument the other packages.
+//
+// # Tracing
+//
+// Package tracing provides an exporter-agnostic tracing interface used to trace events, interaction handlers and rest requests.
 package disgo
 
 import (
@@ -87,6 +95,7 @@ func New(token string, opts ...bot.ConfigOpt) (*bot.Client, error) {
 		opts,
 		handlers.GetGatewayHandlers(),
 		handlers.GetHTTPServerHandler(),
+		handlers.GetWebhookEventHandler(),
 		runtime.GOOS,
 		Name,
 		GitHub,
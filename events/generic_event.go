@@ -1,6 +1,8 @@
 package events
 
 import (
+	"context"
+
 	"github.com/disgoorg/disgo/bot"
 )
 
@@ -14,6 +16,7 @@ type GenericEvent struct {
 	client         *bot.Client
 	sequenceNumber int
 	shardID        int
+	ctx            context.Context
 }
 
 // Client returns the bot.Client instance that dispatched the event
@@ -30,3 +33,17 @@ func (e *GenericEvent) SequenceNumber() int {
 func (e *GenericEvent) ShardID() int {
 	return e.shardID
 }
+
+// DispatchContext returns the context.Context the event is dispatched with.
+// It contains the tracing span the event is dispatched in. Defaults to context.Background().
+func (e *GenericEvent) DispatchContext() context.Context {
+	if e.ctx == nil {
+		return context.Background()
+	}
+	return e.ctx
+}
+
+// SetDispatchContext sets the context.Context the event is dispatched with. This is called by the bot.EventManager before dispatching the event.
+func (e *GenericEvent) SetDispatchContext(ctx context.Context) {
+	e.ctx = ctx
+}
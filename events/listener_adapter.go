@@ -72,6 +72,12 @@ type ListenerAdapter struct {
 	OnEntitlementUpdate func(event *EntitlementUpdate)
 	OnEntitlementDelete func(event *EntitlementDelete)
 
+	// Webhook Events
+	OnApplicationAuthorized    func(event *ApplicationAuthorized)
+	OnApplicationDeauthorized  func(event *ApplicationDeauthorized)
+	OnWebhookEntitlementCreate func(event *WebhookEntitlementCreate)
+	OnUnknownWebhookEvent      func(event *UnknownWebhookEvent)
+
 	// Subscription Events
 	OnSubscriptionCreate func(event *SubscriptionCreate)
 	OnSubscriptionUpdate func(event *SubscriptionUpdate)
@@ -373,6 +379,24 @@ func (l *ListenerAdapter) OnEvent(event bot.Event) {
 			listener(e)
 		}
 
+	// Webhook Events
+	case *ApplicationAuthorized:
+		if listener := l.OnApplicationAuthorized; listener != nil {
+			listener(e)
+		}
+	case *ApplicationDeauthorized:
+		if listener := l.OnApplicationDeauthorized; listener != nil {
+			listener(e)
+		}
+	case *WebhookEntitlementCreate:
+		if listener := l.OnWebhookEntitlementCreate; listener != nil {
+			listener(e)
+		}
+	case *UnknownWebhookEvent:
+		if listener := l.OnUnknownWebhookEvent; listener != nil {
+			listener(e)
+		}
+
 	// Subscription Events
 	case *SubscriptionCreate:
 		if listener := l.OnSubscriptionCreate; listener != nil {
@@ -0,0 +1,43 @@
+package events
+
+import (
+	"time"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// GenericWebhookEvent is the base of all events received via Discord's Webhook Events
+type GenericWebhookEvent struct {
+	*GenericEvent
+	ApplicationID snowflake.ID
+	Timestamp     time.Time
+}
+
+// ApplicationAuthorized indicates that the application was added to a server or user account
+type ApplicationAuthorized struct {
+	*GenericWebhookEvent
+	discord.WebhookEventApplicationAuthorized
+}
+
+// ApplicationDeauthorized indicates that the application was deauthorized by a user
+type ApplicationDeauthorized struct {
+	*GenericWebhookEvent
+	discord.WebhookEventApplicationDeauthorized
+}
+
+// WebhookEntitlementCreate indicates that an entitlement was created, received via Discord's Webhook Events.
+// Bots which also receive entitlements via the gateway get events.EntitlementCreate for the same entitlement.
+type WebhookEntitlementCreate struct {
+	*GenericWebhookEvent
+	discord.WebhookEventEntitlementCreate
+}
+
+// UnknownWebhookEvent is a webhook event without typed data, like discord.WebhookEventTypeQuestUserEnrollment
+type UnknownWebhookEvent struct {
+	*GenericWebhookEvent
+	Type discord.WebhookEventType
+	Data json.RawMessage
+}
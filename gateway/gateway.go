@@ -15,6 +15,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/disgoorg/snowflake/v2"
 	"github.com/gorilla/websocket"
 
 	"github.com/disgoorg/disgo/discord"
@@ -127,6 +128,7 @@ type Gateway interface {
 	Open(ctx context.Context) error
 
 	// Close gracefully closes the Gateway with the websocket.CloseNormalClosure code.
+	// If a SessionStore is configured, the Gateway is closed with the websocket.CloseServiceRestart code instead & its Session is persisted, so it can be resumed by the next Open.
 	// If the context is done, the Gateway connection will be killed.
 	Close(ctx context.Context)
 
@@ -151,6 +153,11 @@ type Gateway interface {
 
 var _ Gateway = (*gatewayImpl)(nil)
 
+// ShardIDByGuild returns the shard ID for the given guildID and shardCount.
+func ShardIDByGuild(guildID snowflake.ID, shardCount int) int {
+	return int((uint64(guildID) >> 22) % uint64(shardCount))
+}
+
 // New creates a new Gateway instance with the provided token, eventHandlerFunc, closeHandlerFunc and ConfigOpt(s).
 func New(token string, eventHandlerFunc EventHandlerFunc, closeHandlerFunc CloseHandlerFunc, opts ...ConfigOpt) Gateway {
 	cfg := defaultConfig()
@@ -158,6 +165,7 @@ func New(token string, eventHandlerFunc EventHandlerFunc, closeHandlerFunc Close
 
 	return &gatewayImpl{
 		config:           cfg,
+		metrics:          newGatewayMetrics(cfg.Metrics, cfg.ShardID),
 		eventHandlerFunc: eventHandlerFunc,
 		closeHandlerFunc: closeHandlerFunc,
 		token:            token,
@@ -167,19 +175,24 @@ func New(token string, eventHandlerFunc EventHandlerFunc, closeHandlerFunc Close
 
 type gatewayImpl struct {
 	config           config
+	metrics          gatewayMetrics
 	eventHandlerFunc EventHandlerFunc
 	closeHandlerFunc CloseHandlerFunc
 	token            string
 
-	conn            transport
-	connMu          sync.Mutex
-	heartbeatCancel context.CancelFunc
-	status          Status
-	statusMu        sync.Mutex
+	conn     transport
+	connMu   sync.Mutex
+	status   Status
+	statusMu sync.Mutex
+
+	// sessionMu guards the SessionID, LastSequenceReceived & ResumeURL of the config, which are updated by the listen goroutine
+	sessionMu sync.Mutex
 
+	heartbeatCancel       context.CancelFunc
 	heartbeatInterval     time.Duration
 	lastHeartbeatSent     time.Time
 	lastHeartbeatReceived time.Time
+	heartbeatMu           sync.Mutex
 }
 
 func (g *gatewayImpl) ShardID() int {
@@ -191,27 +204,107 @@ func (g *gatewayImpl) ShardCount() int {
 }
 
 func (g *gatewayImpl) SessionID() *string {
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
 	return g.config.SessionID
 }
 
 func (g *gatewayImpl) LastSequenceReceived() *int {
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
 	return g.config.LastSequenceReceived
 }
 
 func (g *gatewayImpl) ResumeURL() *string {
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
 	return g.config.ResumeURL
 }
 
+// resumable returns whether there is a session to resume.
+func (g *gatewayImpl) resumable() bool {
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
+	return g.config.SessionID != nil && g.config.LastSequenceReceived != nil
+}
+
+// clearSession clears the resume data, so the next connection identifies again.
+func (g *gatewayImpl) clearSession() {
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
+	g.config.SessionID = nil
+	g.config.LastSequenceReceived = nil
+	g.config.ResumeURL = nil
+}
+
 func (g *gatewayImpl) Intents() Intents {
 	return g.config.Intents
 }
 
 func (g *gatewayImpl) Open(ctx context.Context) error {
+	g.loadSession(ctx)
 	return g.doReconnect(ctx)
 }
 
+// loadSession restores the Session from the SessionStore if no session was configured manually.
+// The stored Session is removed, so it's never resumed twice.
+func (g *gatewayImpl) loadSession(ctx context.Context) {
+	if g.config.SessionStore == nil || g.SessionID() != nil {
+		return
+	}
+
+	session, err := g.config.SessionStore.Get(ctx, g.config.ShardID, g.config.ShardCount)
+	if err != nil {
+		g.config.Logger.ErrorContext(ctx, "failed to load session", slog.Any("err", err))
+		return
+	}
+	if session == nil {
+		return
+	}
+
+	if err = g.config.SessionStore.Delete(ctx, g.config.ShardID, g.config.ShardCount); err != nil {
+		g.config.Logger.ErrorContext(ctx, "failed to delete session", slog.Any("err", err))
+	}
+
+	g.config.Logger.DebugContext(ctx, "loaded session", slog.String("session_id", session.ID), slog.Int("sequence", session.Sequence))
+	g.sessionMu.Lock()
+	defer g.sessionMu.Unlock()
+	g.config.SessionID = &session.ID
+	g.config.LastSequenceReceived = &session.Sequence
+	if session.ResumeURL != "" {
+		g.config.ResumeURL = &session.ResumeURL
+	}
+}
+
+// storeSession persists the current Session to the SessionStore or removes it if there is nothing to resume.
+func (g *gatewayImpl) storeSession(ctx context.Context) {
+	g.sessionMu.Lock()
+	if g.config.SessionID == nil || g.config.LastSequenceReceived == nil {
+		g.sessionMu.Unlock()
+		if err := g.config.SessionStore.Delete(ctx, g.config.ShardID, g.config.ShardCount); err != nil {
+			g.config.Logger.ErrorContext(ctx, "failed to delete session", slog.Any("err", err))
+		}
+		return
+	}
+
+	session := Session{
+		ID:       *g.config.SessionID,
+		Sequence: *g.config.LastSequenceReceived,
+	}
+	if g.config.ResumeURL != nil {
+		session.ResumeURL = *g.config.ResumeURL
+	}
+	g.sessionMu.Unlock()
+
+	if err := g.config.SessionStore.Put(ctx, g.config.ShardID, g.config.ShardCount, session); err != nil {
+		g.config.Logger.ErrorContext(ctx, "failed to store session", slog.Any("err", err))
+		return
+	}
+	g.config.Logger.DebugContext(ctx, "stored session", slog.String("session_id", session.ID), slog.Int("sequence", session.Sequence))
+}
+
 func (g *gatewayImpl) open(ctx context.Context) error {
-	g.config.Logger.DebugContext(ctx, "opening gateway connection", slog.String("compression", g.config.Compression.String()))
+	g.config.Logger.DebugContext(ctx, "opening gateway connection", slog.String("compression", g.config.Compression.String()), slog.String("encoding", g.config.Encoding.String()))
 
 	g.connMu.Lock()
 	if g.conn != nil {
@@ -222,7 +315,7 @@ func (g *gatewayImpl) open(ctx context.Context) error {
 	g.status = StatusConnecting
 	g.statusMu.Unlock()
 
-	if g.config.LastSequenceReceived == nil || g.config.SessionID == nil {
+	if !g.resumable() {
 		if err := g.config.IdentifyRateLimiter.Wait(ctx, g.config.ShardID); err != nil {
 			g.config.Logger.ErrorContext(ctx, "failed to wait for identify rate limiter", slog.Any("err", err))
 			g.connMu.Unlock()
@@ -232,13 +325,13 @@ func (g *gatewayImpl) open(ctx context.Context) error {
 	}
 
 	wsURL := g.config.URL
-	if g.config.ResumeURL != nil && g.config.EnableResumeURL {
-		wsURL = *g.config.ResumeURL
+	if resumeURL := g.ResumeURL(); resumeURL != nil && g.config.EnableResumeURL {
+		wsURL = *resumeURL
 	}
 
 	values := url.Values{}
 	values.Set("v", strconv.Itoa(Version))
-	values.Set("encoding", "json")
+	values.Set("encoding", g.config.Encoding.String())
 
 	if g.config.Compression.IsStreamCompression() {
 		values.Set("compress", string(g.config.Compression))
@@ -246,7 +339,9 @@ func (g *gatewayImpl) open(ctx context.Context) error {
 
 	gatewayURL := wsURL + "?" + values.Encode()
 
+	g.heartbeatMu.Lock()
 	g.lastHeartbeatSent = time.Now().UTC()
+	g.heartbeatMu.Unlock()
 	conn, rs, err := g.config.Dialer.DialContext(ctx, gatewayURL, nil)
 	if err != nil {
 		var body []byte
@@ -273,7 +368,7 @@ func (g *gatewayImpl) open(ctx context.Context) error {
 		return nil
 	})
 
-	t := newTransport(g.config.Compression, conn, g.config.Logger)
+	t := newTransport(g.config.Compression, g.config.Encoding, conn, g.config.Logger)
 	g.conn = t
 	g.connMu.Unlock()
 
@@ -312,14 +407,23 @@ func (g *gatewayImpl) open(ctx context.Context) error {
 }
 
 func (g *gatewayImpl) Close(ctx context.Context) {
+	if g.config.SessionStore != nil {
+		// closing with a non 1000/1001 code keeps the session resumable
+		g.CloseWithCode(ctx, websocket.CloseServiceRestart, "Restarting")
+		g.storeSession(ctx)
+		return
+	}
 	g.CloseWithCode(ctx, websocket.CloseNormalClosure, "Shutting down")
 }
 
 func (g *gatewayImpl) CloseWithCode(ctx context.Context, code int, message string) {
+	g.heartbeatMu.Lock()
 	if g.heartbeatCancel != nil {
 		g.config.Logger.DebugContext(ctx, "closing heartbeat goroutine")
 		g.heartbeatCancel()
+		g.heartbeatCancel = nil
 	}
+	g.heartbeatMu.Unlock()
 
 	g.connMu.Lock()
 	defer g.connMu.Unlock()
@@ -334,9 +438,7 @@ func (g *gatewayImpl) CloseWithCode(ctx context.Context, code int, message strin
 
 		// clear resume data as we closed gracefully
 		if code == websocket.CloseNormalClosure || code == websocket.CloseGoingAway {
-			g.config.SessionID = nil
-			g.config.ResumeURL = nil
-			g.config.LastSequenceReceived = nil
+			g.clearSession()
 		}
 	}
 	g.statusMu.Lock()
@@ -381,6 +483,8 @@ func (g *gatewayImpl) sendInternal(ctx context.Context, op Opcode, d MessageData
 }
 
 func (g *gatewayImpl) Latency() time.Duration {
+	g.heartbeatMu.Lock()
+	defer g.heartbeatMu.Unlock()
 	return g.lastHeartbeatReceived.Sub(g.lastHeartbeatSent)
 }
 
@@ -437,6 +541,7 @@ func (g *gatewayImpl) doReconnect(ctx context.Context) error {
 }
 
 func (g *gatewayImpl) reconnect() {
+	g.metrics.incReconnects()
 	if err := g.doReconnect(context.Background()); err != nil {
 		g.config.Logger.Error("failed to reopen gateway", slog.Any("err", err))
 
@@ -446,30 +551,47 @@ func (g *gatewayImpl) reconnect() {
 	}
 }
 
-func (g *gatewayImpl) heartbeat() {
-	defer g.config.Logger.Debug("exiting heartbeat goroutine")
-
+// startHeartbeat stops the heartbeat goroutine of the previous connection & starts a new one with the given interval.
+func (g *gatewayImpl) startHeartbeat(interval time.Duration) {
 	ctx, cancel := context.WithCancel(context.Background())
+
+	g.heartbeatMu.Lock()
+	if g.heartbeatCancel != nil {
+		g.heartbeatCancel()
+	}
 	g.heartbeatCancel = cancel
+	g.heartbeatInterval = interval
+	g.lastHeartbeatReceived = time.Now().UTC()
+	g.heartbeatMu.Unlock()
+
+	go g.heartbeat(ctx, interval)
+}
+
+func (g *gatewayImpl) heartbeat(ctx context.Context, interval time.Duration) {
+	defer g.config.Logger.Debug("exiting heartbeat goroutine")
 
 	// First heartbeat has to be sent at `heartbeat_interval * jitter`
 	// with jitter being a random value between 0 and 1
 	select {
 	case <-ctx.Done():
 		return
-	case <-time.After(time.Duration(float64(g.heartbeatInterval.Milliseconds())*rand.Float64()) * time.Millisecond):
+	case <-time.After(time.Duration(float64(interval.Milliseconds())*rand.Float64()) * time.Millisecond):
 	}
 	g.sendHeartbeat()
 
 	// Then we send them periodically every `heartbeat_interval`
-	heartbeatTicker := time.NewTicker(g.heartbeatInterval)
+	heartbeatTicker := time.NewTicker(interval)
+	defer heartbeatTicker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return
 
 		case <-heartbeatTicker.C:
-			if g.lastHeartbeatSent.After(g.lastHeartbeatReceived) {
+			g.heartbeatMu.Lock()
+			zombie := g.lastHeartbeatSent.After(g.lastHeartbeatReceived)
+			g.heartbeatMu.Unlock()
+			if zombie {
 				g.config.Logger.Warn("ACK of last heartbeat not received, connection went zombie")
 				closeCtx, closeCancel := context.WithTimeout(context.Background(), 5*time.Second)
 				g.CloseWithCode(closeCtx, websocket.CloseServiceRestart, "heartbeat ACK not received")
@@ -487,11 +609,15 @@ func (g *gatewayImpl) sendHeartbeat() {
 	g.config.Logger.Debug("sending heartbeat")
 
 	sequence := 0
-	if g.config.LastSequenceReceived != nil {
-		sequence = *g.config.LastSequenceReceived
+	if lastSequence := g.LastSequenceReceived(); lastSequence != nil {
+		sequence = *lastSequence
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), g.heartbeatInterval)
+	g.heartbeatMu.Lock()
+	interval := g.heartbeatInterval
+	g.heartbeatMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), interval)
 	defer cancel()
 	if err := g.sendInternal(ctx, OpcodeHeartbeat, MessageDataHeartbeat(sequence)); err != nil {
 		if errors.Is(err, discord.ErrShardNotConnected) || errors.Is(err, syscall.EPIPE) {
@@ -504,7 +630,9 @@ func (g *gatewayImpl) sendHeartbeat() {
 		go g.reconnect()
 		return
 	}
+	g.heartbeatMu.Lock()
 	g.lastHeartbeatSent = time.Now().UTC()
+	g.heartbeatMu.Unlock()
 }
 
 func (g *gatewayImpl) identify() error {
@@ -543,11 +671,13 @@ func (g *gatewayImpl) resume() error {
 	g.statusMu.Lock()
 	g.status = StatusResuming
 	g.statusMu.Unlock()
+	g.sessionMu.Lock()
 	resume := MessageDataResume{
 		Token:     g.token,
 		SessionID: *g.config.SessionID,
 		Seq:       *g.config.LastSequenceReceived,
 	}
+	g.sessionMu.Unlock()
 	g.config.Logger.Debug("sending Resume command")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -590,9 +720,7 @@ func (g *gatewayImpl) listen(conn transport, ready func(error)) {
 				reconnect = closeCode.Reconnect
 
 				if closeCode == CloseEventCodeInvalidSeq {
-					g.config.LastSequenceReceived = nil
-					g.config.SessionID = nil
-					g.config.ResumeURL = nil
+					g.clearSession()
 				}
 				msg := "gateway close received"
 				args := []any{
@@ -631,11 +759,9 @@ func (g *gatewayImpl) listen(conn transport, ready func(error)) {
 
 		switch message.Op {
 		case OpcodeHello:
-			g.heartbeatInterval = time.Duration(message.D.(MessageDataHello).HeartbeatInterval) * time.Millisecond
-			g.lastHeartbeatReceived = time.Now().UTC()
-			go g.heartbeat()
+			g.startHeartbeat(time.Duration(message.D.(MessageDataHello).HeartbeatInterval) * time.Millisecond)
 
-			if g.config.LastSequenceReceived == nil || g.config.SessionID == nil {
+			if !g.resumable() {
 				err = g.identify()
 			} else {
 				err = g.resume()
@@ -647,7 +773,10 @@ func (g *gatewayImpl) listen(conn transport, ready func(error)) {
 
 		case OpcodeDispatch:
 			// set last sequence received
+			g.sessionMu.Lock()
 			g.config.LastSequenceReceived = &message.S
+			g.sessionMu.Unlock()
+			g.metrics.incEvents(message.T)
 
 			eventData, ok := message.D.(EventData)
 			if !ok && message.D != nil {
@@ -656,15 +785,17 @@ func (g *gatewayImpl) listen(conn transport, ready func(error)) {
 			}
 
 			if readyEvent, ok := eventData.(EventReady); ok {
+				g.sessionMu.Lock()
 				g.config.SessionID = &readyEvent.SessionID
 				g.config.ResumeURL = &readyEvent.ResumeGatewayURL
-				g.config.Logger.Debug("successfully identified", slog.String("session_id", *g.config.SessionID))
+				g.sessionMu.Unlock()
+				g.config.Logger.Debug("successfully identified", slog.String("session_id", readyEvent.SessionID))
 				g.statusMu.Lock()
 				g.status = StatusReady
 				g.statusMu.Unlock()
 				ready(nil)
 			} else if _, ok = eventData.(EventResumed); ok {
-				g.config.Logger.Debug("successfully resumed", slog.String("session_id", *g.config.SessionID))
+				g.config.Logger.Debug("successfully resumed", slog.String("session_id", *g.SessionID()))
 				g.statusMu.Lock()
 				g.status = StatusReady
 				g.statusMu.Unlock()
@@ -714,9 +845,7 @@ func (g *gatewayImpl) listen(conn transport, ready func(error)) {
 				code = websocket.CloseServiceRestart
 			} else {
 				// clear resume info
-				g.config.SessionID = nil
-				g.config.LastSequenceReceived = nil
-				g.config.ResumeURL = nil
+				g.clearSession()
 			}
 
 			g.config.Logger.Warn("received invalid session", slog.Bool("can_resume", bool(canResume)))
@@ -737,11 +866,15 @@ func (g *gatewayImpl) listen(conn transport, ready func(error)) {
 
 		case OpcodeHeartbeatACK:
 			newHeartbeat := time.Now().UTC()
+			g.heartbeatMu.Lock()
+			lastHeartbeat := g.lastHeartbeatReceived
+			g.lastHeartbeatReceived = newHeartbeat
+			g.heartbeatMu.Unlock()
 			g.eventHandlerFunc(g, EventTypeHeartbeatAck, message.S, EventHeartbeatAck{
-				LastHeartbeat: g.lastHeartbeatReceived,
+				LastHeartbeat: lastHeartbeat,
 				NewHeartbeat:  newHeartbeat,
 			})
-			g.lastHeartbeatReceived = newHeartbeat
+			g.metrics.observeLatency(g.Latency())
 
 		default:
 			g.config.Logger.Debug("unknown opcode received", slog.Int("opcode", int(message.Op)), slog.String("data", fmt.Sprintf("%s", message.D)))
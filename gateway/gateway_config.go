@@ -4,6 +4,8 @@ import (
 	"log/slog"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/disgoorg/disgo/metrics"
 )
 
 func defaultConfig() config {
@@ -14,12 +16,14 @@ func defaultConfig() config {
 		Intents:             IntentsDefault,
 		Compress:            true,
 		Compression:         CompressionZstdStream,
+		Encoding:            EncodingJSON,
 		URL:                 "wss://gateway.discord.gg",
 		ShardID:             0,
 		ShardCount:          1,
 		AutoReconnect:       true,
 		EnableResumeURL:     true,
 		IdentifyRateLimiter: NewNoopIdentifyRateLimiter(),
+		Metrics:             metrics.Noop,
 	}
 }
 
@@ -38,6 +42,8 @@ type config struct {
 	Compress bool
 	// Compression is the compression type to use for the gateway. Defaults to ZstdCompression.
 	Compression CompressionType
+	// Encoding is the payload encoding to use for the gateway. Defaults to EncodingJSON.
+	Encoding Encoding
 	// URL is the URL of the Gateway. Defaults to fetch from Discord.
 	URL string
 	// ShardID is the shardID of the Gateway. Defaults to 0.
@@ -70,6 +76,10 @@ type config struct {
 	Browser string
 	// Device is the Device it should send on login. Defaults to "disgo".
 	Device string
+	// Metrics is the metrics.Metrics the Gateway records latency, reconnects & events to. Defaults to metrics.Noop.
+	Metrics metrics.Metrics
+	// SessionStore is the SessionStore the Gateway persists its Session to on Close and resumes it from on Open. Defaults to nil (no persistence).
+	SessionStore SessionStore
 }
 
 // ConfigOpt is a type alias for a function that takes a config and is used to configure your Server.
@@ -147,6 +157,14 @@ func WithCompression(compression CompressionType) ConfigOpt {
 	}
 }
 
+// WithEncoding sets the payload encoding to use.
+// See here for more information: https://discord.com/developers/docs/topics/gateway#encoding-and-compression
+func WithEncoding(encoding Encoding) ConfigOpt {
+	return func(config *config) {
+		config.Encoding = encoding
+	}
+}
+
 // WithURL sets the Gateway URL for the Gateway.
 func WithURL(url string) ConfigOpt {
 	return func(config *config) {
@@ -276,3 +294,18 @@ func WithDevice(device string) ConfigOpt {
 		config.Device = device
 	}
 }
+
+// WithMetrics sets the metrics.Metrics the Gateway records latency, reconnects & events to.
+func WithMetrics(m metrics.Metrics) ConfigOpt {
+	return func(config *config) {
+		config.Metrics = m
+	}
+}
+
+// WithSessionStore sets the SessionStore the Gateway persists its Session to on Close and resumes it from on Open.
+// With a SessionStore configured, Close keeps the session alive on Discord's side so it can be resumed after a restart.
+func WithSessionStore(sessionStore SessionStore) ConfigOpt {
+	return func(config *config) {
+		config.SessionStore = sessionStore
+	}
+}
@@ -0,0 +1,37 @@
+package gateway
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/disgoorg/disgo/metrics"
+)
+
+// gatewayMetrics are the metrics recorded by the Gateway.
+type gatewayMetrics struct {
+	shard      string
+	latency    metrics.Gauge
+	reconnects metrics.Counter
+	events     metrics.Counter
+}
+
+func newGatewayMetrics(m metrics.Metrics, shardID int) gatewayMetrics {
+	return gatewayMetrics{
+		shard:      strconv.Itoa(shardID),
+		latency:    m.Gauge("disgo_gateway_latency_seconds", "Time between the last heartbeat and its ACK.", "shard"),
+		reconnects: m.Counter("disgo_gateway_reconnects_total", "Total number of automatic gateway reconnects.", "shard"),
+		events:     m.Counter("disgo_gateway_events_total", "Total number of received gateway dispatch events by type.", "shard", "event"),
+	}
+}
+
+func (m gatewayMetrics) observeLatency(latency time.Duration) {
+	m.latency.Set(latency.Seconds(), m.shard)
+}
+
+func (m gatewayMetrics) incReconnects() {
+	m.reconnects.Add(1, m.shard)
+}
+
+func (m gatewayMetrics) incEvents(eventType EventType) {
+	m.events.Add(1, m.shard, string(eventType))
+}
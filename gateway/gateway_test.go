@@ -0,0 +1,175 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/gorilla/websocket"
+)
+
+type memorySessionStore struct {
+	mu       sync.Mutex
+	sessions map[[2]int]Session
+}
+
+func (s *memorySessionStore) Get(_ context.Context, shardID int, shardCount int) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[[2]int{shardID, shardCount}]
+	if !ok {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+func (s *memorySessionStore) Put(_ context.Context, shardID int, shardCount int, session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[[2]int{shardID, shardCount}] = session
+	return nil
+}
+
+func (s *memorySessionStore) Delete(_ context.Context, shardID int, shardCount int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, [2]int{shardID, shardCount})
+	return nil
+}
+
+// fakeGatewayConn is what the fakeGateway received on one connection.
+type fakeGatewayConn struct {
+	op        Opcode
+	data      json.RawMessage
+	closeCode int
+}
+
+// fakeGateway answers IDENTIFY with READY & RESUME with RESUMED and reports what it received on each connection.
+func fakeGateway(t *testing.T) (*httptest.Server, <-chan fakeGatewayConn) {
+	conns := make(chan fakeGatewayConn, 2)
+	var upgrader websocket.Upgrader
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("failed to upgrade connection: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		if err = conn.WriteJSON(map[string]any{"op": OpcodeHello, "d": map[string]any{"heartbeat_interval": 60000}}); err != nil {
+			t.Errorf("failed to write hello: %v", err)
+			return
+		}
+
+		var received fakeGatewayConn
+		var message struct {
+			Op Opcode          `json:"op"`
+			D  json.RawMessage `json:"d"`
+		}
+		if err = conn.ReadJSON(&message); err != nil {
+			t.Errorf("failed to read identify or resume: %v", err)
+			return
+		}
+		received.op = message.Op
+		received.data = message.D
+
+		switch message.Op {
+		case OpcodeIdentify:
+			err = conn.WriteJSON(map[string]any{"op": OpcodeDispatch, "t": EventTypeReady, "s": 5, "d": map[string]any{
+				"v":                  Version,
+				"user":               map[string]any{"id": "1", "username": "bot"},
+				"guilds":             []any{},
+				"session_id":         "session",
+				"resume_gateway_url": "ws://" + r.Host,
+				"application":        map[string]any{"id": "1", "flags": 0},
+			}})
+		case OpcodeResume:
+			err = conn.WriteJSON(map[string]any{"op": OpcodeDispatch, "t": EventTypeResumed, "s": 6, "d": nil})
+		}
+		if err != nil {
+			t.Errorf("failed to write ready or resumed: %v", err)
+			return
+		}
+
+		for {
+			if _, _, err = conn.ReadMessage(); err != nil {
+				var closeErr *websocket.CloseError
+				if errors.As(err, &closeErr) {
+					received.closeCode = closeErr.Code
+				}
+				conns <- received
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, conns
+}
+
+func TestGatewaySessionStore(t *testing.T) {
+	t.Parallel()
+
+	server, conns := fakeGateway(t)
+	store := &memorySessionStore{sessions: map[[2]int]Session{}}
+	newGateway := func() Gateway {
+		return New("token", func(Gateway, EventType, int, EventData) {}, nil,
+			WithURL("ws"+strings.TrimPrefix(server.URL, "http")),
+			WithCompression(CompressionNone),
+			WithEnableResumeURL(false),
+			WithShardID(0),
+			WithShardCount(1),
+			WithSessionStore(store),
+		)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	gateway := newGateway()
+	if err := gateway.Open(ctx); err != nil {
+		t.Fatalf("failed to open gateway: %v", err)
+	}
+	gateway.Close(ctx)
+
+	received := <-conns
+	if received.op != OpcodeIdentify {
+		t.Errorf("expected IDENTIFY without a stored session, got %d", received.op)
+	}
+	if received.closeCode != websocket.CloseServiceRestart {
+		t.Errorf("expected close code %d, got %d", websocket.CloseServiceRestart, received.closeCode)
+	}
+	session, _ := store.Get(ctx, 0, 1)
+	if session == nil || session.ID != "session" || session.Sequence != 5 {
+		t.Fatalf("expected session with sequence 5 to be stored, got %+v", session)
+	}
+
+	// a new Gateway simulates a restart of the process
+	gateway = newGateway()
+	if err := gateway.Open(ctx); err != nil {
+		t.Fatalf("failed to reopen gateway: %v", err)
+	}
+	if session, _ = store.Get(ctx, 0, 1); session != nil {
+		t.Errorf("expected loaded session to be removed from the store, got %+v", session)
+	}
+	gateway.Close(ctx)
+
+	received = <-conns
+	if received.op != OpcodeResume {
+		t.Fatalf("expected RESUME with a stored session, got %d", received.op)
+	}
+	var resume MessageDataResume
+	if err := json.Unmarshal(received.data, &resume); err != nil {
+		t.Fatalf("failed to unmarshal resume: %v", err)
+	}
+	if resume.SessionID != "session" || resume.Seq != 5 {
+		t.Errorf("expected resume of session with sequence 5, got %+v", resume)
+	}
+	if session, _ = store.Get(ctx, 0, 1); session == nil || session.Sequence != 6 {
+		t.Errorf("expected session with sequence 6 to be stored after the resume, got %+v", session)
+	}
+}
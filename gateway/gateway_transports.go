@@ -1,6 +1,7 @@
 package gateway
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
@@ -11,6 +12,8 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/klauspost/compress/zlib"
 	"github.com/klauspost/compress/zstd"
+
+	"github.com/disgoorg/disgo/internal/etf"
 )
 
 // CompressionType defines the compression mechanism to use for a gateway connection
@@ -40,19 +43,42 @@ func (t CompressionType) String() string {
 	return string(t)
 }
 
-func newTransport(typ CompressionType, conn *websocket.Conn, logger *slog.Logger) transport {
+// Encoding defines the payload encoding to use for a gateway connection
+type Encoding string
+
+const (
+	EncodingJSON Encoding = "json"
+	// EncodingETF uses the Erlang External Term Format, which makes payloads smaller than EncodingJSON.
+	// Received payloads are transcoded to json, so all discord types unmarshal unchanged.
+	// Snowflakes are sent by Discord as big integers and transcoded to json strings.
+	EncodingETF Encoding = "etf"
+)
+
+func (e Encoding) String() string {
+	if e == "" {
+		return string(EncodingJSON)
+	}
+	return string(e)
+}
+
+func newTransport(typ CompressionType, encoding Encoding, conn *websocket.Conn, logger *slog.Logger) transport {
+	base := baseTransport{
+		conn:     conn,
+		logger:   logger,
+		encoding: encoding,
+	}
 	switch typ {
 	case CompressionZlibStream:
-		return newZlibStreamTransport(conn, logger)
+		return newZlibStreamTransport(base)
 	case CompressionZstdStream:
-		return newZstdStreamTransport(conn, logger)
+		return newZstdStreamTransport(base)
 	default:
 		// zlibPayloadTransport supports both compressed (using zlib)
 		// and uncompressed payloads
 		//
 		// The identify payload will state whether (some) payloads
 		// will be compressed or not
-		return newZlibPayloadTransport(conn, logger)
+		return newZlibPayloadTransport(base)
 	}
 }
 
@@ -75,11 +101,16 @@ type transport interface {
 }
 
 type baseTransport struct {
-	conn   *websocket.Conn
-	logger *slog.Logger
+	conn     *websocket.Conn
+	logger   *slog.Logger
+	encoding Encoding
 }
 
 func (t *baseTransport) parseMessage(r io.Reader) (*Message, error) {
+	if t.encoding == EncodingETF {
+		return t.parseETFMessage(r)
+	}
+
 	if t.logger.Enabled(context.Background(), slog.LevelDebug) {
 		buff := new(bytes.Buffer)
 		r = io.TeeReader(r, buff)
@@ -102,6 +133,26 @@ func (t *baseTransport) parseMessage(r io.Reader) (*Message, error) {
 	return &message, nil
 }
 
+func (t *baseTransport) parseETFMessage(r io.Reader) (*Message, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = etf.ToJSON(data); err != nil {
+		t.logger.Error("error while decoding etf gateway message", slog.Any("err", err))
+		return nil, err
+	}
+	t.logger.Debug("received gateway message", slog.String("data", string(data)))
+
+	var message Message
+	if err = json.Unmarshal(data, &message); err != nil {
+		t.logger.Error("error while parsing gateway message", slog.Any("err", err))
+		return nil, err
+	}
+
+	return &message, nil
+}
+
 func (t *baseTransport) WriteMessage(message Message) error {
 	data, err := json.Marshal(message)
 	if err != nil {
@@ -109,6 +160,12 @@ func (t *baseTransport) WriteMessage(message Message) error {
 	}
 
 	t.logger.Debug("sending gateway message", slog.String("data", string(data)))
+	if t.encoding == EncodingETF {
+		if data, err = etf.FromJSON(data); err != nil {
+			return err
+		}
+		return t.conn.WriteMessage(websocket.BinaryMessage, data)
+	}
 	return t.conn.WriteMessage(websocket.TextMessage, data)
 }
 
@@ -155,13 +212,10 @@ type zstdStreamTransport struct {
 	buffer   *pipeBuffer
 }
 
-func newZstdStreamTransport(conn *websocket.Conn, logger *slog.Logger) *zstdStreamTransport {
+func newZstdStreamTransport(base baseTransport) *zstdStreamTransport {
 	return &zstdStreamTransport{
-		baseTransport: baseTransport{
-			conn:   conn,
-			logger: logger,
-		},
-		buffer: new(pipeBuffer),
+		baseTransport: base,
+		buffer:        new(pipeBuffer),
 	}
 }
 
@@ -205,13 +259,10 @@ type zlibStreamTransport struct {
 	buffer   *pipeBuffer
 }
 
-func newZlibStreamTransport(conn *websocket.Conn, logger *slog.Logger) *zlibStreamTransport {
+func newZlibStreamTransport(base baseTransport) *zlibStreamTransport {
 	return &zlibStreamTransport{
-		baseTransport: baseTransport{
-			conn:   conn,
-			logger: logger,
-		},
-		buffer: new(pipeBuffer),
+		baseTransport: base,
+		buffer:        new(pipeBuffer),
 	}
 }
 
@@ -267,12 +318,9 @@ type zlibPayloadTransport struct {
 	baseTransport
 }
 
-func newZlibPayloadTransport(conn *websocket.Conn, logger *slog.Logger) *zlibPayloadTransport {
+func newZlibPayloadTransport(base baseTransport) *zlibPayloadTransport {
 	return &zlibPayloadTransport{
-		baseTransport: baseTransport{
-			conn:   conn,
-			logger: logger,
-		},
+		baseTransport: base,
 	}
 }
 
@@ -282,6 +330,15 @@ func (t *zlibPayloadTransport) ReceiveMessage() (*Message, error) {
 		return nil, err
 	}
 
+	if mt == websocket.BinaryMessage && t.encoding == EncodingETF {
+		// etf payloads are always binary, only decompress them if they don't start with the etf version
+		br := bufio.NewReader(r)
+		if b, err := br.Peek(1); err == nil && b[0] == etf.Version {
+			return t.parseMessage(br)
+		}
+		r = br
+	}
+
 	if mt == websocket.BinaryMessage {
 		reader, err := zlib.NewReader(r)
 		if err != nil {
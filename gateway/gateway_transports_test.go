@@ -0,0 +1,85 @@
+package gateway
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/internal/etf"
+)
+
+func TestParseETFMessage_Presence(t *testing.T) {
+	// snowflakes & timestamps are encoded as big integers, like Discord sends them
+	data, err := etf.FromJSON([]byte(`{"op":0,"s":3,"t":"PRESENCE_UPDATE","d":{
+		"user":{"id":1234567891011121314},
+		"guild_id":81384788765712384,
+		"status":"online",
+		"activities":[{"name":"disgo","type":0,"created_at":1700000000123,"timestamps":{"start":1700000000000,"end":1700003600000}}],
+		"client_status":{"desktop":"online"}
+	}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport := &baseTransport{logger: slog.New(slog.DiscardHandler), encoding: EncodingETF}
+	message, err := transport.parseMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+
+	presence, ok := message.D.(EventPresenceUpdate)
+	if !ok {
+		t.Fatalf("expected EventPresenceUpdate, got %T", message.D)
+	}
+	if presence.PresenceUser.ID != snowflake.ID(1234567891011121314) || presence.GuildID != snowflake.ID(81384788765712384) {
+		t.Errorf("unexpected user or guild id %d, %d", presence.PresenceUser.ID, presence.GuildID)
+	}
+	if len(presence.Activities) != 1 {
+		t.Fatalf("expected 1 activity, got %d", len(presence.Activities))
+	}
+	activity := presence.Activities[0]
+	if !activity.CreatedAt.Equal(time.UnixMilli(1700000000123)) {
+		t.Errorf("unexpected created_at %s", activity.CreatedAt)
+	}
+	if activity.Timestamps == nil || !activity.Timestamps.Start.Equal(time.UnixMilli(1700000000000)) || !activity.Timestamps.End.Equal(time.UnixMilli(1700003600000)) {
+		t.Errorf("unexpected timestamps %+v", activity.Timestamps)
+	}
+}
+
+func BenchmarkParseMessage(b *testing.B) {
+	jsonData := []byte(`{"op":0,"s":42,"t":"MESSAGE_CREATE","d":{
+		"id":"1234567891011121314","channel_id":"81384788765712385","guild_id":"81384788765712384",
+		"author":{"id":"1234567891011121315","username":"user","global_name":"User","avatar":"a_1269e74af4df7417b13759eae50c83dc","discriminator":"0","public_flags":64},
+		"member":{"roles":["81384788765712386","81384788765712387"],"joined_at":"2017-03-13T19:19:14.040000+00:00","deaf":false,"mute":false,"flags":0},
+		"content":"hello world, this is a message with some content","timestamp":"2024-01-01T00:00:00.000000+00:00","edited_timestamp":null,
+		"tts":false,"mention_everyone":false,"mentions":[],"mention_roles":[],"attachments":[],"embeds":[],"pinned":false,"type":0,"flags":0,"nonce":"1234567891011121316"
+	}}`)
+	// Discord sends snowflakes as big integers
+	etfData, err := etf.FromJSON([]byte(strings.NewReplacer(`"1234567891011121314"`, `1234567891011121314`, `"81384788765712385"`, `81384788765712385`, `"81384788765712384"`, `81384788765712384`, `"1234567891011121315"`, `1234567891011121315`).Replace(string(jsonData))))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		encoding Encoding
+		data     []byte
+	}{
+		{encoding: EncodingJSON, data: jsonData},
+		{encoding: EncodingETF, data: etfData},
+	} {
+		b.Run(tt.encoding.String(), func(b *testing.B) {
+			transport := &baseTransport{logger: slog.New(slog.DiscardHandler), encoding: tt.encoding}
+			b.SetBytes(int64(len(tt.data)))
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := transport.parseMessage(bytes.NewReader(tt.data)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
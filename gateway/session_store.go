@@ -0,0 +1,115 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/disgoorg/json/v2"
+)
+
+// Session is the state needed to resume a Gateway session.
+type Session struct {
+	// ID is the session ID received in the EventReady.
+	ID string `json:"id"`
+	// ResumeURL is the resume url received in the EventReady.
+	ResumeURL string `json:"resume_url"`
+	// Sequence is the last sequence received.
+	Sequence int `json:"sequence"`
+}
+
+// SessionStore persists the Session of a Gateway, so it can be resumed after a process restart.
+// The Gateway writes its Session on Close and reads it on Open.
+// Sessions are keyed by shard ID & shard count, as a session can't be resumed with a different shard count.
+type SessionStore interface {
+	// Get returns the Session for the given shard or nil if there is none.
+	Get(ctx context.Context, shardID int, shardCount int) (*Session, error)
+
+	// Put stores the Session for the given shard.
+	Put(ctx context.Context, shardID int, shardCount int, session Session) error
+
+	// Delete removes the Session for the given shard.
+	Delete(ctx context.Context, shardID int, shardCount int) error
+}
+
+var _ SessionStore = (*fileSessionStore)(nil)
+
+// NewFileSessionStore returns a SessionStore which stores each Session as a json file in the given directory.
+// The directory is created if it does not exist.
+func NewFileSessionStore(dir string) SessionStore {
+	return &fileSessionStore{
+		dir: dir,
+	}
+}
+
+type fileSessionStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func (s *fileSessionStore) path(shardID int, shardCount int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("session_%d_%d.json", shardID, shardCount))
+}
+
+func (s *fileSessionStore) Get(_ context.Context, shardID int, shardCount int) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(shardID, shardCount))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var session Session
+	if err = json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to decode session: %w", err)
+	}
+	return &session, nil
+}
+
+func (s *fileSessionStore) Put(_ context.Context, shardID int, shardCount int, session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so we never leave a partially written session behind
+	f, err := os.CreateTemp(s.dir, "session_*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return err
+	}
+	if err = f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), s.path(shardID, shardCount))
+}
+
+func (s *fileSessionStore) Delete(_ context.Context, shardID int, shardCount int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(shardID, shardCount)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
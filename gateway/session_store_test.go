@@ -0,0 +1,48 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFileSessionStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewFileSessionStore(t.TempDir())
+
+	session, err := store.Get(ctx, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session != nil {
+		t.Fatalf("expected no session, got %+v", session)
+	}
+
+	want := Session{ID: "abc", ResumeURL: "wss://gateway-us-east1-b.discord.gg", Sequence: 42}
+	if err = store.Put(ctx, 1, 2, want); err != nil {
+		t.Fatal(err)
+	}
+
+	if session, err = store.Get(ctx, 1, 4); err != nil || session != nil {
+		t.Fatalf("expected no session for different shard count, got %+v, %v", session, err)
+	}
+
+	session, err = store.Get(ctx, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session == nil || *session != want {
+		t.Fatalf("expected session %+v, got %+v", want, session)
+	}
+
+	if err = store.Delete(ctx, 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if session, err = store.Get(ctx, 1, 2); err != nil || session != nil {
+		t.Fatalf("expected session to be deleted, got %+v, %v", session, err)
+	}
+	if err = store.Delete(ctx, 1, 2); err != nil {
+		t.Fatalf("expected deleting a missing session to succeed, got %v", err)
+	}
+}
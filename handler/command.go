@@ -7,6 +7,7 @@ import (
 
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/i18n"
 	"github.com/disgoorg/disgo/rest"
 )
 
@@ -15,32 +16,39 @@ type CommandEvent struct {
 	*events.ApplicationCommandInteractionCreate
 	Vars map[string]string
 	Ctx  context.Context
+	// Catalog is the i18n.Catalog set via Mux.Catalog, which is used by T.
+	Catalog *i18n.Catalog
+}
+
+// T translates the message with the key using the Catalog, see InteractionEvent.T.
+func (e *CommandEvent) T(key string, args ...any) string {
+	return translate(e.Catalog, e, key, args)
 }
 
 func (e *CommandEvent) GetInteractionResponse(opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest.GetInteractionResponse(e.ApplicationID(), e.Token(), opts...)
+	return e.Client().Rest.GetInteractionResponse(e.ApplicationID(), e.Token(), withCtx(e.Ctx, opts)...)
 }
 
 func (e *CommandEvent) UpdateInteractionResponse(messageUpdate discord.MessageUpdate, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest.UpdateInteractionResponse(e.ApplicationID(), e.Token(), messageUpdate, opts...)
+	return e.Client().Rest.UpdateInteractionResponse(e.ApplicationID(), e.Token(), messageUpdate, withCtx(e.Ctx, opts)...)
 }
 
 func (e *CommandEvent) DeleteInteractionResponse(opts ...rest.RequestOpt) error {
-	return e.Client().Rest.DeleteInteractionResponse(e.ApplicationID(), e.Token(), opts...)
+	return e.Client().Rest.DeleteInteractionResponse(e.ApplicationID(), e.Token(), withCtx(e.Ctx, opts)...)
 }
 
 func (e *CommandEvent) GetFollowupMessage(messageID snowflake.ID, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest.GetFollowupMessage(e.ApplicationID(), e.Token(), messageID, opts...)
+	return e.Client().Rest.GetFollowupMessage(e.ApplicationID(), e.Token(), messageID, withCtx(e.Ctx, opts)...)
 }
 
 func (e *CommandEvent) CreateFollowupMessage(messageCreate discord.MessageCreate, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest.CreateFollowupMessage(e.ApplicationID(), e.Token(), messageCreate, opts...)
+	return e.Client().Rest.CreateFollowupMessage(e.ApplicationID(), e.Token(), messageCreate, withCtx(e.Ctx, opts)...)
 }
 
 func (e *CommandEvent) UpdateFollowupMessage(messageID snowflake.ID, messageUpdate discord.MessageUpdate, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest.UpdateFollowupMessage(e.ApplicationID(), e.Token(), messageID, messageUpdate, opts...)
+	return e.Client().Rest.UpdateFollowupMessage(e.ApplicationID(), e.Token(), messageID, messageUpdate, withCtx(e.Ctx, opts)...)
 }
 
 func (e *CommandEvent) DeleteFollowupMessage(messageID snowflake.ID, opts ...rest.RequestOpt) error {
-	return e.Client().Rest.DeleteFollowupMessage(e.ApplicationID(), e.Token(), messageID, opts...)
+	return e.Client().Rest.DeleteFollowupMessage(e.ApplicationID(), e.Token(), messageID, withCtx(e.Ctx, opts)...)
 }
@@ -0,0 +1,429 @@
+package handler
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/omit"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/i18n"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// CommandSyncPlan contains the changes needed to get from the current commands of an application to the given ones.
+// It is created by PlanSyncCommands & applied by CommandSyncPlan.Apply or SyncCommandsDiff.
+type CommandSyncPlan struct {
+	// GuildID is the guild the plan is for or nil for global commands.
+	GuildID *snowflake.ID
+	// Create are the commands which don't exist yet.
+	Create []discord.ApplicationCommandCreate
+	// Update are the existing commands which differ from the given ones.
+	Update []CommandSyncUpdate
+	// Delete are the existing commands which are not part of the given ones.
+	Delete []CommandSyncDelete
+	// Unchanged is the number of existing commands which are equal to the given ones.
+	Unchanged int
+}
+
+// CommandSyncUpdate is a command which is updated by a CommandSyncPlan.
+type CommandSyncUpdate struct {
+	ID      snowflake.ID
+	Command discord.ApplicationCommandCreate
+	// Changes are the json names of the changed fields, like "options" or "name_localizations".
+	Changes []string
+}
+
+// CommandSyncDelete is a command which is deleted by a CommandSyncPlan.
+type CommandSyncDelete struct {
+	ID   snowflake.ID
+	Type discord.ApplicationCommandType
+	Name string
+}
+
+// Empty returns whether the plan doesn't change anything.
+func (p CommandSyncPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// String returns a human-readable summary of the plan, which is useful for dry runs.
+func (p CommandSyncPlan) String() string {
+	var b strings.Builder
+	if p.GuildID == nil {
+		b.WriteString("global commands:")
+	} else {
+		fmt.Fprintf(&b, "guild %s commands:", *p.GuildID)
+	}
+	for _, del := range p.Delete {
+		fmt.Fprintf(&b, "\n  - delete %s (%s)", commandSyncName(del.Type, del.Name), del.ID)
+	}
+	for _, update := range p.Update {
+		fmt.Fprintf(&b, "\n  ~ update %s (%s): %s", commandSyncName(update.Command.Type(), update.Command.CommandName()), update.ID, strings.Join(update.Changes, ", "))
+	}
+	for _, command := range p.Create {
+		fmt.Fprintf(&b, "\n  + create %s", commandSyncName(command.Type(), command.CommandName()))
+	}
+	fmt.Fprintf(&b, "\n  %d unchanged", p.Unchanged)
+	return b.String()
+}
+
+// Apply performs the delete, update & create requests of the plan in this order. It returns on the first error.
+// Deleting first frees up slots, so renaming a command doesn't fail when the application is at Discord's command limit.
+func (p CommandSyncPlan) Apply(client *bot.Client, opts ...rest.RequestOpt) error {
+	for _, del := range p.Delete {
+		var err error
+		if p.GuildID == nil {
+			err = client.Rest.DeleteGlobalCommand(client.ApplicationID, del.ID, opts...)
+		} else {
+			err = client.Rest.DeleteGuildCommand(client.ApplicationID, *p.GuildID, del.ID, opts...)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to delete command %s: %w", del.Name, err)
+		}
+	}
+	for _, update := range p.Update {
+		commandUpdate := toCommandUpdate(update.Command)
+		var err error
+		if p.GuildID == nil {
+			_, err = client.Rest.UpdateGlobalCommand(client.ApplicationID, update.ID, commandUpdate, opts...)
+		} else {
+			_, err = client.Rest.UpdateGuildCommand(client.ApplicationID, *p.GuildID, update.ID, commandUpdate, opts...)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update command %s: %w", update.Command.CommandName(), err)
+		}
+	}
+	for _, command := range p.Create {
+		var err error
+		if p.GuildID == nil {
+			_, err = client.Rest.CreateGlobalCommand(client.ApplicationID, command, opts...)
+		} else {
+			_, err = client.Rest.CreateGuildCommand(client.ApplicationID, *p.GuildID, command, opts...)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create command %s: %w", command.CommandName(), err)
+		}
+	}
+	return nil
+}
+
+// SyncCommandsDiff works like SyncCommands, but instead of overwriting all commands, it only creates, updates & deletes the commands which changed.
+// This keeps the ids of unchanged commands & avoids unnecessary requests. It returns the applied plans and returns on the first error for multiple guilds.
+// Use PlanSyncCommands for a dry run.
+//
+// Like SyncCommands, it doesn't localize the commands. Use SyncLocalizedCommandsDiff for commands localized with an i18n.Catalog,
+// otherwise the localizations of the existing commands are detected as changes & removed.
+func SyncCommandsDiff(client *bot.Client, commands []discord.ApplicationCommandCreate, guildIDs []snowflake.ID, opts ...rest.RequestOpt) ([]CommandSyncPlan, error) {
+	plans, err := PlanSyncCommands(client, commands, guildIDs, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for i, plan := range plans {
+		if err = plan.Apply(client, opts...); err != nil {
+			return plans[:i], err
+		}
+	}
+	return plans, nil
+}
+
+// SyncLocalizedCommandsDiff works like SyncCommandsDiff, but localizes the commands with i18n.Catalog.LocalizeCommands before planning the changes.
+func SyncLocalizedCommandsDiff(client *bot.Client, catalog *i18n.Catalog, commands []discord.ApplicationCommandCreate, guildIDs []snowflake.ID, opts ...rest.RequestOpt) ([]CommandSyncPlan, error) {
+	return SyncCommandsDiff(client, catalog.LocalizeCommands(commands), guildIDs, opts...)
+}
+
+// PlanSyncCommands fetches the current commands for the given guilds or globally if no guildIDs are given and returns the CommandSyncPlan for each of them without applying it.
+//
+// Commands are matched by type & name and compared by their name, description, localizations, options, default member permissions, contexts, integration types, nsfw flag & entry point handler.
+// If a command doesn't set its contexts or integration types, Discord fills in defaults, so they are not compared.
+func PlanSyncCommands(client *bot.Client, commands []discord.ApplicationCommandCreate, guildIDs []snowflake.ID, opts ...rest.RequestOpt) ([]CommandSyncPlan, error) {
+	if len(guildIDs) == 0 {
+		plan, err := planSyncCommands(client, commands, nil, opts)
+		if err != nil {
+			return nil, err
+		}
+		return []CommandSyncPlan{plan}, nil
+	}
+
+	plans := make([]CommandSyncPlan, 0, len(guildIDs))
+	for _, guildID := range guildIDs {
+		plan, err := planSyncCommands(client, commands, &guildID, opts)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+type commandSyncKey struct {
+	t    discord.ApplicationCommandType
+	name string
+}
+
+type rawCommand struct {
+	ID   snowflake.ID                   `json:"id"`
+	Type discord.ApplicationCommandType `json:"type"`
+	Name string                         `json:"name"`
+	Data json.RawMessage                `json:"-"`
+}
+
+func planSyncCommands(client *bot.Client, commands []discord.ApplicationCommandCreate, guildID *snowflake.ID, opts []rest.RequestOpt) (CommandSyncPlan, error) {
+	plan := CommandSyncPlan{GuildID: guildID}
+
+	// the commands are fetched raw, as discord.ApplicationCommand can't tell a null from a "0" default_member_permissions
+	var (
+		endpoint *rest.CompiledEndpoint
+		raws     []json.RawMessage
+	)
+	query := discord.QueryValues{"with_localizations": true}
+	if guildID == nil {
+		endpoint = rest.GetGlobalCommands.Compile(query, client.ApplicationID)
+	} else {
+		endpoint = rest.GetGuildCommands.Compile(query, client.ApplicationID, *guildID)
+	}
+	if err := client.Rest.Do(endpoint, nil, &raws, opts...); err != nil {
+		return plan, err
+	}
+
+	existing := make(map[commandSyncKey]rawCommand, len(raws))
+	for _, raw := range raws {
+		var command rawCommand
+		if err := json.Unmarshal(raw, &command); err != nil {
+			return plan, err
+		}
+		if command.Type == 0 {
+			command.Type = discord.ApplicationCommandTypeSlash
+		}
+		command.Data = raw
+		existing[commandSyncKey{t: command.Type, name: command.Name}] = command
+	}
+
+	for _, command := range commands {
+		key := commandSyncKey{t: command.Type(), name: command.CommandName()}
+		current, ok := existing[key]
+		if !ok {
+			plan.Create = append(plan.Create, command)
+			continue
+		}
+		delete(existing, key)
+
+		changes, err := diffCommand(current.Data, command)
+		if err != nil {
+			return plan, fmt.Errorf("failed to diff command %s: %w", command.CommandName(), err)
+		}
+		if len(changes) == 0 {
+			plan.Unchanged++
+			continue
+		}
+		plan.Update = append(plan.Update, CommandSyncUpdate{
+			ID:      current.ID,
+			Command: command,
+			Changes: changes,
+		})
+	}
+
+	for _, command := range existing {
+		plan.Delete = append(plan.Delete, CommandSyncDelete{
+			ID:   command.ID,
+			Type: command.Type,
+			Name: command.Name,
+		})
+	}
+	slices.SortFunc(plan.Delete, func(a CommandSyncDelete, b CommandSyncDelete) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return plan, nil
+}
+
+// commandSyncFields are the fields of a command which are compared.
+var commandSyncFields = []string{
+	"type",
+	"name",
+	"name_localizations",
+	"description",
+	"description_localizations",
+	"options",
+	"default_member_permissions",
+	"integration_types",
+	"contexts",
+	"nsfw",
+	"handler",
+}
+
+// diffCommand returns the names of the fields which differ between the current command & the given one.
+func diffCommand(current json.RawMessage, command discord.ApplicationCommandCreate) ([]string, error) {
+	currentFields, err := normalizeCommand(current)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(command)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := normalizeCommand(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Discord defaults these when they are not set, so only compare them when they are set
+	for _, name := range []string{"integration_types", "contexts"} {
+		if _, ok := fields[name]; !ok {
+			delete(currentFields, name)
+		}
+	}
+
+	var changes []string
+	for _, name := range commandSyncFields {
+		if !reflect.DeepEqual(currentFields[name], fields[name]) {
+			changes = append(changes, name)
+		}
+	}
+	return changes, nil
+}
+
+func normalizeCommand(data []byte) (map[string]any, error) {
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name := range fields {
+		if !slices.Contains(commandSyncFields, name) {
+			delete(fields, name)
+		}
+	}
+	if _, ok := fields["type"]; !ok {
+		fields["type"] = float64(discord.ApplicationCommandTypeSlash)
+	}
+	return normalizeValue(fields).(map[string]any), nil
+}
+
+// normalizeValue removes all values which Discord treats as unset, like null, false, "", [] & {}, as well as the localized names & descriptions.
+func normalizeValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if key == "name_localized" || key == "description_localized" {
+				delete(v, key)
+				continue
+			}
+			if value = normalizeValue(value); value == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = value
+		}
+		if len(v) == 0 {
+			return nil
+		}
+		return v
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+		for i, value := range v {
+			v[i] = normalizeValue(value)
+		}
+		return v
+	case string:
+		if v == "" {
+			return nil
+		}
+	case bool:
+		if !v {
+			return nil
+		}
+	}
+	return v
+}
+
+// toCommandUpdate converts the command to an update which replaces all compared fields of the existing command.
+func toCommandUpdate(command discord.ApplicationCommandCreate) discord.ApplicationCommandUpdate {
+	switch c := command.(type) {
+	case discord.SlashCommandCreate:
+		options := c.Options
+		if options == nil {
+			options = []discord.ApplicationCommandOption{}
+		}
+		return discord.SlashCommandUpdate{
+			Name:                     &c.Name,
+			NameLocalizations:        &c.NameLocalizations,
+			Description:              &c.Description,
+			DescriptionLocalizations: &c.DescriptionLocalizations,
+			Options:                  &options,
+			DefaultMemberPermissions: updateDefaultMemberPermissions(c.DefaultMemberPermissions),
+			IntegrationTypes:         updateSlice(c.IntegrationTypes),
+			Contexts:                 updateSlice(c.Contexts),
+			NSFW:                     updateNSFW(c.NSFW),
+		}
+	case discord.UserCommandCreate:
+		return discord.UserCommandUpdate{
+			Name:                     &c.Name,
+			NameLocalizations:        &c.NameLocalizations,
+			DefaultMemberPermissions: updateDefaultMemberPermissions(c.DefaultMemberPermissions),
+			IntegrationTypes:         updateSlice(c.IntegrationTypes),
+			Contexts:                 updateSlice(c.Contexts),
+			NSFW:                     updateNSFW(c.NSFW),
+		}
+	case discord.MessageCommandCreate:
+		return discord.MessageCommandUpdate{
+			Name:                     &c.Name,
+			NameLocalizations:        &c.NameLocalizations,
+			DefaultMemberPermissions: updateDefaultMemberPermissions(c.DefaultMemberPermissions),
+			IntegrationTypes:         updateSlice(c.IntegrationTypes),
+			Contexts:                 updateSlice(c.Contexts),
+			NSFW:                     updateNSFW(c.NSFW),
+		}
+	case discord.EntryPointCommandCreate:
+		var handler *discord.EntryPointCommandHandlerType
+		if c.Handler != 0 {
+			handler = &c.Handler
+		}
+		return discord.EntryPointCommandUpdate{
+			Name:                     &c.Name,
+			NameLocalizations:        &c.NameLocalizations,
+			DefaultMemberPermissions: updateDefaultMemberPermissions(c.DefaultMemberPermissions),
+			IntegrationTypes:         updateSlice(c.IntegrationTypes),
+			Contexts:                 updateSlice(c.Contexts),
+			NSFW:                     updateNSFW(c.NSFW),
+			Handler:                  handler,
+		}
+	default:
+		panic(fmt.Sprintf("unknown application command create type %T", command))
+	}
+}
+
+// updateDefaultMemberPermissions resets the default member permissions if the command doesn't set them, as they would stay unchanged otherwise.
+func updateDefaultMemberPermissions(permissions omit.Omit[*discord.Permissions]) omit.Omit[*discord.Permissions] {
+	if !permissions.OK {
+		return omit.NewNilPtr[discord.Permissions]()
+	}
+	return permissions
+}
+
+// updateSlice keeps unset slices unchanged, as Discord defaults them.
+func updateSlice[T any](s []T) *[]T {
+	if len(s) == 0 {
+		return nil
+	}
+	return &s
+}
+
+func updateNSFW(nsfw *bool) *bool {
+	if nsfw == nil {
+		return new(bool)
+	}
+	return nsfw
+}
+
+func commandSyncName(t discord.ApplicationCommandType, name string) string {
+	if t == discord.ApplicationCommandTypeSlash {
+		return "/" + name
+	}
+	return fmt.Sprintf("%s (type %d)", name, t)
+}
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/omit"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/i18n"
+	"github.com/disgoorg/disgo/rest"
+)
+
+const currentCommands = `[
+	{"id": "1", "application_id": "100", "version": "1", "type": 1, "name": "ping", "description": "Ping the bot", "name_localizations": null, "description_localizations": null, "default_member_permissions": null, "dm_permission": true, "nsfw": false, "integration_types": [0], "contexts": null, "options": [{"type": 3, "name": "message", "description": "The message", "required": false, "name_localizations": null}]},
+	{"id": "2", "application_id": "100", "version": "1", "type": 1, "name": "ban", "description": "Ban a user", "default_member_permissions": null, "integration_types": [0]},
+	{"id": "3", "application_id": "100", "version": "1", "type": 2, "name": "Info", "description": "", "default_member_permissions": "0", "integration_types": [0]},
+	{"id": "4", "application_id": "100", "version": "1", "type": 1, "name": "old", "description": "Old command", "integration_types": [0]}
+]`
+
+func TestPlanSyncCommands(t *testing.T) {
+	var requests []string
+	mock := func(next rest.RequestHandler) rest.RequestHandler {
+		return func(rq *rest.Request) (*rest.Response, error) {
+			requests = append(requests, rq.Endpoint.Endpoint.Method+" "+rq.Endpoint.URL)
+			response := &rest.Response{Response: &http.Response{StatusCode: http.StatusOK, Status: "200 OK"}}
+			switch rq.Endpoint.Endpoint {
+			case rest.GetGlobalCommands:
+				response.RawBody = []byte(currentCommands)
+			case rest.DeleteGlobalCommand:
+				response.Response.StatusCode = http.StatusNoContent
+			default:
+				response.RawBody = []byte(`{"id": "5", "type": 1, "name": "new"}`)
+			}
+			return response, nil
+		}
+	}
+	client := &bot.Client{
+		ApplicationID: 100,
+		Rest:          rest.New(rest.NewClient("", rest.WithURL(""), rest.WithMiddlewares(mock))),
+	}
+
+	commands := []discord.ApplicationCommandCreate{
+		discord.SlashCommandCreate{
+			Name:        "ping",
+			Description: "Ping the bot",
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionString{Name: "message", Description: "The message"},
+			},
+		},
+		discord.SlashCommandCreate{
+			Name:                     "ban",
+			Description:              "Ban a user",
+			DefaultMemberPermissions: omit.NewPtr(discord.PermissionBanMembers),
+		},
+		discord.UserCommandCreate{
+			Name:                     "Info",
+			DefaultMemberPermissions: omit.NewPtr(discord.PermissionsNone),
+		},
+		discord.SlashCommandCreate{
+			Name:        "new",
+			Description: "New command",
+		},
+	}
+
+	plans, err := PlanSyncCommands(client, commands, nil)
+	if err != nil {
+		t.Fatalf("failed to plan command sync: %v", err)
+	}
+	if len(plans) != 1 {
+		t.Fatalf("expected 1 plan, got %d", len(plans))
+	}
+	plan := plans[0]
+
+	if len(plan.Create) != 1 || plan.Create[0].CommandName() != "new" {
+		t.Errorf("expected /new to be created, got %v", plan.Create)
+	}
+	if len(plan.Update) != 1 || plan.Update[0].ID != snowflake.ID(2) || !slices.Equal(plan.Update[0].Changes, []string{"default_member_permissions"}) {
+		t.Errorf("expected default member permissions of /ban to be updated, got %+v", plan.Update)
+	}
+	if len(plan.Delete) != 1 || plan.Delete[0].ID != snowflake.ID(4) {
+		t.Errorf("expected /old to be deleted, got %+v", plan.Delete)
+	}
+	if plan.Unchanged != 2 {
+		t.Errorf("expected 2 unchanged commands, got %d", plan.Unchanged)
+	}
+	if len(requests) != 1 {
+		t.Errorf("expected only the commands to be fetched, got %v", requests)
+	}
+
+	requests = nil
+	if err = plan.Apply(client); err != nil {
+		t.Fatalf("failed to apply command sync: %v", err)
+	}
+	expected := []string{
+		"DELETE /applications/100/commands/4",
+		"PATCH /applications/100/commands/2",
+		"POST /applications/100/commands",
+	}
+	if !slices.Equal(requests, expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestSyncCommandsDiffRenameAtLimit(t *testing.T) {
+	const limit = 100
+
+	// the mock behaves like Discord and rejects new commands once the application has 100 of them
+	commandIDs := map[string]string{}
+	current := make([]string, 0, limit)
+	for i := range limit {
+		id := strconv.Itoa(i + 1)
+		commandIDs[id] = fmt.Sprintf("command-%d", i)
+		current = append(current, fmt.Sprintf(`{"id": "%s", "type": 1, "name": "command-%d", "description": "Command"}`, id, i))
+	}
+	mock := func(next rest.RequestHandler) rest.RequestHandler {
+		return func(rq *rest.Request) (*rest.Response, error) {
+			response := &rest.Response{Response: &http.Response{StatusCode: http.StatusOK, Status: "200 OK"}}
+			switch rq.Endpoint.Endpoint {
+			case rest.GetGlobalCommands:
+				response.RawBody = []byte("[" + strings.Join(current, ",") + "]")
+			case rest.DeleteGlobalCommand:
+				delete(commandIDs, path.Base(rq.Endpoint.URL))
+				response.Response.StatusCode = http.StatusNoContent
+			case rest.CreateGlobalCommand:
+				if len(commandIDs) >= limit {
+					response.Response.StatusCode = http.StatusBadRequest
+					response.Response.Status = "400 Bad Request"
+					response.RawBody = []byte(`{"code": 30032, "message": "Maximum number of application commands reached (100)"}`)
+					break
+				}
+				commandIDs["1000"] = "renamed"
+				response.RawBody = []byte(`{"id": "1000", "type": 1, "name": "renamed"}`)
+			}
+			return response, nil
+		}
+	}
+	client := &bot.Client{
+		ApplicationID: 100,
+		Rest:          rest.New(rest.NewClient("", rest.WithURL(""), rest.WithMiddlewares(mock))),
+	}
+
+	// command-0 is renamed, which deletes it & creates a new command
+	commands := make([]discord.ApplicationCommandCreate, 0, limit)
+	commands = append(commands, discord.SlashCommandCreate{Name: "renamed", Description: "Command"})
+	for i := 1; i < limit; i++ {
+		commands = append(commands, discord.SlashCommandCreate{Name: fmt.Sprintf("command-%d", i), Description: "Command"})
+	}
+
+	plans, err := SyncCommandsDiff(client, commands, nil)
+	if err != nil {
+		t.Fatalf("failed to sync commands: %v", err)
+	}
+	if len(plans) != 1 || len(plans[0].Create) != 1 || len(plans[0].Delete) != 1 || plans[0].Unchanged != limit-1 {
+		t.Fatalf("expected command-0 to be deleted & /renamed to be created, got %s", plans)
+	}
+	if _, ok := commandIDs["1"]; ok {
+		t.Error("expected command-0 to be deleted")
+	}
+	if commandIDs["1000"] != "renamed" || len(commandIDs) != limit {
+		t.Errorf("expected /renamed to be created, got %d commands", len(commandIDs))
+	}
+}
+
+func TestPlanSyncCommandsLocalized(t *testing.T) {
+	mock := func(next rest.RequestHandler) rest.RequestHandler {
+		return func(rq *rest.Request) (*rest.Response, error) {
+			return &rest.Response{
+				Response: &http.Response{StatusCode: http.StatusOK, Status: "200 OK"},
+				RawBody:  []byte(`[{"id": "1", "type": 1, "name": "purge", "description": "Delete messages", "name_localizations": {"de": "löschen"}, "description_localizations": {"de": "Nachrichten löschen"}}]`),
+			}, nil
+		}
+	}
+	client := &bot.Client{
+		ApplicationID: 100,
+		Rest:          rest.New(rest.NewClient("", rest.WithURL(""), rest.WithMiddlewares(mock))),
+	}
+
+	catalog := i18n.New(discord.LocaleEnglishUS)
+	if err := catalog.Add(discord.LocaleGerman, map[string]any{
+		"commands": map[string]any{"purge": map[string]any{"name": "löschen", "description": "Nachrichten löschen"}},
+	}); err != nil {
+		t.Fatalf("failed to add messages: %v", err)
+	}
+	commands := []discord.ApplicationCommandCreate{
+		discord.SlashCommandCreate{Name: "purge", Description: "Delete messages"},
+	}
+
+	plans, err := PlanSyncCommands(client, catalog.LocalizeCommands(commands), nil)
+	if err != nil {
+		t.Fatalf("failed to plan command sync: %v", err)
+	}
+	if !plans[0].Empty() {
+		t.Errorf("expected localized commands to be unchanged, got %s", plans[0])
+	}
+
+	plans, err = SyncLocalizedCommandsDiff(client, catalog, commands, nil)
+	if err != nil {
+		t.Fatalf("failed to sync commands: %v", err)
+	}
+	if !plans[0].Empty() {
+		t.Errorf("expected commands localized while syncing to be unchanged, got %s", plans[0])
+	}
+
+	plans, err = PlanSyncCommands(client, commands, nil)
+	if err != nil {
+		t.Fatalf("failed to plan command sync: %v", err)
+	}
+	if len(plans[0].Update) != 1 || !slices.Equal(plans[0].Update[0].Changes, []string{"name_localizations", "description_localizations"}) {
+		t.Errorf("expected localizations of unlocalized commands to be updated, got %s", plans[0])
+	}
+}
@@ -27,10 +27,14 @@ import (
 	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/i18n"
 	"github.com/disgoorg/disgo/rest"
 )
 
 // SyncCommands sets the given commands for the given guilds or globally if no guildIDs are empty. It will return on the first error for multiple guilds.
+// It always overwrites all commands, use SyncCommandsDiff to only send the changed ones.
+//
+// Localizations are sent as they are set on the commands, use SyncLocalizedCommands to localize them with an i18n.Catalog.
 func SyncCommands(client *bot.Client, commands []discord.ApplicationCommandCreate, guildIDs []snowflake.ID, opts ...rest.RequestOpt) error {
 	if len(guildIDs) == 0 {
 		_, err := client.Rest.SetGlobalCommands(client.ApplicationID, commands, opts...)
@@ -45,6 +49,11 @@ func SyncCommands(client *bot.Client, commands []discord.ApplicationCommandCreat
 	return nil
 }
 
+// SyncLocalizedCommands works like SyncCommands, but localizes the commands with i18n.Catalog.LocalizeCommands before syncing them.
+func SyncLocalizedCommands(client *bot.Client, catalog *i18n.Catalog, commands []discord.ApplicationCommandCreate, guildIDs []snowflake.ID, opts ...rest.RequestOpt) error {
+	return SyncCommands(client, catalog.LocalizeCommands(commands), guildIDs, opts...)
+}
+
 type handlerHolder[T any] struct {
 	pattern string
 	handler T
@@ -87,8 +96,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ApplicationCommandInteraction: event.Interaction.(discord.ApplicationCommandInteraction),
 				Respond:                       event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:    event.Vars,
+			Ctx:     event.Ctx,
+			Catalog: event.Catalog,
 		})
 	case SlashCommandHandler:
 		commandInteraction := event.Interaction.(discord.ApplicationCommandInteraction)
@@ -98,8 +108,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ApplicationCommandInteraction: commandInteraction,
 				Respond:                       event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:    event.Vars,
+			Ctx:     event.Ctx,
+			Catalog: event.Catalog,
 		})
 	case UserCommandHandler:
 		commandInteraction := event.Interaction.(discord.ApplicationCommandInteraction)
@@ -109,8 +120,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ApplicationCommandInteraction: commandInteraction,
 				Respond:                       event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:    event.Vars,
+			Ctx:     event.Ctx,
+			Catalog: event.Catalog,
 		})
 	case MessageCommandHandler:
 		commandInteraction := event.Interaction.(discord.ApplicationCommandInteraction)
@@ -120,8 +132,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ApplicationCommandInteraction: commandInteraction,
 				Respond:                       event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:    event.Vars,
+			Ctx:     event.Ctx,
+			Catalog: event.Catalog,
 		})
 	case EntryPointCommandHandler:
 		commandInteraction := event.Interaction.(discord.ApplicationCommandInteraction)
@@ -131,8 +144,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ApplicationCommandInteraction: commandInteraction,
 				Respond:                       event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:    event.Vars,
+			Ctx:     event.Ctx,
+			Catalog: event.Catalog,
 		})
 	case AutocompleteHandler:
 		return handler(&AutocompleteEvent{
@@ -141,8 +155,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				AutocompleteInteraction: event.Interaction.(discord.AutocompleteInteraction),
 				Respond:                 event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:    event.Vars,
+			Ctx:     event.Ctx,
+			Catalog: event.Catalog,
 		})
 	case ComponentHandler:
 		return handler(&ComponentEvent{
@@ -151,8 +166,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ComponentInteraction: event.Interaction.(discord.ComponentInteraction),
 				Respond:              event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:    event.Vars,
+			Ctx:     event.Ctx,
+			Catalog: event.Catalog,
 		})
 	case ButtonComponentHandler:
 		componentInteraction := event.Interaction.(discord.ComponentInteraction)
@@ -162,8 +178,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ComponentInteraction: componentInteraction,
 				Respond:              event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:    event.Vars,
+			Ctx:     event.Ctx,
+			Catalog: event.Catalog,
 		})
 	case SelectMenuComponentHandler:
 		componentInteraction := event.Interaction.(discord.ComponentInteraction)
@@ -173,8 +190,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ComponentInteraction: componentInteraction,
 				Respond:              event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:    event.Vars,
+			Ctx:     event.Ctx,
+			Catalog: event.Catalog,
 		})
 	case ModalHandler:
 		return handler(&ModalEvent{
@@ -183,8 +201,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ModalSubmitInteraction: event.Interaction.(discord.ModalSubmitInteraction),
 				Respond:                event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:    event.Vars,
+			Ctx:     event.Ctx,
+			Catalog: event.Catalog,
 		})
 	}
 	return errors.New("unknown handler type")
@@ -7,6 +7,7 @@ import (
 
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/i18n"
 	"github.com/disgoorg/disgo/rest"
 )
 
@@ -15,6 +16,35 @@ type InteractionEvent struct {
 	*events.InteractionCreate
 	Vars map[string]string
 	Ctx  context.Context
+	// Catalog is the i18n.Catalog set via Mux.Catalog, which is used by T.
+	Catalog *i18n.Catalog
+}
+
+// T translates the message with the key using the Catalog.
+// It falls back from the locale of the user to the locale of the guild & the default locale of the Catalog.
+// The args are key value pairs for the placeholders & plural form of the message, see i18n.Catalog.T.
+// If no Catalog is set or no locale has the message, the key is returned.
+func (e *InteractionEvent) T(key string, args ...any) string {
+	return translate(e.Catalog, e, key, args)
+}
+
+func translate(catalog *i18n.Catalog, interaction discord.Interaction, key string, args []any) string {
+	if catalog == nil {
+		return key
+	}
+	locales := []discord.Locale{interaction.Locale()}
+	if guildLocale := interaction.GuildLocale(); guildLocale != nil {
+		locales = append(locales, *guildLocale)
+	}
+	return catalog.Translate(locales, key, args...)
+}
+
+// withCtx prepends rest.WithCtx with the context of the event to the given rest.RequestOpt(s), so it can be overridden.
+func withCtx(ctx context.Context, opts []rest.RequestOpt) []rest.RequestOpt {
+	if ctx == nil {
+		return opts
+	}
+	return append([]rest.RequestOpt{rest.WithCtx(ctx)}, opts...)
 }
 
 // CreateMessage responds to the interaction with a new message.
@@ -56,29 +86,29 @@ func (e *InteractionEvent) AutocompleteResult(choices []discord.AutocompleteChoi
 }
 
 func (e *InteractionEvent) GetInteractionResponse(opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest.GetInteractionResponse(e.ApplicationID(), e.Token(), opts...)
+	return e.Client().Rest.GetInteractionResponse(e.ApplicationID(), e.Token(), withCtx(e.Ctx, opts)...)
 }
 
 func (e *InteractionEvent) UpdateInteractionResponse(messageUpdate discord.MessageUpdate, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest.UpdateInteractionResponse(e.ApplicationID(), e.Token(), messageUpdate, opts...)
+	return e.Client().Rest.UpdateInteractionResponse(e.ApplicationID(), e.Token(), messageUpdate, withCtx(e.Ctx, opts)...)
 }
 
 func (e *InteractionEvent) DeleteInteractionResponse(opts ...rest.RequestOpt) error {
-	return e.Client().Rest.DeleteInteractionResponse(e.ApplicationID(), e.Token(), opts...)
+	return e.Client().Rest.DeleteInteractionResponse(e.ApplicationID(), e.Token(), withCtx(e.Ctx, opts)...)
 }
 
 func (e *InteractionEvent) GetFollowupMessage(messageID snowflake.ID, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest.GetFollowupMessage(e.ApplicationID(), e.Token(), messageID, opts...)
+	return e.Client().Rest.GetFollowupMessage(e.ApplicationID(), e.Token(), messageID, withCtx(e.Ctx, opts)...)
 }
 
 func (e *InteractionEvent) CreateFollowupMessage(messageCreate discord.MessageCreate, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest.CreateFollowupMessage(e.ApplicationID(), e.Token(), messageCreate, opts...)
+	return e.Client().Rest.CreateFollowupMessage(e.ApplicationID(), e.Token(), messageCreate, withCtx(e.Ctx, opts)...)
 }
 
 func (e *InteractionEvent) UpdateFollowupMessage(messageID snowflake.ID, messageUpdate discord.MessageUpdate, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest.UpdateFollowupMessage(e.ApplicationID(), e.Token(), messageID, messageUpdate, opts...)
+	return e.Client().Rest.UpdateFollowupMessage(e.ApplicationID(), e.Token(), messageID, messageUpdate, withCtx(e.Ctx, opts)...)
 }
 
 func (e *InteractionEvent) DeleteFollowupMessage(messageID snowflake.ID, opts ...rest.RequestOpt) error {
-	return e.Client().Rest.DeleteFollowupMessage(e.ApplicationID(), e.Token(), messageID, opts...)
+	return e.Client().Rest.DeleteFollowupMessage(e.ApplicationID(), e.Token(), messageID, withCtx(e.Ctx, opts)...)
 }
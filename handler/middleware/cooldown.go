@@ -0,0 +1,202 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+// CooldownScope defines who shares a cooldown bucket.
+type CooldownScope int
+
+// Constants for CooldownScope
+const (
+	// CooldownScopeUser gives every user their own bucket across all guilds & DMs.
+	CooldownScopeUser CooldownScope = iota
+	// CooldownScopeMember gives every user their own bucket per guild. In DMs, it behaves like CooldownScopeUser.
+	CooldownScopeMember
+	// CooldownScopeChannel gives every channel its own bucket.
+	CooldownScopeChannel
+	// CooldownScopeGuild gives every guild its own bucket. In DMs, it behaves like CooldownScopeUser.
+	CooldownScopeGuild
+	// CooldownScopeGlobal shares one bucket between all interactions.
+	CooldownScopeGlobal
+)
+
+// CooldownWindow defines how uses are counted.
+type CooldownWindow int
+
+// Constants for CooldownWindow
+const (
+	// CooldownWindowFixed counts the uses in a window which starts with the first use & resets after the period.
+	CooldownWindowFixed CooldownWindow = iota
+	// CooldownWindowSliding counts the uses in the last period, so a use is only possible again one period after the oldest counted use.
+	CooldownWindowSliding
+)
+
+// CooldownStorage stores the uses of cooldown buckets. Implementations must be safe for concurrent use.
+type CooldownStorage interface {
+	// Take records a use of the bucket with the key if it has less than limit uses in the period.
+	// It returns 0 if the use was recorded & otherwise how long to wait until the next use is possible.
+	Take(ctx context.Context, key string, limit int, period time.Duration, window CooldownWindow) (time.Duration, error)
+}
+
+// CooldownResponseFunc responds to an interaction which is on cooldown.
+type CooldownResponseFunc func(e *handler.InteractionEvent, retryAfter time.Duration) error
+
+// DefaultCooldownResponse responds with an ephemeral message telling the user when to try again.
+func DefaultCooldownResponse(e *handler.InteractionEvent, retryAfter time.Duration) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	return e.CreateMessage(discord.MessageCreate{
+		Content: fmt.Sprintf("You are on cooldown. Try again in %ds.", seconds),
+		Flags:   discord.MessageFlagEphemeral,
+	})
+}
+
+// Cooldown is a middleware that limits how often the routes it is applied to can be used in the given period.
+// Autocomplete interactions are not limited, as Discord sends them while typing.
+//
+// Every command path, like /report or /config set, has its own buckets, unless WithCooldownSharedRoutes is set.
+// Components & modals share the buckets of the middleware, as their custom IDs usually contain variables,
+// so apply a separate Cooldown to every component or modal route which should have its own cooldown.
+//
+//	r.Group(func(r handler.Router) {
+//		r.Use(middleware.Cooldown(10*time.Second, middleware.WithCooldownScope(middleware.CooldownScopeMember)))
+//		r.SlashCommand("/report", handleReport)
+//	})
+func Cooldown(period time.Duration, opts ...CooldownConfigOpt) handler.Middleware {
+	cfg := defaultCooldownConfig()
+	cfg.apply(opts)
+
+	return func(next handler.Handler) handler.Handler {
+		return func(event *handler.InteractionEvent) error {
+			if event.Type() == discord.InteractionTypeAutocomplete {
+				return next(event)
+			}
+
+			key := cfg.Name
+			if route := cooldownRoute(event); route != "" && !cfg.SharedRoutes {
+				key += ":" + route
+			}
+			retryAfter, err := cfg.Storage.Take(event.Ctx, key+":"+cooldownBucket(event, cfg.Scope), cfg.Limit, period, cfg.Window)
+			if err != nil {
+				return err
+			}
+			if retryAfter > 0 {
+				return cfg.Response(event, retryAfter)
+			}
+			return next(event)
+		}
+	}
+}
+
+// cooldownRoute returns the command path of application commands & an empty string for all other interactions.
+func cooldownRoute(event *handler.InteractionEvent) string {
+	interaction, ok := event.Interaction.(discord.ApplicationCommandInteraction)
+	if !ok {
+		return ""
+	}
+	if data, ok := interaction.Data.(discord.SlashCommandInteractionData); ok {
+		return data.CommandPath()
+	}
+	return "/" + interaction.Data.CommandName()
+}
+
+func cooldownBucket(event *handler.InteractionEvent, scope CooldownScope) string {
+	userID := event.User().ID.String()
+	guildID := event.GuildID()
+	switch scope {
+	case CooldownScopeMember:
+		if guildID != nil {
+			return "member:" + guildID.String() + ":" + userID
+		}
+	case CooldownScopeChannel:
+		if channel := event.Channel(); channel.MessageChannel != nil {
+			return "channel:" + channel.ID().String()
+		}
+	case CooldownScopeGuild:
+		if guildID != nil {
+			return "guild:" + guildID.String()
+		}
+	case CooldownScopeGlobal:
+		return "global"
+	}
+	return "user:" + userID
+}
+
+// NewMemoryCooldownStorage returns a new in-memory CooldownStorage, which is the default storage of the Cooldown middleware.
+func NewMemoryCooldownStorage() CooldownStorage {
+	return &memoryCooldownStorage{
+		now:     time.Now,
+		buckets: map[string]*cooldownBucketUses{},
+	}
+}
+
+// cleanupInterval is how often expired buckets are removed from the memoryCooldownStorage.
+const cleanupInterval = time.Minute
+
+type memoryCooldownStorage struct {
+	now func() time.Time
+
+	mu          sync.Mutex
+	buckets     map[string]*cooldownBucketUses
+	lastCleanup time.Time
+}
+
+type cooldownBucketUses struct {
+	// uses are the times of the counted uses of a sliding window
+	uses []time.Time
+	// count is the number of uses of a fixed window
+	count   int
+	expires time.Time
+}
+
+func (s *memoryCooldownStorage) Take(_ context.Context, key string, limit int, period time.Duration, window CooldownWindow) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastCleanup) > cleanupInterval {
+		for k, bucket := range s.buckets {
+			if !now.Before(bucket.expires) {
+				delete(s.buckets, k)
+			}
+		}
+		s.lastCleanup = now
+	}
+
+	bucket, ok := s.buckets[key]
+	if !ok || !now.Before(bucket.expires) {
+		bucket = &cooldownBucketUses{}
+		s.buckets[key] = bucket
+	}
+
+	if window == CooldownWindowSliding {
+		// drop all uses which are older than the period
+		i := 0
+		for i < len(bucket.uses) && !now.Before(bucket.uses[i].Add(period)) {
+			i++
+		}
+		bucket.uses = bucket.uses[i:]
+		if len(bucket.uses) >= limit {
+			return bucket.uses[len(bucket.uses)-limit].Add(period).Sub(now), nil
+		}
+		bucket.uses = append(bucket.uses, now)
+		bucket.expires = now.Add(period)
+		return 0, nil
+	}
+
+	if bucket.count >= limit {
+		return bucket.expires.Sub(now), nil
+	}
+	if bucket.count == 0 {
+		bucket.expires = now.Add(period)
+	}
+	bucket.count++
+	return 0, nil
+}
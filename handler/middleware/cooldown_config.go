@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"strconv"
+	"sync/atomic"
+)
+
+func defaultCooldownConfig() cooldownConfig {
+	return cooldownConfig{
+		Limit:    1,
+		Scope:    CooldownScopeUser,
+		Window:   CooldownWindowFixed,
+		Name:     "cooldown-" + strconv.FormatInt(cooldownID.Add(1), 10),
+		Response: DefaultCooldownResponse,
+	}
+}
+
+// cooldownID is used to give every Cooldown middleware a unique default name.
+var cooldownID atomic.Int64
+
+type cooldownConfig struct {
+	Limit        int
+	Scope        CooldownScope
+	Window       CooldownWindow
+	Storage      CooldownStorage
+	Name         string
+	SharedRoutes bool
+	Response     CooldownResponseFunc
+}
+
+// CooldownConfigOpt can be used to supply optional parameters to Cooldown.
+type CooldownConfigOpt func(config *cooldownConfig)
+
+func (c *cooldownConfig) apply(opts []CooldownConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.Storage == nil {
+		c.Storage = NewMemoryCooldownStorage()
+	}
+}
+
+// WithCooldownLimit sets the number of uses per period. Defaults to 1.
+func WithCooldownLimit(limit int) CooldownConfigOpt {
+	return func(config *cooldownConfig) {
+		config.Limit = limit
+	}
+}
+
+// WithCooldownScope sets who shares a bucket. Defaults to CooldownScopeUser.
+func WithCooldownScope(scope CooldownScope) CooldownConfigOpt {
+	return func(config *cooldownConfig) {
+		config.Scope = scope
+	}
+}
+
+// WithCooldownWindow sets how uses are counted. Defaults to CooldownWindowFixed.
+func WithCooldownWindow(window CooldownWindow) CooldownConfigOpt {
+	return func(config *cooldownConfig) {
+		config.Window = window
+	}
+}
+
+// WithCooldownStorage sets the CooldownStorage, which is useful to share cooldowns between multiple processes. Defaults to a new in-memory storage per middleware.
+func WithCooldownStorage(storage CooldownStorage) CooldownConfigOpt {
+	return func(config *cooldownConfig) {
+		config.Storage = storage
+	}
+}
+
+// WithCooldownName sets the name which prefixes the bucket keys. Middlewares with the same name & Storage share their buckets. Defaults to a unique name per middleware.
+func WithCooldownName(name string) CooldownConfigOpt {
+	return func(config *cooldownConfig) {
+		config.Name = name
+	}
+}
+
+// WithCooldownSharedRoutes shares the buckets between all commands the middleware is applied to, instead of giving every command path its own buckets.
+func WithCooldownSharedRoutes() CooldownConfigOpt {
+	return func(config *cooldownConfig) {
+		config.SharedRoutes = true
+	}
+}
+
+// WithCooldownResponse sets the function which responds to interactions which are on cooldown. Defaults to DefaultCooldownResponse.
+func WithCooldownResponse(response CooldownResponseFunc) CooldownConfigOpt {
+	return func(config *cooldownConfig) {
+		config.Response = response
+	}
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+func TestMemoryCooldownStorage(t *testing.T) {
+	ctx := context.Background()
+	for _, window := range []CooldownWindow{CooldownWindowFixed, CooldownWindowSliding} {
+		storage := NewMemoryCooldownStorage().(*memoryCooldownStorage)
+		now := time.Now()
+		storage.now = func() time.Time { return now }
+
+		key := "test"
+		for i := range 2 {
+			if retryAfter, _ := storage.Take(ctx, key, 2, time.Minute, window); retryAfter != 0 {
+				t.Fatalf("window %d: expected use %d to be allowed, got retry after %s", window, i, retryAfter)
+			}
+			now = now.Add(10 * time.Second)
+		}
+		if retryAfter, _ := storage.Take(ctx, key, 2, time.Minute, window); retryAfter != 40*time.Second {
+			t.Fatalf("window %d: expected third use to be limited for 40s, got retry after %s", window, retryAfter)
+		}
+		if retryAfter, _ := storage.Take(ctx, "other", 2, time.Minute, window); retryAfter != 0 {
+			t.Fatalf("window %d: expected other bucket to be allowed, got retry after %s", window, retryAfter)
+		}
+
+		now = now.Add(40 * time.Second)
+		if retryAfter, _ := storage.Take(ctx, key, 2, time.Minute, window); retryAfter != 0 {
+			t.Fatalf("window %d: expected use after the period to be allowed, got retry after %s", window, retryAfter)
+		}
+	}
+}
+
+func TestMemoryCooldownStorage_Sliding(t *testing.T) {
+	ctx := context.Background()
+	storage := NewMemoryCooldownStorage().(*memoryCooldownStorage)
+	now := time.Now()
+	storage.now = func() time.Time { return now }
+
+	_, _ = storage.Take(ctx, "test", 2, time.Minute, CooldownWindowSliding)
+	now = now.Add(40 * time.Second)
+	_, _ = storage.Take(ctx, "test", 2, time.Minute, CooldownWindowSliding)
+	now = now.Add(20 * time.Second)
+
+	// the first use left the window, but the second one is still counted
+	if retryAfter, _ := storage.Take(ctx, "test", 2, time.Minute, CooldownWindowSliding); retryAfter != 0 {
+		t.Fatalf("expected use to be allowed after the oldest use left the window, got retry after %s", retryAfter)
+	}
+	if retryAfter, _ := storage.Take(ctx, "test", 2, time.Minute, CooldownWindowSliding); retryAfter != 40*time.Second {
+		t.Fatalf("expected use to be limited until the second use leaves the window, got retry after %s", retryAfter)
+	}
+}
+
+func TestMemoryCooldownStorage_Cleanup(t *testing.T) {
+	ctx := context.Background()
+	storage := NewMemoryCooldownStorage().(*memoryCooldownStorage)
+	now := time.Now()
+	storage.now = func() time.Time { return now }
+
+	_, _ = storage.Take(ctx, "expired", 1, time.Second, CooldownWindowFixed)
+	_, _ = storage.Take(ctx, "active", 1, time.Hour, CooldownWindowFixed)
+	now = now.Add(2 * cleanupInterval)
+	_, _ = storage.Take(ctx, "new", 1, time.Second, CooldownWindowFixed)
+
+	if _, ok := storage.buckets["expired"]; ok {
+		t.Error("expected expired bucket to be removed")
+	}
+	if _, ok := storage.buckets["active"]; !ok {
+		t.Error("expected active bucket to be kept")
+	}
+}
+
+func TestCooldown_Routes(t *testing.T) {
+	for _, shared := range []bool{false, true} {
+		opts := []CooldownConfigOpt{WithCooldownResponse(func(e *handler.InteractionEvent, _ time.Duration) error {
+			return e.CreateMessage(discord.MessageCreate{Content: "cooldown"})
+		})}
+		if shared {
+			opts = append(opts, WithCooldownSharedRoutes())
+		}
+
+		mux := handler.New()
+		mux.Group(func(r handler.Router) {
+			r.Use(Cooldown(time.Minute, opts...))
+			for _, name := range []string{"/ban", "/kick"} {
+				r.Command(name, func(e *handler.CommandEvent) error {
+					return e.CreateMessage(discord.MessageCreate{Content: "ok"})
+				})
+			}
+		})
+
+		ban := fmt.Sprintf(guardCommandInteraction, 0, 0)
+		kick := strings.Replace(ban, `"name": "ban"`, `"name": "kick"`, 1)
+		if _, handled := runGuard(t, mux, ban); !handled {
+			t.Fatalf("shared %t: expected first /ban to be handled", shared)
+		}
+		if _, handled := runGuard(t, mux, ban); handled {
+			t.Errorf("shared %t: expected second /ban to be on cooldown", shared)
+		}
+		if _, handled := runGuard(t, mux, kick); handled != !shared {
+			t.Errorf("shared %t: expected /kick to be handled %t, got %t", shared, !shared, handled)
+		}
+	}
+}
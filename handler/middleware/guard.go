@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+// GuardFunc checks whether an interaction is allowed. If not, it returns the reason, which is shown to the user.
+type GuardFunc func(e *handler.InteractionEvent) (allowed bool, reason string)
+
+// GuardDenyFunc responds to an interaction which is denied by a guard.
+type GuardDenyFunc func(e *handler.InteractionEvent, reason string) error
+
+// DefaultGuardDeny responds with an ephemeral message containing the reason.
+// Autocomplete interactions are responded to with no choices, as they can't be responded to with a message.
+func DefaultGuardDeny(e *handler.InteractionEvent, reason string) error {
+	if e.Type() == discord.InteractionTypeAutocomplete {
+		return e.AutocompleteResult(nil)
+	}
+	return e.CreateMessage(discord.MessageCreate{
+		Content: reason,
+		Flags:   discord.MessageFlagEphemeral,
+	})
+}
+
+// Guard is a middleware that only calls the next handler if the GuardFunc allows the interaction.
+// Otherwise, the interaction is denied with the configured GuardDenyFunc.
+//
+//	r.Group(func(r handler.Router) {
+//		r.Use(middleware.Guard(func(e *handler.InteractionEvent) (bool, string) {
+//			return e.User().ID == ownerID, "Only the owner of the bot can use this."
+//		}))
+//		r.SlashCommand("/shutdown", handleShutdown)
+//	})
+func Guard(check GuardFunc, opts ...GuardConfigOpt) handler.Middleware {
+	cfg := defaultGuardConfig()
+	cfg.apply(opts)
+
+	return func(next handler.Handler) handler.Handler {
+		return func(event *handler.InteractionEvent) error {
+			if allowed, reason := check(event); !allowed {
+				if cfg.Message != "" {
+					reason = cfg.Message
+				}
+				return cfg.Deny(event, reason)
+			}
+			return next(event)
+		}
+	}
+}
+
+// RequirePermissions is a guard that only allows members with all the given permissions in the channel of the interaction.
+// Interactions outside of guilds are denied.
+func RequirePermissions(permissions discord.Permissions, opts ...GuardConfigOpt) handler.Middleware {
+	return Guard(func(e *handler.InteractionEvent) (bool, string) {
+		member := e.Member()
+		if member == nil {
+			return false, "This can only be used in a server."
+		}
+		if missing := permissions.Remove(member.Permissions); missing != discord.PermissionsNone {
+			return false, "You are missing the following permissions: " + missing.String()
+		}
+		return true, ""
+	}, opts...)
+}
+
+// RequireBotPermissions is a guard that only allows interactions if the bot has all the given permissions in the channel of the interaction.
+// Interactions without the permissions of the bot are denied, as they can't be checked.
+func RequireBotPermissions(permissions discord.Permissions, opts ...GuardConfigOpt) handler.Middleware {
+	return Guard(func(e *handler.InteractionEvent) (bool, string) {
+		appPermissions := e.AppPermissions()
+		if appPermissions == nil {
+			return false, "I am missing the following permissions: " + permissions.String()
+		}
+		if missing := permissions.Remove(*appPermissions); missing != discord.PermissionsNone {
+			return false, "I am missing the following permissions: " + missing.String()
+		}
+		return true, ""
+	}, opts...)
+}
+
+// RequireContext is a guard that only allows interactions in one of the given discord.InteractionContextType(s).
+func RequireContext(contexts []discord.InteractionContextType, opts ...GuardConfigOpt) handler.Middleware {
+	return Guard(func(e *handler.InteractionEvent) (bool, string) {
+		if slices.Contains(contexts, e.Context()) {
+			return true, ""
+		}
+		switch e.Context() {
+		case discord.InteractionContextTypeGuild:
+			return false, "This can't be used in a server."
+		case discord.InteractionContextTypeBotDM:
+			return false, "This can't be used in DMs with the bot."
+		default:
+			return false, "This can't be used in private channels."
+		}
+	}, opts...)
+}
+
+// RequireGuild is a guard that only allows interactions in guilds.
+func RequireGuild(opts ...GuardConfigOpt) handler.Middleware {
+	return Guard(func(e *handler.InteractionEvent) (bool, string) {
+		return e.GuildID() != nil, "This can only be used in a server."
+	}, opts...)
+}
+
+// RequireNSFW is a guard that only allows interactions in channels which are marked as NSFW.
+// For threads, the parent channel is looked up in the cache of the client.
+func RequireNSFW(opts ...GuardConfigOpt) handler.Middleware {
+	return Guard(func(e *handler.InteractionEvent) (bool, string) {
+		return nsfwChannel(e), "This can only be used in NSFW channels."
+	}, opts...)
+}
+
+func nsfwChannel(e *handler.InteractionEvent) bool {
+	var channel discord.Channel = e.Channel().MessageChannel
+	if thread, ok := channel.(discord.GuildThread); ok {
+		if thread.ParentID() == nil || e.Client() == nil || e.Client().Caches == nil {
+			return false
+		}
+		parent, ok := e.Client().Caches.Channel(*thread.ParentID())
+		if !ok {
+			return false
+		}
+		channel = parent
+	}
+	messageChannel, ok := channel.(discord.GuildMessageChannel)
+	return ok && messageChannel.NSFW()
+}
+
+// RequireInvoker is a guard that only allows the user who invoked the original interaction to use the components & modals of its response.
+// Other interaction types are always allowed.
+func RequireInvoker(opts ...GuardConfigOpt) handler.Middleware {
+	return Guard(func(e *handler.InteractionEvent) (bool, string) {
+		var message *discord.Message
+		switch i := e.Interaction.(type) {
+		case discord.ComponentInteraction:
+			message = &i.Message
+		case discord.ModalSubmitInteraction:
+			message = i.Message
+		}
+		if message == nil {
+			return true, ""
+		}
+
+		var invoker *discord.User
+		if message.InteractionMetadata != nil {
+			invoker = &message.InteractionMetadata.User
+		} else if message.Interaction != nil {
+			invoker = &message.Interaction.User
+		}
+		return invoker == nil || invoker.ID == e.User().ID, "Only the user who used the command can use this."
+	}, opts...)
+}
@@ -0,0 +1,35 @@
+package middleware
+
+func defaultGuardConfig() guardConfig {
+	return guardConfig{
+		Deny: DefaultGuardDeny,
+	}
+}
+
+type guardConfig struct {
+	Deny    GuardDenyFunc
+	Message string
+}
+
+// GuardConfigOpt can be used to supply optional parameters to Guard & the Require guards.
+type GuardConfigOpt func(config *guardConfig)
+
+func (c *guardConfig) apply(opts []GuardConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithGuardDeny sets the function which responds to denied interactions. Defaults to DefaultGuardDeny.
+func WithGuardDeny(deny GuardDenyFunc) GuardConfigOpt {
+	return func(config *guardConfig) {
+		config.Deny = deny
+	}
+}
+
+// WithGuardMessage sets a message which replaces the reason passed to the GuardDenyFunc.
+func WithGuardMessage(message string) GuardConfigOpt {
+	return func(config *guardConfig) {
+		config.Message = message
+	}
+}
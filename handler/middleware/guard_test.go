@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/disgo/rest"
+)
+
+const guardCommandInteraction = `{
+	"type": 2,
+	"id": "1",
+	"token": "token",
+	"guild_id": "2",
+	"channel_id": "3",
+	"context": 0,
+	"app_permissions": "%d",
+	"member": {"user": {"id": "10", "username": "user"}, "roles": [], "permissions": "%d", "joined_at": "2017-03-13T19:19:14.040000+00:00"},
+	"data": {"type": 1, "name": "ban", "id": "4"}
+}`
+
+const guardButtonInteraction = `{
+	"type": 3,
+	"id": "1",
+	"token": "token",
+	"channel_id": "3",
+	"context": 1,
+	"user": {"id": "%s", "username": "user"},
+	"message": {"id": "5", "channel_id": "3", "author": {"id": "6", "username": "bot"}, "content": "", "timestamp": "2017-03-13T19:19:14.040000+00:00",
+		"interaction_metadata": {"id": "7", "type": 2, "user": {"id": "10", "username": "user"}}},
+	"data": {"component_type": 2, "custom_id": "/confirm"}
+}`
+
+func runGuard(t *testing.T, mux *handler.Mux, data string) (*discord.InteractionResponse, bool) {
+	interaction, err := discord.UnmarshalInteraction([]byte(data))
+	if err != nil {
+		t.Fatalf("failed to unmarshal interaction: %v", err)
+	}
+
+	var (
+		response *discord.InteractionResponse
+		handled  bool
+	)
+	mux.OnEvent(&events.InteractionCreate{
+		GenericEvent: events.NewGenericEvent(nil, 0, 0),
+		Interaction:  interaction,
+		Respond: func(responseType discord.InteractionResponseType, data discord.InteractionResponseData, opts ...rest.RequestOpt) error {
+			response = &discord.InteractionResponse{Type: responseType, Data: data}
+			if messageCreate, ok := data.(discord.MessageCreate); ok && messageCreate.Content == "ok" {
+				handled = true
+			}
+			return nil
+		},
+	})
+	return response, handled
+}
+
+func TestGuards(t *testing.T) {
+	mux := handler.New()
+	mux.Group(func(r handler.Router) {
+		r.Use(
+			RequirePermissions(discord.PermissionBanMembers),
+			RequireBotPermissions(discord.PermissionBanMembers, WithGuardMessage("I can't ban members.")),
+			RequireContext([]discord.InteractionContextType{discord.InteractionContextTypeGuild}),
+		)
+		r.Command("/ban", func(e *handler.CommandEvent) error {
+			return e.CreateMessage(discord.MessageCreate{Content: "ok"})
+		})
+	})
+	mux.Group(func(r handler.Router) {
+		r.Use(RequireInvoker())
+		r.Component("/confirm", func(e *handler.ComponentEvent) error {
+			return e.CreateMessage(discord.MessageCreate{Content: "ok"})
+		})
+	})
+
+	if _, handled := runGuard(t, mux, fmt.Sprintf(guardCommandInteraction, discord.PermissionBanMembers, discord.PermissionBanMembers)); !handled {
+		t.Error("expected member with ban members to be allowed")
+	}
+
+	response, handled := runGuard(t, mux, fmt.Sprintf(guardCommandInteraction, discord.PermissionBanMembers, discord.PermissionKickMembers))
+	if handled {
+		t.Error("expected member without ban members to be denied")
+	} else if messageCreate, _ := response.Data.(discord.MessageCreate); messageCreate.Content != "You are missing the following permissions: Ban Members" || messageCreate.Flags != discord.MessageFlagEphemeral {
+		t.Errorf("unexpected denial %+v", response.Data)
+	}
+
+	response, handled = runGuard(t, mux, fmt.Sprintf(guardCommandInteraction, discord.PermissionKickMembers, discord.PermissionBanMembers))
+	if handled {
+		t.Error("expected interaction to be denied if the bot lacks ban members")
+	} else if messageCreate, _ := response.Data.(discord.MessageCreate); messageCreate.Content != "I can't ban members." {
+		t.Errorf("expected custom denial message, got %+v", response.Data)
+	}
+
+	if _, handled = runGuard(t, mux, fmt.Sprintf(guardButtonInteraction, "10")); !handled {
+		t.Error("expected invoker to be allowed")
+	}
+	if _, handled = runGuard(t, mux, fmt.Sprintf(guardButtonInteraction, "11")); handled {
+		t.Error("expected other user to be denied")
+	}
+}
+
+func TestRequireBotPermissions_Unknown(t *testing.T) {
+	mux := handler.New()
+	mux.Group(func(r handler.Router) {
+		r.Use(RequireBotPermissions(discord.PermissionBanMembers | discord.PermissionKickMembers))
+		r.Command("/ban", func(e *handler.CommandEvent) error {
+			return e.CreateMessage(discord.MessageCreate{Content: "ok"})
+		})
+	})
+
+	// the bot has ban members, but the interaction doesn't include its permissions
+	data := fmt.Sprintf(guardCommandInteraction, discord.PermissionBanMembers, discord.PermissionBanMembers)
+	data = strings.Replace(data, fmt.Sprintf(`"app_permissions": "%d",`, discord.PermissionBanMembers), "", 1)
+	response, handled := runGuard(t, mux, data)
+	if handled {
+		t.Fatal("expected interaction without app permissions to be denied")
+	}
+	// the order of the permission names is not stable
+	messageCreate, _ := response.Data.(discord.MessageCreate)
+	if !strings.HasPrefix(messageCreate.Content, "I am missing the following permissions: ") || !strings.Contains(messageCreate.Content, "Ban Members") || !strings.Contains(messageCreate.Content, "Kick Members") {
+		t.Errorf("expected both permissions to be missing, got %+v", response.Data)
+	}
+}
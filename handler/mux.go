@@ -8,6 +8,9 @@ import (
 	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/i18n"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/tracing"
 )
 
 var defaultErrorHandler ErrorHandler = func(event *InteractionEvent, err error) {
@@ -35,6 +38,7 @@ type Mux struct {
 	notFoundHandler NotFoundHandler
 	errorHandler    ErrorHandler
 	defaultContext  func() context.Context
+	catalog         *i18n.Catalog
 }
 
 // OnEvent is called when a new event is received.
@@ -68,13 +72,22 @@ func (r *Mux) OnEvent(event bot.Event) {
 	if r.defaultContext != nil {
 		ctx = r.defaultContext()
 	} else {
-		ctx = context.Background()
+		ctx = e.DispatchContext()
 	}
 
 	ie := &InteractionEvent{
-		InteractionCreate: e,
-		Ctx:               ctx,
-		Vars:              make(map[string]string),
+		Vars:    make(map[string]string),
+		Ctx:     ctx,
+		Catalog: r.catalog,
+	}
+	respond := e.Respond
+	ie.InteractionCreate = &events.InteractionCreate{
+		GenericEvent: e.GenericEvent,
+		Interaction:  e.Interaction,
+		// respond with the current context of the event, so the interaction callback is part of its trace
+		Respond: func(responseType discord.InteractionResponseType, data discord.InteractionResponseData, opts ...rest.RequestOpt) error {
+			return respond(responseType, data, withCtx(ie.Ctx, opts)...)
+		},
 	}
 	if err := r.Handle(path, ie); err != nil {
 		if r.errorHandler != nil {
@@ -129,7 +142,7 @@ func (r *Mux) Handle(path string, event *InteractionEvent) error {
 
 		for _, route := range r.routes {
 			if route.Match(path, t, t2) {
-				return route.Handle(path, event)
+				return handleRoute(route, path, event)
 			}
 		}
 		if r.notFoundHandler != nil {
@@ -145,6 +158,24 @@ func (r *Mux) Handle(path string, event *InteractionEvent) error {
 	return handlerChain(event)
 }
 
+// handleRoute handles the given event with the given Route in a new tracing span.
+func handleRoute(route Route, path string, event *InteractionEvent) error {
+	tracer := tracing.Noop
+	if client := event.Client(); client != nil && client.Tracer != nil {
+		tracer = client.Tracer
+	}
+
+	ctx, span := tracer.Start(event.Ctx, "handler "+path, tracing.String("disgo.handler.path", path))
+	defer span.End()
+	event.Ctx = ctx
+
+	err := route.Handle(path, event)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
+
 // Use adds the given middlewares to the current Router.
 func (r *Mux) Use(middlewares ...Middleware) {
 	r.middlewares = append(r.middlewares, middlewares...)
@@ -319,8 +350,14 @@ func (r *Mux) Error(h ErrorHandler) {
 	r.errorHandler = h
 }
 
+// Catalog sets the i18n.Catalog used by InteractionEvent.T to translate responses into the locale of the user.
+// This catalog only works for the root router and will be ignored for sub routers.
+func (r *Mux) Catalog(catalog *i18n.Catalog) {
+	r.catalog = catalog
+}
+
 // DefaultContext sets the default context for this router.
-// This context will be used for all interaction events.
+// This context will be used for all interaction events instead of the dispatch context of the event, which contains its tracing span.
 func (r *Mux) DefaultContext(ctx func() context.Context) {
 	r.defaultContext = ctx
 }
@@ -1,13 +1,17 @@
 package handler
 
 import (
+	"context"
 	"maps"
+	"net/http"
 	"os"
 	"reflect"
 	"testing"
 
+	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/i18n"
 	"github.com/disgoorg/disgo/rest"
 )
 
@@ -311,3 +315,90 @@ func TestMux(t *testing.T) {
 		}
 	}
 }
+
+func TestMuxCatalog(t *testing.T) {
+	slashData, err := os.ReadFile("testdata/command/slash_command.json")
+	if err != nil {
+		t.Fatalf("failed to read slash command data: %v", err)
+	}
+
+	catalog := i18n.New(discord.LocaleGerman)
+	if err = catalog.Add(discord.LocaleEnglishUS, map[string]any{"pong": "Pong, {name}!"}); err != nil {
+		t.Fatalf("failed to add messages: %v", err)
+	}
+
+	mux := New()
+	mux.Catalog(catalog)
+	mux.SlashCommand("/foo", func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		return e.CreateMessage(discord.MessageCreate{Content: e.T("pong", "name", e.User().Username)})
+	})
+
+	interaction, err := discord.UnmarshalInteraction(slashData)
+	if err != nil {
+		t.Fatalf("failed to unmarshal interaction: %v", err)
+	}
+	recorder := NewRecorder()
+	mux.OnEvent(&events.InteractionCreate{
+		GenericEvent: events.NewGenericEvent(nil, 0, 0),
+		Interaction:  interaction,
+		Respond:      recorder.Respond,
+	})
+
+	expected := &discord.InteractionResponse{
+		Type: discord.InteractionResponseTypeCreateMessage,
+		Data: discord.MessageCreate{Content: "Pong, Mason!"},
+	}
+	if !reflect.DeepEqual(expected, recorder.Response) {
+		t.Errorf("expected %+v, got %+v", expected, recorder.Response)
+	}
+}
+
+type testCtxKey struct{}
+
+func TestMuxRestCtx(t *testing.T) {
+	slashData, err := os.ReadFile("testdata/command/slash_command.json")
+	if err != nil {
+		t.Fatalf("failed to read slash command data: %v", err)
+	}
+
+	var ctxValue any
+	mock := func(next rest.RequestHandler) rest.RequestHandler {
+		return func(rq *rest.Request) (*rest.Response, error) {
+			ctxValue = rq.Request.Context().Value(testCtxKey{})
+			return &rest.Response{
+				Response: &http.Response{StatusCode: http.StatusOK, Status: "200 OK"},
+				RawBody:  []byte(`{"id": "1", "channel_id": "2", "author": {"id": "3", "username": "bot"}, "content": "followup", "timestamp": "2017-03-13T19:19:14.040000+00:00"}`),
+			}, nil
+		}
+	}
+	client := &bot.Client{
+		ApplicationID: 100,
+		Rest:          rest.New(rest.NewClient("", rest.WithURL(""), rest.WithMiddlewares(mock))),
+	}
+
+	mux := New()
+	mux.Use(func(next Handler) Handler {
+		return func(e *InteractionEvent) error {
+			e.Ctx = context.WithValue(e.Ctx, testCtxKey{}, "value")
+			return next(e)
+		}
+	})
+	mux.SlashCommand("/foo", func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		_, err := e.CreateFollowupMessage(discord.MessageCreate{Content: "followup"})
+		return err
+	})
+
+	interaction, err := discord.UnmarshalInteraction(slashData)
+	if err != nil {
+		t.Fatalf("failed to unmarshal interaction: %v", err)
+	}
+	mux.OnEvent(&events.InteractionCreate{
+		GenericEvent: events.NewGenericEvent(client, 0, 0),
+		Interaction:  interaction,
+		Respond:      NewRecorder().Respond,
+	})
+
+	if ctxValue != "value" {
+		t.Errorf("expected the followup request to use the context of the event, got %v", ctxValue)
+	}
+}
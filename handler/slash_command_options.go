@@ -0,0 +1,549 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+var (
+	// ErrOptionRequired is returned when a required option is missing.
+	ErrOptionRequired = errors.New("option is required")
+	// ErrOptionType is returned when an option has a different type than its field.
+	ErrOptionType = errors.New("option has the wrong type")
+	// ErrOptionNotResolved is returned when the user, member, role, channel or attachment of an option is not resolved.
+	ErrOptionNotResolved = errors.New("option is not resolved")
+	// ErrOptionOutOfRange is returned when an option is below its min or above its max value or length.
+	ErrOptionOutOfRange = errors.New("option is out of range")
+	// ErrOptionInvalidChoice is returned when an option is none of its choices.
+	ErrOptionInvalidChoice = errors.New("option is not a valid choice")
+)
+
+// OptionError is returned when a slash command option can't be decoded into its field or is invalid.
+// When returned by a handler of BindSlashCommand, it is passed to the ErrorHandler of the Mux.
+type OptionError struct {
+	// Option is the name of the option.
+	Option string
+	// Err is the cause, like ErrOptionRequired or ErrOptionOutOfRange.
+	Err error
+}
+
+func (e *OptionError) Error() string {
+	return fmt.Sprintf("invalid option %s: %s", e.Option, e.Err)
+}
+
+func (e *OptionError) Unwrap() error {
+	return e.Err
+}
+
+// SlashCommandOptionsHandler is a function that handles slash command interactions with their options decoded into T.
+type SlashCommandOptionsHandler[T any] func(options T, data discord.SlashCommandInteractionData, e *CommandEvent) error
+
+// BindSlashCommand returns a SlashCommandHandler which decodes the options of the interaction into T using DecodeSlashCommandOptions before calling the given handler.
+// If the options are invalid, the *OptionError is returned to the ErrorHandler of the Mux & the handler is not called.
+//
+//	r.SlashCommand("/ban", handler.BindSlashCommand(func(options BanOptions, data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+//		...
+//	}))
+func BindSlashCommand[T any](h SlashCommandOptionsHandler[T]) SlashCommandHandler {
+	return func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		options, err := DecodeSlashCommandOptions[T](data)
+		if err != nil {
+			return err
+		}
+		return h(options, data, e)
+	}
+}
+
+// NewSlashCommandCreate returns the given discord.SlashCommandCreate with its Options derived from the struct T using SlashCommandOptions.
+func NewSlashCommandCreate[T any](create discord.SlashCommandCreate) (discord.SlashCommandCreate, error) {
+	options, err := SlashCommandOptions[T]()
+	if err != nil {
+		return create, err
+	}
+	create.Options = options
+	return create, nil
+}
+
+// SlashCommandOptions derives the options of a slash command from the exported fields of the struct T.
+// The options can also be used for sub commands. Required options are sorted before optional ones.
+//
+// The option type is derived from the field type:
+//   - string: discord.ApplicationCommandOptionString
+//   - int, int8, int16, int32, int64: discord.ApplicationCommandOptionInt
+//   - float32, float64: discord.ApplicationCommandOptionFloat
+//   - bool: discord.ApplicationCommandOptionBool
+//   - discord.User, discord.ResolvedMember: discord.ApplicationCommandOptionUser
+//   - discord.Role: discord.ApplicationCommandOptionRole
+//   - discord.ResolvedChannel: discord.ApplicationCommandOptionChannel
+//   - discord.Attachment: discord.ApplicationCommandOptionAttachment
+//   - snowflake.ID: discord.ApplicationCommandOptionMentionable
+//
+// Pointer fields are optional, all others are required. The options are configured with the following struct tags:
+//   - name: the name of the option, defaults to the field name in snake_case. "-" skips the field
+//   - description: the description of the option, which is required
+//   - name_<locale> & description_<locale>: the localizations of the name & description, e.g. name_de:"benutzer"
+//   - min & max: the min & max value of int & float options
+//   - min_length & max_length: the min & max length of string options
+//   - choices: the choices of string, int & float options separated by ";", with an optional name before "=", e.g. choices:"Soft Ban=soft;Hard Ban=hard"
+//   - channel_types: the discord.ChannelType(s) of channel options separated by ",", e.g. channel_types:"0,5"
+//   - autocomplete: "true" enables autocomplete for string, int & float options
+func SlashCommandOptions[T any]() ([]discord.ApplicationCommandOption, error) {
+	fields, err := optionFieldsOf(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	options := make([]discord.ApplicationCommandOption, len(fields))
+	for i, field := range fields {
+		options[i] = field.option
+	}
+	return options, nil
+}
+
+// DecodeSlashCommandOptions decodes the options of the interaction into the struct T, which is described by SlashCommandOptions.
+// Missing, mistyped & invalid options are reported with an *OptionError.
+func DecodeSlashCommandOptions[T any](data discord.SlashCommandInteractionData) (T, error) {
+	var options T
+	fields, err := optionFieldsOf(reflect.TypeFor[T]())
+	if err != nil {
+		return options, err
+	}
+
+	v := reflect.ValueOf(&options).Elem()
+	for _, field := range fields {
+		if err = field.decode(data, v.FieldByIndex(field.index)); err != nil {
+			return options, &OptionError{Option: field.name, Err: err}
+		}
+	}
+	return options, nil
+}
+
+var (
+	userType            = reflect.TypeFor[discord.User]()
+	resolvedMemberType  = reflect.TypeFor[discord.ResolvedMember]()
+	roleType            = reflect.TypeFor[discord.Role]()
+	resolvedChannelType = reflect.TypeFor[discord.ResolvedChannel]()
+	attachmentType      = reflect.TypeFor[discord.Attachment]()
+	snowflakeType       = reflect.TypeFor[snowflake.ID]()
+)
+
+// optionFields caches the []optionField of every struct type.
+var optionFields sync.Map
+
+type optionField struct {
+	index      []int
+	name       string
+	optional   bool
+	optionType discord.ApplicationCommandOptionType
+	option     discord.ApplicationCommandOption
+
+	min       *float64
+	max       *float64
+	minLength *int
+	maxLength *int
+	// choices are the values of the choices, converted to string, int64 or float64
+	choices []any
+}
+
+func optionFieldsOf(t reflect.Type) ([]optionField, error) {
+	if fields, ok := optionFields.Load(t); ok {
+		return fields.([]optionField), nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("slash command options must be a struct, got %s", t)
+	}
+
+	var fields []optionField
+	for _, structField := range reflect.VisibleFields(t) {
+		if !structField.IsExported() || structField.Anonymous || structField.Tag.Get("name") == "-" {
+			continue
+		}
+		field, err := newOptionField(structField)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slash command option field %s.%s: %w", t, structField.Name, err)
+		}
+		fields = append(fields, field)
+	}
+	// Discord requires all required options to be before the optional ones
+	slices.SortStableFunc(fields, func(a optionField, b optionField) int {
+		switch {
+		case a.optional == b.optional:
+			return 0
+		case a.optional:
+			return 1
+		default:
+			return -1
+		}
+	})
+
+	fields2, _ := optionFields.LoadOrStore(t, fields)
+	return fields2.([]optionField), nil
+}
+
+func newOptionField(structField reflect.StructField) (optionField, error) {
+	tag := structField.Tag
+	field := optionField{
+		index: structField.Index,
+		name:  tag.Get("name"),
+	}
+	if field.name == "" {
+		field.name = snakeCase(structField.Name)
+	}
+
+	t := structField.Type
+	if t.Kind() == reflect.Pointer {
+		field.optional = true
+		t = t.Elem()
+	}
+
+	description := tag.Get("description")
+	if description == "" {
+		return field, errors.New("missing description tag")
+	}
+	nameLocalizations, descriptionLocalizations := tagLocalizations(tag)
+
+	var err error
+	switch {
+	case t == userType || t == resolvedMemberType:
+		field.optionType = discord.ApplicationCommandOptionTypeUser
+		field.option = discord.ApplicationCommandOptionUser{
+			Name:                     field.name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 !field.optional,
+		}
+	case t == roleType:
+		field.optionType = discord.ApplicationCommandOptionTypeRole
+		field.option = discord.ApplicationCommandOptionRole{
+			Name:                     field.name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 !field.optional,
+		}
+	case t == resolvedChannelType:
+		field.optionType = discord.ApplicationCommandOptionTypeChannel
+		var channelTypes []discord.ChannelType
+		if channelTypes, err = tagChannelTypes(tag); err != nil {
+			return field, err
+		}
+		field.option = discord.ApplicationCommandOptionChannel{
+			Name:                     field.name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 !field.optional,
+			ChannelTypes:             channelTypes,
+		}
+	case t == attachmentType:
+		field.optionType = discord.ApplicationCommandOptionTypeAttachment
+		field.option = discord.ApplicationCommandOptionAttachment{
+			Name:                     field.name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 !field.optional,
+		}
+	case t == snowflakeType:
+		field.optionType = discord.ApplicationCommandOptionTypeMentionable
+		field.option = discord.ApplicationCommandOptionMentionable{
+			Name:                     field.name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 !field.optional,
+		}
+	case t.Kind() == reflect.String:
+		field.optionType = discord.ApplicationCommandOptionTypeString
+		if field.minLength, err = tagInt(tag, "min_length"); err != nil {
+			return field, err
+		}
+		if field.maxLength, err = tagInt(tag, "max_length"); err != nil {
+			return field, err
+		}
+		var choices []discord.ApplicationCommandOptionChoiceString
+		for name, value := range tagChoices(tag) {
+			choices = append(choices, discord.ApplicationCommandOptionChoiceString{Name: name, Value: value})
+			field.choices = append(field.choices, value)
+		}
+		field.option = discord.ApplicationCommandOptionString{
+			Name:                     field.name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 !field.optional,
+			Choices:                  choices,
+			Autocomplete:             tag.Get("autocomplete") == "true",
+			MinLength:                field.minLength,
+			MaxLength:                field.maxLength,
+		}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		field.optionType = discord.ApplicationCommandOptionTypeInt
+		var minValue, maxValue *int
+		if minValue, err = tagInt(tag, "min"); err != nil {
+			return field, err
+		}
+		if maxValue, err = tagInt(tag, "max"); err != nil {
+			return field, err
+		}
+		if minValue != nil {
+			f := float64(*minValue)
+			field.min = &f
+		}
+		if maxValue != nil {
+			f := float64(*maxValue)
+			field.max = &f
+		}
+		var choices []discord.ApplicationCommandOptionChoiceInt
+		for name, value := range tagChoices(tag) {
+			v, err := strconv.Atoi(value)
+			if err != nil {
+				return field, fmt.Errorf("invalid choice %q: %w", value, err)
+			}
+			choices = append(choices, discord.ApplicationCommandOptionChoiceInt{Name: name, Value: v})
+			field.choices = append(field.choices, int64(v))
+		}
+		field.option = discord.ApplicationCommandOptionInt{
+			Name:                     field.name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 !field.optional,
+			Choices:                  choices,
+			Autocomplete:             tag.Get("autocomplete") == "true",
+			MinValue:                 minValue,
+			MaxValue:                 maxValue,
+		}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		field.optionType = discord.ApplicationCommandOptionTypeFloat
+		if field.min, err = tagFloat(tag, "min"); err != nil {
+			return field, err
+		}
+		if field.max, err = tagFloat(tag, "max"); err != nil {
+			return field, err
+		}
+		var choices []discord.ApplicationCommandOptionChoiceFloat
+		for name, value := range tagChoices(tag) {
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return field, fmt.Errorf("invalid choice %q: %w", value, err)
+			}
+			choices = append(choices, discord.ApplicationCommandOptionChoiceFloat{Name: name, Value: v})
+			field.choices = append(field.choices, v)
+		}
+		field.option = discord.ApplicationCommandOptionFloat{
+			Name:                     field.name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 !field.optional,
+			Choices:                  choices,
+			Autocomplete:             tag.Get("autocomplete") == "true",
+			MinValue:                 field.min,
+			MaxValue:                 field.max,
+		}
+	case t.Kind() == reflect.Bool:
+		field.optionType = discord.ApplicationCommandOptionTypeBool
+		field.option = discord.ApplicationCommandOptionBool{
+			Name:                     field.name,
+			NameLocalizations:        nameLocalizations,
+			Description:              description,
+			DescriptionLocalizations: descriptionLocalizations,
+			Required:                 !field.optional,
+		}
+	default:
+		return field, fmt.Errorf("unsupported type %s", structField.Type)
+	}
+	return field, nil
+}
+
+func (f optionField) decode(data discord.SlashCommandInteractionData, v reflect.Value) error {
+	option, ok := data.Option(f.name)
+	if !ok {
+		if f.optional {
+			return nil
+		}
+		return ErrOptionRequired
+	}
+	if option.Type != f.optionType {
+		return ErrOptionType
+	}
+
+	if f.optional {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+
+	var (
+		value    any
+		resolved bool
+	)
+	switch v.Type() {
+	case userType:
+		value, resolved = data.OptUser(f.name)
+	case resolvedMemberType:
+		value, resolved = data.OptMember(f.name)
+	case roleType:
+		value, resolved = data.OptRole(f.name)
+	case resolvedChannelType:
+		value, resolved = data.OptChannel(f.name)
+	case attachmentType:
+		value, resolved = data.OptAttachment(f.name)
+	case snowflakeType:
+		v.Set(reflect.ValueOf(option.Snowflake()))
+		return nil
+	default:
+		return f.decodeValue(option, v)
+	}
+	if !resolved {
+		return ErrOptionNotResolved
+	}
+	v.Set(reflect.ValueOf(value))
+	return nil
+}
+
+func (f optionField) decodeValue(option discord.SlashCommandOption, v reflect.Value) error {
+	var (
+		value  any
+		number *float64
+	)
+	switch f.optionType {
+	case discord.ApplicationCommandOptionTypeString:
+		s := option.String()
+		if length := len([]rune(s)); (f.minLength != nil && length < *f.minLength) || (f.maxLength != nil && length > *f.maxLength) {
+			return ErrOptionOutOfRange
+		}
+		v.SetString(s)
+		value = s
+	case discord.ApplicationCommandOptionTypeInt:
+		i := int64(option.Int())
+		if v.OverflowInt(i) {
+			return ErrOptionOutOfRange
+		}
+		v.SetInt(i)
+		value = i
+		f := float64(i)
+		number = &f
+	case discord.ApplicationCommandOptionTypeFloat:
+		fl := option.Float()
+		v.SetFloat(fl)
+		value = fl
+		number = &fl
+	case discord.ApplicationCommandOptionTypeBool:
+		v.SetBool(option.Bool())
+		return nil
+	}
+
+	if number != nil && ((f.min != nil && *number < *f.min) || (f.max != nil && *number > *f.max)) {
+		return ErrOptionOutOfRange
+	}
+	if len(f.choices) > 0 && !slices.Contains(f.choices, value) {
+		return ErrOptionInvalidChoice
+	}
+	return nil
+}
+
+// snakeCase converts a Go field name like MaxAge to max_age.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// only start a new word if the previous rune is lower case or the next one is, e.g. UserID -> user_id & IDList -> id_list
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func tagLocalizations(tag reflect.StructTag) (map[discord.Locale]string, map[discord.Locale]string) {
+	var names, descriptions map[discord.Locale]string
+	for locale := range discord.Locales {
+		if name, ok := tag.Lookup("name_" + locale.Code()); ok {
+			if names == nil {
+				names = map[discord.Locale]string{}
+			}
+			names[locale] = name
+		}
+		if description, ok := tag.Lookup("description_" + locale.Code()); ok {
+			if descriptions == nil {
+				descriptions = map[discord.Locale]string{}
+			}
+			descriptions[locale] = description
+		}
+	}
+	return names, descriptions
+}
+
+func tagInt(tag reflect.StructTag, key string) (*int, error) {
+	s, ok := tag.Lookup(key)
+	if !ok {
+		return nil, nil
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s tag: %w", key, err)
+	}
+	return &i, nil
+}
+
+func tagFloat(tag reflect.StructTag, key string) (*float64, error) {
+	s, ok := tag.Lookup(key)
+	if !ok {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s tag: %w", key, err)
+	}
+	return &f, nil
+}
+
+// tagChoices returns the choices of the tag as name & value pairs in their order.
+func tagChoices(tag reflect.StructTag) func(yield func(string, string) bool) {
+	return func(yield func(string, string) bool) {
+		s, ok := tag.Lookup("choices")
+		if !ok {
+			return
+		}
+		for choice := range strings.SplitSeq(s, ";") {
+			name, value, ok := strings.Cut(choice, "=")
+			if !ok {
+				value = name
+			}
+			if !yield(name, value) {
+				return
+			}
+		}
+	}
+}
+
+func tagChannelTypes(tag reflect.StructTag) ([]discord.ChannelType, error) {
+	s, ok := tag.Lookup("channel_types")
+	if !ok {
+		return nil, nil
+	}
+	var channelTypes []discord.ChannelType
+	for channelType := range strings.SplitSeq(s, ",") {
+		i, err := strconv.Atoi(strings.TrimSpace(channelType))
+		if err != nil {
+			return nil, fmt.Errorf("invalid channel_types tag: %w", err)
+		}
+		channelTypes = append(channelTypes, discord.ChannelType(i))
+	}
+	return channelTypes, nil
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+type banOptions struct {
+	Reason  *string                  `description:"The reason of the ban" max_length:"10" description_de:"Der Grund des Banns"`
+	User    discord.User             `description:"The user to ban" name_de:"benutzer"`
+	Days    int                      `description:"The days of messages to delete" min:"0" max:"7"`
+	Mode    string                   `description:"The mode of the ban" choices:"Soft Ban=soft;Hard Ban=hard"`
+	Channel *discord.ResolvedChannel `description:"The channel to log the ban in" channel_types:"0,5"`
+	Ignored string                   `name:"-"`
+}
+
+const banInteraction = `{
+	"type": 2,
+	"id": "786008729715212338",
+	"token": "A_UNIQUE_TOKEN",
+	"guild_id": "290926798626357999",
+	"channel_id": "645027906669510667",
+	"locale": "en-US",
+	"member": {"user": {"id": "53908232506183680", "username": "Mason"}, "roles": [], "permissions": "2147483647", "joined_at": "2017-03-13T19:19:14.040000+00:00"},
+	"data": {
+		"type": 1,
+		"name": "ban",
+		"id": "771825006014889984",
+		"options": [
+			{"name": "user", "type": 6, "value": "53908232506183681"},
+			{"name": "days", "type": 4, "value": %s},
+			{"name": "mode", "type": 3, "value": "%s"}
+		],
+		"resolved": {"users": {"53908232506183681": {"id": "53908232506183681", "username": "Banned"}}}
+	}
+}`
+
+func TestSlashCommandOptions(t *testing.T) {
+	options, err := SlashCommandOptions[banOptions]()
+	if err != nil {
+		t.Fatalf("failed to derive options: %v", err)
+	}
+
+	zero := 0
+	seven := 7
+	ten := 10
+	expected := []discord.ApplicationCommandOption{
+		discord.ApplicationCommandOptionUser{
+			Name:              "user",
+			NameLocalizations: map[discord.Locale]string{discord.LocaleGerman: "benutzer"},
+			Description:       "The user to ban",
+			Required:          true,
+		},
+		discord.ApplicationCommandOptionInt{
+			Name:        "days",
+			Description: "The days of messages to delete",
+			Required:    true,
+			MinValue:    &zero,
+			MaxValue:    &seven,
+		},
+		discord.ApplicationCommandOptionString{
+			Name:        "mode",
+			Description: "The mode of the ban",
+			Required:    true,
+			Choices: []discord.ApplicationCommandOptionChoiceString{
+				{Name: "Soft Ban", Value: "soft"},
+				{Name: "Hard Ban", Value: "hard"},
+			},
+		},
+		discord.ApplicationCommandOptionString{
+			Name:                     "reason",
+			Description:              "The reason of the ban",
+			DescriptionLocalizations: map[discord.Locale]string{discord.LocaleGerman: "Der Grund des Banns"},
+			MaxLength:                &ten,
+		},
+		discord.ApplicationCommandOptionChannel{
+			Name:         "channel",
+			Description:  "The channel to log the ban in",
+			ChannelTypes: []discord.ChannelType{discord.ChannelTypeGuildText, discord.ChannelTypeGuildNews},
+		},
+	}
+	if !reflect.DeepEqual(expected, options) {
+		t.Errorf("expected %+v, got %+v", expected, options)
+	}
+
+	if _, err = SlashCommandOptions[struct {
+		Name string
+	}](); err == nil {
+		t.Error("expected error for missing description")
+	}
+	if _, err = SlashCommandOptions[struct {
+		Names []string `description:"names"`
+	}](); err == nil {
+		t.Error("expected error for unsupported type")
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	for name, expected := range map[string]string{
+		"User":       "user",
+		"MaxAge":     "max_age",
+		"UserID":     "user_id",
+		"IDList":     "id_list",
+		"DeleteDays": "delete_days",
+	} {
+		if s := snakeCase(name); s != expected {
+			t.Errorf("expected %q for %q, got %q", expected, name, s)
+		}
+	}
+}
+
+func TestBindSlashCommand(t *testing.T) {
+	var (
+		options    banOptions
+		handled    int
+		handlerErr error
+	)
+	mux := New()
+	mux.SlashCommand("/ban", BindSlashCommand(func(o banOptions, data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		options = o
+		handled++
+		return nil
+	}))
+	mux.Error(func(e *InteractionEvent, err error) {
+		handlerErr = err
+	})
+
+	run := func(days string, mode string) {
+		interaction, err := discord.UnmarshalInteraction([]byte(fmt.Sprintf(banInteraction, days, mode)))
+		if err != nil {
+			t.Fatalf("failed to unmarshal interaction: %v", err)
+		}
+		mux.OnEvent(&events.InteractionCreate{
+			GenericEvent: events.NewGenericEvent(nil, 0, 0),
+			Interaction:  interaction,
+			Respond:      NewRecorder().Respond,
+		})
+	}
+
+	run("3", "soft")
+	if handled != 1 || handlerErr != nil {
+		t.Fatalf("expected handler to be called without error, got %d calls & %v", handled, handlerErr)
+	}
+	if options.User.ID != snowflake.ID(53908232506183681) || options.User.Username != "Banned" || options.Days != 3 || options.Mode != "soft" || options.Reason != nil || options.Channel != nil {
+		t.Errorf("unexpected options %+v", options)
+	}
+
+	var optionErr *OptionError
+	run("8", "soft")
+	if !errors.As(handlerErr, &optionErr) || optionErr.Option != "days" || !errors.Is(handlerErr, ErrOptionOutOfRange) {
+		t.Errorf("expected days to be out of range, got %v", handlerErr)
+	}
+	run("3", "medium")
+	if !errors.As(handlerErr, &optionErr) || optionErr.Option != "mode" || !errors.Is(handlerErr, ErrOptionInvalidChoice) {
+		t.Errorf("expected mode to be an invalid choice, got %v", handlerErr)
+	}
+	if handled != 1 {
+		t.Errorf("expected handler not to be called for invalid options, got %d calls", handled)
+	}
+}
@@ -25,6 +25,9 @@ type config struct {
 	CertFile   string
 	KeyFile    string
 	Verifier   Verifier
+
+	WebhookEventsURL        string
+	WebhookEventHandlerFunc WebhookEventHandlerFunc
 }
 
 // ConfigOpt is a type alias for a function that takes a config and is used to configure your Server.
@@ -86,3 +89,12 @@ func WithVerifier(verifier Verifier) ConfigOpt {
 		config.Verifier = verifier
 	}
 }
+
+// WithWebhookEvents mounts a handler for Discord's Webhook Events at the given URL, which calls the given WebhookEventHandlerFunc.
+// The URL has to be set as Webhook Events URL in the developer portal of your application.
+func WithWebhookEvents(url string, handlerFunc WebhookEventHandlerFunc) ConfigOpt {
+	return func(config *config) {
+		config.WebhookEventsURL = url
+		config.WebhookEventHandlerFunc = handlerFunc
+	}
+}
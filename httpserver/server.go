@@ -9,7 +9,7 @@ import (
 	"net/http"
 )
 
-// Server is used for receiving Discord's interactions via Outgoing Webhooks
+// Server is used for receiving Discord's interactions via Outgoing Webhooks and Discord's Webhook Events
 type Server interface {
 	// Start starts the Server
 	Start()
@@ -47,6 +47,9 @@ type serverImpl struct {
 
 func (s *serverImpl) Start() {
 	s.config.ServeMux.Handle(s.config.URL, HandleInteraction(s.verifier, s.publicKey, s.config.Logger, s.eventHandlerFunc))
+	if s.config.WebhookEventsURL != "" && s.config.WebhookEventHandlerFunc != nil {
+		s.config.ServeMux.Handle(s.config.WebhookEventsURL, HandleWebhookEvent(s.verifier, s.publicKey, s.config.Logger, s.config.WebhookEventHandlerFunc))
+	}
 	s.config.HTTPServer.Addr = s.config.Address
 	s.config.HTTPServer.Handler = s.config.ServeMux
 
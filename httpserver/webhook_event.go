@@ -0,0 +1,48 @@
+package httpserver
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/disgoorg/json/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// WebhookEventHandlerFunc is used to handle events from Discord's Webhook Events
+type WebhookEventHandlerFunc func(payload discord.WebhookEventPayload)
+
+// HandleWebhookEvent handles a webhook event from Discord's Webhook Events. It verifies and parses the payload and then calls the passed WebhookEventHandlerFunc.
+// Pings are acknowledged without calling the WebhookEventHandlerFunc. All other events are acknowledged before the WebhookEventHandlerFunc is called, as Discord expects a response within 3 seconds.
+// See https://discord.com/developers/docs/events/webhook-events
+func HandleWebhookEvent(verifier Verifier, publicKey PublicKey, logger *slog.Logger, handleFunc WebhookEventHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if ok := VerifyRequest(verifier, r, publicKey); !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			data, _ := io.ReadAll(r.Body)
+			logger.Debug("received webhook event with invalid signature", slog.String("body", string(data)))
+			return
+		}
+
+		defer func() {
+			_ = r.Body.Close()
+		}()
+
+		rqData, _ := io.ReadAll(r.Body)
+		logger.Debug("received webhook event", slog.String("body", string(rqData)))
+
+		var v discord.WebhookEventPayload
+		if err := json.Unmarshal(rqData, &v); err != nil {
+			logger.Error("error while decoding webhook event", slog.Any("err", err))
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+		if v.Type == discord.WebhookEventPayloadTypePing || v.Event == nil {
+			return
+		}
+		go handleFunc(v)
+	}
+}
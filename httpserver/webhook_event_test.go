@@ -0,0 +1,77 @@
+package httpserver
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestHandleWebhookEvent(t *testing.T) {
+	t.Parallel()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payloads := make(chan discord.WebhookEventPayload, 1)
+	handler := HandleWebhookEvent(DefaultVerifier{}, publicKey, slog.Default(), func(payload discord.WebhookEventPayload) {
+		payloads <- payload
+	})
+
+	do := func(body string, sign bool) int {
+		rq := httptest.NewRequest(http.MethodPost, "/webhook-events", strings.NewReader(body))
+		timestamp := "1729262573"
+		signature := ed25519.Sign(privateKey, []byte(timestamp+body))
+		if !sign {
+			signature[0] ^= 0xff
+		}
+		rq.Header.Set("X-Signature-Ed25519", hex.EncodeToString(signature))
+		rq.Header.Set("X-Signature-Timestamp", timestamp)
+
+		rs := httptest.NewRecorder()
+		handler(rs, rq)
+		return rs.Code
+	}
+
+	if code := do(`{"version":1,"application_id":"1","type":0}`, true); code != http.StatusNoContent {
+		t.Errorf("expected ping to be acknowledged with 204, got %d", code)
+	}
+	if code := do(`{"version":1,"application_id":"1","type":0}`, false); code != http.StatusUnauthorized {
+		t.Errorf("expected invalid signature to be rejected with 401, got %d", code)
+	}
+
+	body := `{"version":1,"application_id":"1","type":1,"event":{"type":"APPLICATION_AUTHORIZED","timestamp":"2024-10-18T14:42:53.064834","data":{"integration_type":1,"scopes":["applications.commands"],"user":{"id":"2","username":"test"}}}}`
+	if code := do(body, true); code != http.StatusNoContent {
+		t.Errorf("expected event to be acknowledged with 204, got %d", code)
+	}
+
+	select {
+	case payload := <-payloads:
+		data, ok := payload.Event.Data.(discord.WebhookEventApplicationAuthorized)
+		if !ok {
+			t.Fatalf("expected application authorized data, got %T", payload.Event.Data)
+		}
+		if data.User.ID != 2 || data.IntegrationType == nil || *data.IntegrationType != discord.ApplicationIntegrationTypeUserInstall {
+			t.Errorf("unexpected application authorized data: %+v", data)
+		}
+		if expected := time.Date(2024, 10, 18, 14, 42, 53, 64834000, time.UTC); !payload.Event.Timestamp.Equal(expected) {
+			t.Errorf("expected timestamp %s, got %s", expected, payload.Event.Timestamp)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected event to be handled")
+	}
+
+	select {
+	case payload := <-payloads:
+		t.Errorf("expected only one event to be handled, got %+v", payload)
+	default:
+	}
+}
@@ -0,0 +1,142 @@
+package i18n
+
+import (
+	"github.com/disgoorg/disgo/discord"
+)
+
+// LocalizeCommands returns a copy of the commands with their name & description localizations filled from the catalog, which can then be synced with handler.SyncCommands or handler.SyncCommandsDiff.
+// Localizations which are already set are kept. The keys are derived from the names of the commands, options & choices:
+//   - commands.<command>.name & commands.<command>.description
+//   - commands.<command>.options.<option>.name & commands.<command>.options.<option>.description
+//   - commands.<command>.options.<option>.choices.<choice> for the names of choices
+//
+// Options of sub commands & sub command groups are nested the same way, e.g. commands.<command>.options.<group>.options.<sub command>.name.
+func (c *Catalog) LocalizeCommands(commands []discord.ApplicationCommandCreate) []discord.ApplicationCommandCreate {
+	localized := make([]discord.ApplicationCommandCreate, len(commands))
+	for i, command := range commands {
+		localized[i] = c.LocalizeCommand(command)
+	}
+	return localized
+}
+
+// LocalizeCommand works like LocalizeCommands for a single command.
+func (c *Catalog) LocalizeCommand(command discord.ApplicationCommandCreate) discord.ApplicationCommandCreate {
+	prefix := "commands." + command.CommandName()
+	switch cmd := command.(type) {
+	case discord.SlashCommandCreate:
+		cmd.NameLocalizations = c.localize(cmd.NameLocalizations, prefix+".name")
+		cmd.DescriptionLocalizations = c.localize(cmd.DescriptionLocalizations, prefix+".description")
+		cmd.Options = c.localizeOptions(cmd.Options, prefix)
+		return cmd
+	case discord.UserCommandCreate:
+		cmd.NameLocalizations = c.localize(cmd.NameLocalizations, prefix+".name")
+		return cmd
+	case discord.MessageCommandCreate:
+		cmd.NameLocalizations = c.localize(cmd.NameLocalizations, prefix+".name")
+		return cmd
+	case discord.EntryPointCommandCreate:
+		cmd.NameLocalizations = c.localize(cmd.NameLocalizations, prefix+".name")
+		return cmd
+	default:
+		return command
+	}
+}
+
+func (c *Catalog) localizeOptions(options []discord.ApplicationCommandOption, prefix string) []discord.ApplicationCommandOption {
+	if len(options) == 0 {
+		return options
+	}
+	localized := make([]discord.ApplicationCommandOption, len(options))
+	for i, option := range options {
+		localized[i] = c.localizeOption(option, prefix+".options."+option.OptionName())
+	}
+	return localized
+}
+
+func (c *Catalog) localizeOption(option discord.ApplicationCommandOption, prefix string) discord.ApplicationCommandOption {
+	switch o := option.(type) {
+	case discord.ApplicationCommandOptionSubCommand:
+		o.NameLocalizations, o.DescriptionLocalizations = c.localizeNameDescription(o.NameLocalizations, o.DescriptionLocalizations, prefix)
+		o.Options = c.localizeOptions(o.Options, prefix)
+		return o
+	case discord.ApplicationCommandOptionSubCommandGroup:
+		o.NameLocalizations, o.DescriptionLocalizations = c.localizeNameDescription(o.NameLocalizations, o.DescriptionLocalizations, prefix)
+		if len(o.Options) > 0 {
+			subCommands := make([]discord.ApplicationCommandOptionSubCommand, len(o.Options))
+			for i, subCommand := range o.Options {
+				subCommands[i] = c.localizeOption(subCommand, prefix+".options."+subCommand.Name).(discord.ApplicationCommandOptionSubCommand)
+			}
+			o.Options = subCommands
+		}
+		return o
+	case discord.ApplicationCommandOptionString:
+		o.NameLocalizations, o.DescriptionLocalizations = c.localizeNameDescription(o.NameLocalizations, o.DescriptionLocalizations, prefix)
+		if len(o.Choices) > 0 {
+			choices := make([]discord.ApplicationCommandOptionChoiceString, len(o.Choices))
+			for i, choice := range o.Choices {
+				choice.NameLocalizations = c.localize(choice.NameLocalizations, prefix+".choices."+choice.Name)
+				choices[i] = choice
+			}
+			o.Choices = choices
+		}
+		return o
+	case discord.ApplicationCommandOptionInt:
+		o.NameLocalizations, o.DescriptionLocalizations = c.localizeNameDescription(o.NameLocalizations, o.DescriptionLocalizations, prefix)
+		if len(o.Choices) > 0 {
+			choices := make([]discord.ApplicationCommandOptionChoiceInt, len(o.Choices))
+			for i, choice := range o.Choices {
+				choice.NameLocalizations = c.localize(choice.NameLocalizations, prefix+".choices."+choice.Name)
+				choices[i] = choice
+			}
+			o.Choices = choices
+		}
+		return o
+	case discord.ApplicationCommandOptionFloat:
+		o.NameLocalizations, o.DescriptionLocalizations = c.localizeNameDescription(o.NameLocalizations, o.DescriptionLocalizations, prefix)
+		if len(o.Choices) > 0 {
+			choices := make([]discord.ApplicationCommandOptionChoiceFloat, len(o.Choices))
+			for i, choice := range o.Choices {
+				choice.NameLocalizations = c.localize(choice.NameLocalizations, prefix+".choices."+choice.Name)
+				choices[i] = choice
+			}
+			o.Choices = choices
+		}
+		return o
+	case discord.ApplicationCommandOptionBool:
+		o.NameLocalizations, o.DescriptionLocalizations = c.localizeNameDescription(o.NameLocalizations, o.DescriptionLocalizations, prefix)
+		return o
+	case discord.ApplicationCommandOptionUser:
+		o.NameLocalizations, o.DescriptionLocalizations = c.localizeNameDescription(o.NameLocalizations, o.DescriptionLocalizations, prefix)
+		return o
+	case discord.ApplicationCommandOptionChannel:
+		o.NameLocalizations, o.DescriptionLocalizations = c.localizeNameDescription(o.NameLocalizations, o.DescriptionLocalizations, prefix)
+		return o
+	case discord.ApplicationCommandOptionRole:
+		o.NameLocalizations, o.DescriptionLocalizations = c.localizeNameDescription(o.NameLocalizations, o.DescriptionLocalizations, prefix)
+		return o
+	case discord.ApplicationCommandOptionMentionable:
+		o.NameLocalizations, o.DescriptionLocalizations = c.localizeNameDescription(o.NameLocalizations, o.DescriptionLocalizations, prefix)
+		return o
+	case discord.ApplicationCommandOptionAttachment:
+		o.NameLocalizations, o.DescriptionLocalizations = c.localizeNameDescription(o.NameLocalizations, o.DescriptionLocalizations, prefix)
+		return o
+	default:
+		return option
+	}
+}
+
+func (c *Catalog) localizeNameDescription(names map[discord.Locale]string, descriptions map[discord.Locale]string, prefix string) (map[discord.Locale]string, map[discord.Locale]string) {
+	return c.localize(names, prefix+".name"), c.localize(descriptions, prefix+".description")
+}
+
+// localize returns a copy of the localizations with the missing ones filled from the catalog.
+func (c *Catalog) localize(localizations map[discord.Locale]string, key string) map[discord.Locale]string {
+	catalogLocalizations := c.Localizations(key)
+	if len(catalogLocalizations) == 0 {
+		return localizations
+	}
+	for locale, localization := range localizations {
+		catalogLocalizations[locale] = localization
+	}
+	return catalogLocalizations
+}
@@ -0,0 +1,276 @@
+// Package i18n provides a translation catalog for localizing application commands & interaction responses.
+//
+// Messages are loaded per discord.Locale from message files like locales/de.json, which map keys to messages.
+// Nested objects are flattened to dotted keys & objects with plural categories as keys are plural messages:
+//
+//	{
+//		"ping": {"name": "ping", "description": "Pingt den Bot"},
+//		"pong": "Pong! Latenz: {latency}",
+//		"messages_deleted": {"zero": "Keine Nachrichten gelöscht", "one": "{count} Nachricht gelöscht", "other": "{count} Nachrichten gelöscht"}
+//	}
+//
+// Placeholders like {latency} are replaced by the arguments passed as key value pairs, e.g. T(locale, "pong", "latency", latency).
+// The plural form is chosen by the "count" argument according to the plural rules of the locale.
+package i18n
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/disgoorg/json/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// UnmarshalFunc decodes a message file. It is compatible with json.Unmarshal & the Unmarshal functions of most TOML & YAML libraries.
+type UnmarshalFunc func(data []byte, v any) error
+
+// New returns a new empty Catalog which falls back to the given default locale.
+func New(defaultLocale discord.Locale) *Catalog {
+	return &Catalog{
+		defaultLocale: defaultLocale,
+		messages:      map[discord.Locale]map[string]message{},
+	}
+}
+
+// Catalog contains the messages of all locales. It is safe for concurrent use.
+type Catalog struct {
+	defaultLocale discord.Locale
+
+	mu       sync.RWMutex
+	messages map[discord.Locale]map[string]message
+}
+
+// message is a translated message. Simple messages only have PluralOther set.
+type message map[PluralCategory]string
+
+// DefaultLocale returns the locale used when a message is missing in all requested locales.
+func (c *Catalog) DefaultLocale() discord.Locale {
+	return c.defaultLocale
+}
+
+// Locales returns all locales with messages.
+func (c *Catalog) Locales() []discord.Locale {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	locales := make([]discord.Locale, 0, len(c.messages))
+	for locale := range c.messages {
+		locales = append(locales, locale)
+	}
+	return locales
+}
+
+// Add adds the messages of the locale, overwriting existing messages with the same keys.
+// The messages are in the same format as the message files, so the values can be strings, plural maps or nested maps.
+func (c *Catalog) Add(locale discord.Locale, messages map[string]any) error {
+	flattened := map[string]message{}
+	if err := flatten(flattened, "", messages); err != nil {
+		return fmt.Errorf("invalid messages for locale %s: %w", locale, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	existing, ok := c.messages[locale]
+	if !ok {
+		c.messages[locale] = flattened
+		return nil
+	}
+	for key, msg := range flattened {
+		existing[key] = msg
+	}
+	return nil
+}
+
+// Load decodes the message file with the given UnmarshalFunc & adds its messages to the locale.
+// If unmarshal is nil, json.Unmarshal is used.
+func (c *Catalog) Load(locale discord.Locale, data []byte, unmarshal UnmarshalFunc) error {
+	if unmarshal == nil {
+		unmarshal = json.Unmarshal
+	}
+	var messages map[string]any
+	if err := unmarshal(data, &messages); err != nil {
+		return fmt.Errorf("failed to decode messages for locale %s: %w", locale, err)
+	}
+	return c.Add(locale, messages)
+}
+
+// LoadFS loads all message files of the fs.FS matching the pattern, like "locales/*.json".
+// The locale of each file is its name without the extension, like de or en-US. If unmarshal is nil, json.Unmarshal is used.
+//
+//	//go:embed locales
+//	var locales embed.FS
+//
+//	catalog := i18n.New(discord.LocaleEnglishUS)
+//	err := catalog.LoadFS(locales, "locales/*.toml", toml.Unmarshal)
+func (c *Catalog) LoadFS(fsys fs.FS, pattern string, unmarshal UnmarshalFunc) error {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+		name := path.Base(file)
+		locale := discord.Locale(strings.TrimSuffix(name, path.Ext(name)))
+		if err = c.Load(locale, data, unmarshal); err != nil {
+			return fmt.Errorf("failed to load %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// Has returns whether the locale has a message for the key.
+func (c *Catalog) Has(locale discord.Locale, key string) bool {
+	_, ok := c.message(locale, key)
+	return ok
+}
+
+// T returns the message for the key in the locale, falling back to the default locale.
+// The args are key value pairs, which replace the placeholders of the message & select its plural form by the "count" argument.
+// If no locale has the message, the key is returned.
+func (c *Catalog) T(locale discord.Locale, key string, args ...any) string {
+	return c.Translate([]discord.Locale{locale}, key, args...)
+}
+
+// Translate works like T, but tries all given locales in order before falling back to the default locale.
+func (c *Catalog) Translate(locales []discord.Locale, key string, args ...any) string {
+	for _, locale := range locales {
+		if msg, ok := c.message(locale, key); ok {
+			return msg.format(locale, args)
+		}
+	}
+	if msg, ok := c.message(c.defaultLocale, key); ok {
+		return msg.format(c.defaultLocale, args)
+	}
+	return key
+}
+
+// Localizations returns the messages for the key in all locales except the default locale, which is what the NameLocalizations & DescriptionLocalizations fields expect.
+// It returns nil if no locale has the message.
+func (c *Catalog) Localizations(key string, args ...any) map[discord.Locale]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var localizations map[discord.Locale]string
+	for locale, messages := range c.messages {
+		if locale == c.defaultLocale {
+			continue
+		}
+		msg, ok := messages[key]
+		if !ok {
+			continue
+		}
+		if localizations == nil {
+			localizations = map[discord.Locale]string{}
+		}
+		localizations[locale] = msg.format(locale, args)
+	}
+	return localizations
+}
+
+func (c *Catalog) message(locale discord.Locale, key string) (message, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	msg, ok := c.messages[locale][key]
+	return msg, ok
+}
+
+func (m message) format(locale discord.Locale, args []any) string {
+	s := m[PluralOther]
+	if len(m) > 1 {
+		if count, hasCount := countArg(args); hasCount {
+			if zero, hasZero := m[PluralZero]; hasZero && count == 0 {
+				s = zero
+			} else if plural, hasPlural := m[PluralCategoryOf(locale, count)]; hasPlural {
+				s = plural
+			}
+		}
+	}
+	if len(args) < 2 || !strings.Contains(s, "{") {
+		return s
+	}
+
+	oldNew := make([]string, 0, len(args))
+	for i := 0; i+1 < len(args); i += 2 {
+		oldNew = append(oldNew, "{"+fmt.Sprint(args[i])+"}", fmt.Sprint(args[i+1]))
+	}
+	return strings.NewReplacer(oldNew...).Replace(s)
+}
+
+// countArg returns the value of the "count" argument as an integer.
+func countArg(args []any) (int64, bool) {
+	for i := 0; i+1 < len(args); i += 2 {
+		if key, ok := args[i].(string); !ok || key != "count" {
+			continue
+		}
+		switch count := args[i+1].(type) {
+		case int:
+			return int64(count), true
+		case int8:
+			return int64(count), true
+		case int16:
+			return int64(count), true
+		case int32:
+			return int64(count), true
+		case int64:
+			return count, true
+		case uint:
+			return int64(count), true
+		case uint8:
+			return int64(count), true
+		case uint16:
+			return int64(count), true
+		case uint32:
+			return int64(count), true
+		case uint64:
+			return int64(count), true
+		case float64:
+			return int64(count), count == float64(int64(count))
+		}
+		return 0, false
+	}
+	return 0, false
+}
+
+func flatten(messages map[string]message, prefix string, values map[string]any) error {
+	for key, value := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case string:
+			messages[key] = message{PluralOther: v}
+		case map[string]any:
+			if msg, ok := pluralMessage(v); ok {
+				messages[key] = msg
+				continue
+			}
+			if err := flatten(messages, key, v); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("invalid message %s of type %T", key, value)
+		}
+	}
+	return nil
+}
+
+// pluralMessage returns the plural message if all keys of the values are plural categories & the "other" category is set.
+func pluralMessage(values map[string]any) (message, bool) {
+	if _, ok := values[string(PluralOther)]; !ok {
+		return nil, false
+	}
+	msg := make(message, len(values))
+	for key, value := range values {
+		s, ok := value.(string)
+		if !ok || !PluralCategory(key).valid() {
+			return nil, false
+		}
+		msg[PluralCategory(key)] = s
+	}
+	return msg, true
+}
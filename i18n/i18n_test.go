@@ -0,0 +1,126 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func testCatalog(t *testing.T) *Catalog {
+	fsys := fstest.MapFS{
+		"locales/en-US.json": {Data: []byte(`{
+			"pong": "Pong! Latency: {latency}",
+			"deleted": {"zero": "No messages deleted", "one": "{count} message deleted", "other": "{count} messages deleted"},
+			"commands": {"purge": {"name": "purge", "description": "Delete messages"}}
+		}`)},
+		"locales/de.json": {Data: []byte(`{
+			"pong": "Pong! Latenz: {latency}",
+			"commands": {"purge": {
+				"name": "löschen",
+				"description": "Nachrichten löschen",
+				"options": {"mode": {"name": "modus", "choices": {"All": "Alle"}}}
+			}}
+		}`)},
+		"locales/ru.json": {Data: []byte(`{
+			"deleted": {"one": "Удалено {count} сообщение", "few": "Удалено {count} сообщения", "many": "Удалено {count} сообщений", "other": "Удалено {count} сообщения"}
+		}`)},
+	}
+
+	catalog := New(discord.LocaleEnglishUS)
+	if err := catalog.LoadFS(fsys, "locales/*.json", nil); err != nil {
+		t.Fatalf("failed to load catalog: %v", err)
+	}
+	return catalog
+}
+
+func TestCatalog_Translate(t *testing.T) {
+	catalog := testCatalog(t)
+
+	tests := []struct {
+		locales  []discord.Locale
+		key      string
+		args     []any
+		expected string
+	}{
+		{locales: []discord.Locale{discord.LocaleGerman}, key: "pong", args: []any{"latency", "42ms"}, expected: "Pong! Latenz: 42ms"},
+		{locales: []discord.Locale{discord.LocaleFrench, discord.LocaleGerman}, key: "pong", args: []any{"latency", "42ms"}, expected: "Pong! Latenz: 42ms"},
+		{locales: []discord.Locale{discord.LocaleFrench}, key: "pong", args: []any{"latency", "42ms"}, expected: "Pong! Latency: 42ms"},
+		{locales: []discord.Locale{discord.LocaleGerman}, key: "deleted", args: []any{"count", 0}, expected: "No messages deleted"},
+		{locales: []discord.Locale{discord.LocaleGerman}, key: "deleted", args: []any{"count", 1}, expected: "1 message deleted"},
+		{locales: []discord.Locale{discord.LocaleGerman}, key: "deleted", args: []any{"count", 5}, expected: "5 messages deleted"},
+		{locales: []discord.Locale{discord.LocaleRussian}, key: "deleted", args: []any{"count", 21}, expected: "Удалено 21 сообщение"},
+		{locales: []discord.Locale{discord.LocaleRussian}, key: "deleted", args: []any{"count", 3}, expected: "Удалено 3 сообщения"},
+		{locales: []discord.Locale{discord.LocaleRussian}, key: "deleted", args: []any{"count", 11}, expected: "Удалено 11 сообщений"},
+		{locales: []discord.Locale{discord.LocaleGerman}, key: "missing", expected: "missing"},
+	}
+	for _, tt := range tests {
+		if s := catalog.Translate(tt.locales, tt.key, tt.args...); s != tt.expected {
+			t.Errorf("expected %q for %s in %v, got %q", tt.expected, tt.key, tt.locales, s)
+		}
+	}
+}
+
+func TestPluralCategoryOf(t *testing.T) {
+	tests := []struct {
+		locale   discord.Locale
+		count    int64
+		expected PluralCategory
+	}{
+		{locale: discord.LocaleEnglishUS, count: 1, expected: PluralOne},
+		{locale: discord.LocaleEnglishUS, count: 0, expected: PluralOther},
+		{locale: discord.LocaleFrench, count: 0, expected: PluralOne},
+		{locale: discord.LocaleJapanese, count: 1, expected: PluralOther},
+		{locale: discord.LocalePolish, count: 22, expected: PluralFew},
+		{locale: discord.LocalePolish, count: 12, expected: PluralMany},
+		{locale: discord.LocaleCzech, count: 3, expected: PluralFew},
+		{locale: discord.LocaleLithuanian, count: 11, expected: PluralOther},
+		{locale: discord.LocaleRomanian, count: 119, expected: PluralFew},
+		{locale: discord.LocaleRomanian, count: 20, expected: PluralOther},
+	}
+	for _, tt := range tests {
+		if category := PluralCategoryOf(tt.locale, tt.count); category != tt.expected {
+			t.Errorf("expected %s for %d in %s, got %s", tt.expected, tt.count, tt.locale, category)
+		}
+	}
+}
+
+func TestCatalog_LocalizeCommands(t *testing.T) {
+	catalog := testCatalog(t)
+
+	commands := catalog.LocalizeCommands([]discord.ApplicationCommandCreate{
+		discord.SlashCommandCreate{
+			Name:                     "purge",
+			Description:              "Delete messages",
+			DescriptionLocalizations: map[discord.Locale]string{discord.LocaleGerman: "Nachrichten entfernen"},
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionString{
+					Name:        "mode",
+					Description: "Which messages to delete",
+					Choices:     []discord.ApplicationCommandOptionChoiceString{{Name: "All", Value: "all"}},
+				},
+			},
+		},
+	})
+
+	expected := discord.SlashCommandCreate{
+		Name:                     "purge",
+		NameLocalizations:        map[discord.Locale]string{discord.LocaleGerman: "löschen"},
+		Description:              "Delete messages",
+		DescriptionLocalizations: map[discord.Locale]string{discord.LocaleGerman: "Nachrichten entfernen"},
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionString{
+				Name:              "mode",
+				NameLocalizations: map[discord.Locale]string{discord.LocaleGerman: "modus"},
+				Description:       "Which messages to delete",
+				Choices: []discord.ApplicationCommandOptionChoiceString{
+					{Name: "All", NameLocalizations: map[discord.Locale]string{discord.LocaleGerman: "Alle"}, Value: "all"},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, commands[0]) {
+		t.Errorf("expected %+v, got %+v", expected, commands[0])
+	}
+}
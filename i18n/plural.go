@@ -0,0 +1,110 @@
+package i18n
+
+import (
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// PluralCategory is a CLDR plural category, which selects the form of a plural message.
+type PluralCategory string
+
+// Constants for PluralCategory
+const (
+	// PluralZero is used for a count of 0 if a message sets it, regardless of the plural rules of the locale.
+	PluralZero  PluralCategory = "zero"
+	PluralOne   PluralCategory = "one"
+	PluralTwo   PluralCategory = "two"
+	PluralFew   PluralCategory = "few"
+	PluralMany  PluralCategory = "many"
+	PluralOther PluralCategory = "other"
+)
+
+func (c PluralCategory) valid() bool {
+	switch c {
+	case PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther:
+		return true
+	}
+	return false
+}
+
+// PluralCategoryOf returns the plural category of the count in the locale according to the CLDR plural rules for integers.
+// Unknown locales use the English rules.
+func PluralCategoryOf(locale discord.Locale, count int64) PluralCategory {
+	n := count
+	if n < 0 {
+		n = -n
+	}
+	mod10 := n % 10
+	mod100 := n % 100
+
+	language, _, _ := strings.Cut(locale.Code(), "-")
+	switch language {
+	case "id", "ja", "ko", "th", "vi", "zh":
+		return PluralOther
+	case "fr", "hi", "pt":
+		if n == 0 || n == 1 {
+			return PluralOne
+		}
+		return PluralOther
+	case "ru", "uk":
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return PluralOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return PluralFew
+		default:
+			return PluralMany
+		}
+	case "hr":
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return PluralOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return PluralFew
+		default:
+			return PluralOther
+		}
+	case "pl":
+		switch {
+		case n == 1:
+			return PluralOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return PluralFew
+		default:
+			return PluralMany
+		}
+	case "cs":
+		switch {
+		case n == 1:
+			return PluralOne
+		case n >= 2 && n <= 4:
+			return PluralFew
+		default:
+			return PluralOther
+		}
+	case "lt":
+		switch {
+		case mod10 == 1 && (mod100 < 11 || mod100 > 19):
+			return PluralOne
+		case mod10 >= 2 && (mod100 < 11 || mod100 > 19):
+			return PluralFew
+		default:
+			return PluralOther
+		}
+	case "ro":
+		switch {
+		case n == 1:
+			return PluralOne
+		case n == 0 || (mod100 >= 1 && mod100 <= 19):
+			return PluralFew
+		default:
+			return PluralOther
+		}
+	default:
+		if n == 1 {
+			return PluralOne
+		}
+		return PluralOther
+	}
+}
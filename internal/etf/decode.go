@@ -0,0 +1,408 @@
+package etf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/klauspost/compress/zlib"
+)
+
+// ToJSON transcodes a single ETF encoded term to json.
+// Atoms nil, true & false are transcoded to their json counterparts, all other atoms to strings.
+// Tuples & lists are transcoded to arrays and map keys to strings.
+// Integers which don't fit into a float64 without losing precision, like snowflakes, are transcoded to json strings.
+func ToJSON(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if data[0] != Version {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidVersion, data[0])
+	}
+
+	// json is usually a bit larger than etf
+	d := &decoder{data: data[1:], buf: make([]byte, 0, len(data)+len(data)/2)}
+	if err := d.term(0); err != nil {
+		return nil, err
+	}
+	return d.buf, nil
+}
+
+type decoder struct {
+	data []byte
+	buf  []byte
+}
+
+// read returns the next n bytes without copying them.
+// n is checked against the remaining bytes first, so lengths from malformed payloads never cause large allocations.
+func (d *decoder) read(n int) ([]byte, error) {
+	if n < 0 || n > len(d.data) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := d.data[:n:n]
+	d.data = d.data[n:]
+	return b, nil
+}
+
+func (d *decoder) uint8() (int, error) {
+	if len(d.data) == 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	b := d.data[0]
+	d.data = d.data[1:]
+	return int(b), nil
+}
+
+func (d *decoder) uint16() (int, error) {
+	b, err := d.read(2)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(b)), nil
+}
+
+func (d *decoder) uint32() (int, error) {
+	b, err := d.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint32(b)), nil
+}
+
+func (d *decoder) term(depth int) error {
+	if depth > maxDepth {
+		return ErrMaxDepth
+	}
+
+	tag, err := d.uint8()
+	if err != nil {
+		return err
+	}
+
+	switch byte(tag) {
+	case tagSmallInteger:
+		n, err := d.uint8()
+		if err != nil {
+			return err
+		}
+		d.buf = strconv.AppendInt(d.buf, int64(n), 10)
+
+	case tagInteger:
+		b, err := d.read(4)
+		if err != nil {
+			return err
+		}
+		d.buf = strconv.AppendInt(d.buf, int64(int32(binary.BigEndian.Uint32(b))), 10)
+
+	case tagNewFloat:
+		b, err := d.read(8)
+		if err != nil {
+			return err
+		}
+		return d.float(math.Float64frombits(binary.BigEndian.Uint64(b)))
+
+	case tagFloat:
+		b, err := d.read(31)
+		if err != nil {
+			return err
+		}
+		f, err := strconv.ParseFloat(strings.TrimRight(string(b), "\x00"), 64)
+		if err != nil {
+			return fmt.Errorf("etf: invalid float: %w", err)
+		}
+		return d.float(f)
+
+	case tagSmallBig:
+		n, err := d.uint8()
+		if err != nil {
+			return err
+		}
+		return d.big(n)
+
+	case tagLargeBig:
+		n, err := d.uint32()
+		if err != nil {
+			return err
+		}
+		return d.big(n)
+
+	case tagAtom, tagAtomUTF8:
+		n, err := d.uint16()
+		if err != nil {
+			return err
+		}
+		return d.atom(n)
+
+	case tagSmallAtom, tagSmallAtomUTF8:
+		n, err := d.uint8()
+		if err != nil {
+			return err
+		}
+		return d.atom(n)
+
+	case tagBinary:
+		n, err := d.uint32()
+		if err != nil {
+			return err
+		}
+		b, err := d.read(n)
+		if err != nil {
+			return err
+		}
+		d.buf = appendString(d.buf, b)
+
+	case tagString:
+		// a list of bytes
+		n, err := d.uint16()
+		if err != nil {
+			return err
+		}
+		b, err := d.read(n)
+		if err != nil {
+			return err
+		}
+		d.buf = append(d.buf, '[')
+		for i, c := range b {
+			if i > 0 {
+				d.buf = append(d.buf, ',')
+			}
+			d.buf = strconv.AppendInt(d.buf, int64(c), 10)
+		}
+		d.buf = append(d.buf, ']')
+
+	case tagNil:
+		d.buf = append(d.buf, '[', ']')
+
+	case tagList:
+		n, err := d.uint32()
+		if err != nil {
+			return err
+		}
+		if err = d.array(n, depth); err != nil {
+			return err
+		}
+		// proper lists end with a nil tail
+		tail, err := d.uint8()
+		if err != nil {
+			return err
+		}
+		if byte(tail) != tagNil {
+			return fmt.Errorf("etf: improper lists are not supported")
+		}
+
+	case tagSmallTuple:
+		n, err := d.uint8()
+		if err != nil {
+			return err
+		}
+		return d.array(n, depth)
+
+	case tagLargeTuple:
+		n, err := d.uint32()
+		if err != nil {
+			return err
+		}
+		return d.array(n, depth)
+
+	case tagMap:
+		n, err := d.uint32()
+		if err != nil {
+			return err
+		}
+		return d.object(n, depth)
+
+	case tagCompressed:
+		size, err := d.uint32()
+		if err != nil {
+			return err
+		}
+		if size > maxUncompressedSize {
+			return fmt.Errorf("etf: compressed term of %d bytes exceeds the maximum size", size)
+		}
+		return d.compressed(size, depth)
+
+	default:
+		return fmt.Errorf("etf: unsupported tag %d", tag)
+	}
+	return nil
+}
+
+func (d *decoder) float(f float64) error {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Errorf("etf: unsupported float value %v", f)
+	}
+	d.buf = strconv.AppendFloat(d.buf, f, 'g', -1, 64)
+	return nil
+}
+
+// compressed transcodes a zlib compressed term which is size bytes long when uncompressed.
+func (d *decoder) compressed(size int, depth int) error {
+	br := bytes.NewReader(d.data)
+	zr, err := zlib.NewReader(br)
+	if err != nil {
+		return fmt.Errorf("etf: invalid compressed term: %w", err)
+	}
+	defer zr.Close()
+
+	// the buffer only grows with the data actually decompressed, not with the declared size
+	data, err := io.ReadAll(io.LimitReader(zr, int64(size)+1))
+	if err != nil {
+		return fmt.Errorf("etf: invalid compressed term: %w", err)
+	}
+	if len(data) != size {
+		return fmt.Errorf("etf: compressed term has %d bytes instead of %d", len(data), size)
+	}
+
+	remaining := d.data[len(d.data)-br.Len():]
+	d.data = data
+	if err = d.term(depth + 1); err != nil {
+		return err
+	}
+	d.data = remaining
+	return nil
+}
+
+// big transcodes a big integer with n digits to a json number, or to a json string if it doesn't fit into a float64.
+func (d *decoder) big(n int) error {
+	if n > 8 {
+		return fmt.Errorf("etf: big integer with %d bytes does not fit into 64 bits", n)
+	}
+	sign, err := d.uint8()
+	if err != nil {
+		return err
+	}
+	b, err := d.read(n)
+	if err != nil {
+		return err
+	}
+
+	// digits are stored little endian
+	var v uint64
+	for i := n - 1; i >= 0; i-- {
+		v = v<<8 | uint64(b[i])
+	}
+
+	quoted := v > maxSafeInteger
+	if quoted {
+		d.buf = append(d.buf, '"')
+	}
+	if sign != 0 && v != 0 {
+		d.buf = append(d.buf, '-')
+	}
+	d.buf = strconv.AppendUint(d.buf, v, 10)
+	if quoted {
+		d.buf = append(d.buf, '"')
+	}
+	return nil
+}
+
+func (d *decoder) atom(n int) error {
+	b, err := d.read(n)
+	if err != nil {
+		return err
+	}
+	switch string(b) {
+	case "nil", "null":
+		d.buf = append(d.buf, "null"...)
+	case "true":
+		d.buf = append(d.buf, "true"...)
+	case "false":
+		d.buf = append(d.buf, "false"...)
+	default:
+		d.buf = appendString(d.buf, b)
+	}
+	return nil
+}
+
+func (d *decoder) array(n int, depth int) error {
+	// every element takes at least one byte
+	if n > len(d.data) {
+		return io.ErrUnexpectedEOF
+	}
+	d.buf = append(d.buf, '[')
+	for i := range n {
+		if i > 0 {
+			d.buf = append(d.buf, ',')
+		}
+		if err := d.term(depth + 1); err != nil {
+			return err
+		}
+	}
+	d.buf = append(d.buf, ']')
+	return nil
+}
+
+func (d *decoder) object(n int, depth int) error {
+	// every key & value takes at least one byte
+	if n > len(d.data)/2 {
+		return io.ErrUnexpectedEOF
+	}
+	d.buf = append(d.buf, '{')
+	for i := range n {
+		if i > 0 {
+			d.buf = append(d.buf, ',')
+		}
+
+		start := len(d.buf)
+		if err := d.term(depth + 1); err != nil {
+			return err
+		}
+		// json only allows string keys
+		if d.buf[start] != '"' {
+			key := string(d.buf[start:])
+			d.buf = appendString(d.buf[:start], []byte(key))
+		}
+
+		d.buf = append(d.buf, ':')
+		if err := d.term(depth + 1); err != nil {
+			return err
+		}
+	}
+	d.buf = append(d.buf, '}')
+	return nil
+}
+
+const hex = "0123456789abcdef"
+
+// appendString appends s as a json string to buf.
+func appendString(buf []byte, s []byte) []byte {
+	buf = append(buf, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				buf = append(buf, '\\', c)
+			case c < 0x20:
+				switch c {
+				case '\n':
+					buf = append(buf, '\\', 'n')
+				case '\r':
+					buf = append(buf, '\\', 'r')
+				case '\t':
+					buf = append(buf, '\\', 't')
+				default:
+					buf = append(buf, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+				}
+			default:
+				buf = append(buf, c)
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRune(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf = append(buf, `�`...)
+		} else {
+			buf = append(buf, s[i:i+size]...)
+		}
+		i += size
+	}
+	return append(buf, '"')
+}
@@ -0,0 +1,129 @@
+package etf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/disgoorg/json/v2"
+)
+
+// FromJSON encodes the given json as an ETF term.
+// Objects are encoded as maps with binary keys, arrays as lists, strings as binaries and null, true & false as atoms.
+// Integers which don't fit into 32 bits are encoded as big integers.
+func FromJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	e := &encoder{buf: []byte{Version}}
+	if err := e.value(v); err != nil {
+		return nil, err
+	}
+	return e.buf, nil
+}
+
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) value(v any) error {
+	switch v := v.(type) {
+	case nil:
+		e.atom("nil")
+	case bool:
+		if v {
+			e.atom("true")
+		} else {
+			e.atom("false")
+		}
+	case string:
+		e.buf = append(e.buf, tagBinary)
+		e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(len(v)))
+		e.buf = append(e.buf, v...)
+	case json.Number:
+		return e.number(v)
+	case []any:
+		if len(v) == 0 {
+			e.buf = append(e.buf, tagNil)
+			return nil
+		}
+		e.buf = append(e.buf, tagList)
+		e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(len(v)))
+		for _, item := range v {
+			if err := e.value(item); err != nil {
+				return err
+			}
+		}
+		e.buf = append(e.buf, tagNil)
+	case map[string]any:
+		e.buf = append(e.buf, tagMap)
+		e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(len(v)))
+		for key, item := range v {
+			if err := e.value(key); err != nil {
+				return err
+			}
+			if err := e.value(item); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("etf: unsupported json value %T", v)
+	}
+	return nil
+}
+
+func (e *encoder) atom(name string) {
+	e.buf = append(e.buf, tagSmallAtomUTF8, byte(len(name)))
+	e.buf = append(e.buf, name...)
+}
+
+func (e *encoder) number(n json.Number) error {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		switch {
+		case i >= 0 && i <= math.MaxUint8:
+			e.buf = append(e.buf, tagSmallInteger, byte(i))
+		case i >= math.MinInt32 && i <= math.MaxInt32:
+			e.buf = append(e.buf, tagInteger)
+			e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(int32(i)))
+		case i < 0:
+			e.big(uint64(-i), true)
+		default:
+			e.big(uint64(i), false)
+		}
+		return nil
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		e.big(u, false)
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return fmt.Errorf("etf: invalid number %q: %w", n, err)
+	}
+	e.buf = append(e.buf, tagNewFloat)
+	e.buf = binary.BigEndian.AppendUint64(e.buf, math.Float64bits(f))
+	return nil
+}
+
+func (e *encoder) big(v uint64, negative bool) {
+	var digits []byte
+	for v > 0 {
+		digits = append(digits, byte(v))
+		v >>= 8
+	}
+
+	var sign byte
+	if negative {
+		sign = 1
+	}
+	e.buf = append(e.buf, tagSmallBig, byte(len(digits)), sign)
+	e.buf = append(e.buf, digits...)
+}
@@ -0,0 +1,51 @@
+// Package etf transcodes between the Erlang External Term Format used by the Discord gateway & json.
+//
+// Terms are transcoded to json instead of being decoded into go types directly, so all types which implement json.Unmarshaler keep working unchanged.
+// Integers are transcoded to json numbers, except for integers which don't fit into a float64 without losing precision.
+// Those are transcoded to json strings, as Discord only sends snowflakes that large and snowflake.ID expects quoted strings.
+//
+// See https://www.erlang.org/doc/apps/erts/erl_ext_dist.html for the format specification.
+package etf
+
+import "errors"
+
+// Version is the first byte of every ETF encoded term.
+const Version byte = 131
+
+const (
+	tagNewFloat      byte = 70
+	tagCompressed    byte = 80
+	tagSmallInteger  byte = 97
+	tagInteger       byte = 98
+	tagFloat         byte = 99
+	tagAtom          byte = 100
+	tagSmallTuple    byte = 104
+	tagLargeTuple    byte = 105
+	tagNil           byte = 106
+	tagString        byte = 107
+	tagList          byte = 108
+	tagBinary        byte = 109
+	tagSmallBig      byte = 110
+	tagLargeBig      byte = 111
+	tagSmallAtom     byte = 115
+	tagMap           byte = 116
+	tagAtomUTF8      byte = 118
+	tagSmallAtomUTF8 byte = 119
+)
+
+// maxDepth is the maximum nesting depth of terms, so malformed payloads can't exhaust the stack.
+const maxDepth = 512
+
+// maxUncompressedSize is the maximum uncompressed size of compressed terms, so malformed payloads can't exhaust the memory.
+const maxUncompressedSize = 64 << 20
+
+// maxSafeInteger is the largest integer which can be represented exactly by a float64.
+const maxSafeInteger = 1<<53 - 1
+
+var (
+	// ErrInvalidVersion is returned when a term does not start with Version.
+	ErrInvalidVersion = errors.New("etf: invalid version")
+
+	// ErrMaxDepth is returned when a term is nested deeper than allowed.
+	ErrMaxDepth = errors.New("etf: max depth exceeded")
+)
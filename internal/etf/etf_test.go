@@ -0,0 +1,85 @@
+package etf
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/disgoorg/json/v2"
+)
+
+func TestToJSON(t *testing.T) {
+	data := []byte{
+		Version,
+		tagMap, 0, 0, 0, 4,
+		// "op": 0
+		tagSmallAtomUTF8, 2, 'o', 'p', tagSmallInteger, 0,
+		// "s": 1000
+		tagSmallAtomUTF8, 1, 's', tagInteger, 0, 0, 0x03, 0xe8,
+		// "t": nil
+		tagSmallAtomUTF8, 1, 't', tagSmallAtomUTF8, 3, 'n', 'i', 'l',
+		// "d": {"id": 1234567891011121314, "name": "a\"b", "tags": []}
+		tagSmallAtomUTF8, 1, 'd', tagMap, 0, 0, 0, 3,
+		tagBinary, 0, 0, 0, 2, 'i', 'd', tagSmallBig, 8, 0, 0xa2, 0x30, 0xd2, 0xb2, 0xf4, 0x10, 0x22, 0x11,
+		tagBinary, 0, 0, 0, 4, 'n', 'a', 'm', 'e', tagBinary, 0, 0, 0, 3, 'a', '"', 'b',
+		tagBinary, 0, 0, 0, 4, 't', 'a', 'g', 's', tagNil,
+	}
+
+	got, err := ToJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"op":0,"s":1000,"t":null,"d":{"id":"1234567891011121314","name":"a\"b","tags":[]}}`
+	if string(got) != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	data := []byte(`{"op":2,"d":{"token":"abc","intents":3276799,"shard":[0,1],"large":1234567891011121314,"neg":-5,"f":1.5,"presence":null,"compress":false}}`)
+
+	etf, err := FromJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ToJSON(etf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected, actual map[string]any
+	if err = json.Unmarshal([]byte(`{"op":2,"d":{"token":"abc","intents":3276799,"shard":[0,1],"large":"1234567891011121314","neg":-5,"f":1.5,"presence":null,"compress":false}}`), &expected); err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal(got, &actual); err != nil {
+		t.Fatalf("invalid json %s: %v", got, err)
+	}
+
+	expectedJSON, _ := json.Marshal(expected)
+	actualJSON, _ := json.Marshal(actual)
+	if !bytes.Equal(expectedJSON, actualJSON) {
+		t.Errorf("expected %s, got %s", expectedJSON, actualJSON)
+	}
+}
+
+func TestToJSON_InvalidLength(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"binary": {Version, tagBinary, 0xff, 0xff, 0xff, 0xff, 'a'},
+		"list":   {Version, tagList, 0xff, 0xff, 0xff, 0xff, tagNil},
+		"map":    {Version, tagMap, 0xff, 0xff, 0xff, 0xff, tagNil, tagNil},
+	} {
+		t.Run(name, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(10, func() {
+				if _, err := ToJSON(data); !errors.Is(err, io.ErrUnexpectedEOF) {
+					t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+				}
+			})
+			// only the output buffer is allocated, not the declared length
+			if allocs > 2 {
+				t.Errorf("expected at most 2 allocations, got %v", allocs)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+// Package linejson implements a minimal request & response protocol over TCP or Unix sockets.
+// Every request & response is a single json value terminated by a newline. The protocol is only meant to be used in trusted networks.
+package linejson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+)
+
+// NewServer returns a new Server which answers every request with the response returned by the handleFunc.
+func NewServer[Rq any, Rs any](handleFunc func(rq Rq) Rs, logger *slog.Logger) *Server[Rq, Rs] {
+	return &Server[Rq, Rs]{
+		handleFunc: handleFunc,
+		logger:     logger,
+		listeners:  map[net.Listener]struct{}{},
+		conns:      map[net.Conn]struct{}{},
+	}
+}
+
+// Server serves requests of connections accepted from one or more net.Listener(s).
+type Server[Rq any, Rs any] struct {
+	handleFunc func(rq Rq) Rs
+	logger     *slog.Logger
+
+	mu        sync.Mutex
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+	closed    bool
+}
+
+// ListenAndServe listens on the given network address & serves requests until the Server is closed.
+func (s *Server[Rq, Rs]) ListenAndServe(network string, address string) error {
+	ln, err := net.Listen(network, address)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ln)
+}
+
+// Serve accepts connections on the given net.Listener & serves requests until the Server is closed.
+func (s *Server[Rq, Rs]) Serve(ln net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		_ = ln.Close()
+		return net.ErrClosed
+	}
+	s.listeners[ln] = struct{}{}
+	s.mu.Unlock()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			delete(s.listeners, ln)
+			s.mu.Unlock()
+			if closed {
+				return net.ErrClosed
+			}
+			return err
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+		go s.handleConn(conn)
+	}
+}
+
+// Close stops all listeners & closes all open connections.
+func (s *Server[Rq, Rs]) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	var err error
+	for ln := range s.listeners {
+		err = errors.Join(err, ln.Close())
+	}
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	clear(s.listeners)
+	clear(s.conns)
+	return err
+}
+
+func (s *Server[Rq, Rs]) handleConn(conn net.Conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		_ = conn.Close()
+	}()
+
+	dec := json.NewDecoder(conn)
+	enc := json.NewEncoder(conn)
+	for {
+		var rq Rq
+		if err := dec.Decode(&rq); err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				s.logger.Error("failed to decode request", slog.Any("err", err), slog.String("remote_addr", conn.RemoteAddr().String()))
+			}
+			return
+		}
+
+		if err := enc.Encode(s.handleFunc(rq)); err != nil {
+			s.logger.Error("failed to encode response", slog.Any("err", err), slog.String("remote_addr", conn.RemoteAddr().String()))
+			return
+		}
+	}
+}
+
+// NewClient returns a new Client which connects to a Server listening on the given network address.
+// The name of the Server is used in errors. Up to maxIdle connections are kept open between requests.
+func NewClient[Rq any, Rs any](network string, address string, name string, maxIdle int) *Client[Rq, Rs] {
+	return &Client[Rq, Rs]{
+		network: network,
+		address: address,
+		name:    name,
+		maxIdle: max(maxIdle, 1),
+	}
+}
+
+// Client sends requests to a Server. It is safe for concurrent use, concurrent requests use separate connections.
+type Client[Rq any, Rs any] struct {
+	network string
+	address string
+	name    string
+	maxIdle int
+	dialer  net.Dialer
+
+	mu     sync.Mutex
+	idle   []*clientConn
+	closed bool
+}
+
+type clientConn struct {
+	conn net.Conn
+	enc  interface{ Encode(v any) error }
+	dec  interface{ Decode(v any) error }
+}
+
+func (c *Client[Rq, Rs]) getConn(ctx context.Context) (*clientConn, error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil, net.ErrClosed
+	}
+	if n := len(c.idle); n > 0 {
+		cc := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.mu.Unlock()
+		return cc, nil
+	}
+	c.mu.Unlock()
+
+	conn, err := c.dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return nil, err
+	}
+	return &clientConn{
+		conn: conn,
+		enc:  json.NewEncoder(conn),
+		dec:  json.NewDecoder(conn),
+	}, nil
+}
+
+func (c *Client[Rq, Rs]) putConn(cc *clientConn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed || len(c.idle) >= c.maxIdle {
+		_ = cc.conn.Close()
+		return
+	}
+	c.idle = append(c.idle, cc)
+}
+
+// Do sends the request & returns the response of the Server.
+// If the context is done before the response is received, the connection is closed & the error of the context is returned.
+func (c *Client[Rq, Rs]) Do(ctx context.Context, rq Rq) (Rs, error) {
+	var rs Rs
+	cc, err := c.getConn(ctx)
+	if err != nil {
+		return rs, fmt.Errorf("failed to connect to %s: %w", c.name, err)
+	}
+
+	deadline, _ := ctx.Deadline()
+	_ = cc.conn.SetDeadline(deadline)
+	// abort pending reads & writes when the context is cancelled
+	stop := context.AfterFunc(ctx, func() {
+		_ = cc.conn.SetDeadline(time.Now())
+	})
+
+	if err = cc.enc.Encode(rq); err == nil {
+		err = cc.dec.Decode(&rs)
+	}
+	if !stop() || err != nil {
+		// the connection is in an unknown state, don't reuse it
+		_ = cc.conn.Close()
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return rs, ctxErr
+		}
+		return rs, fmt.Errorf("failed to communicate with %s: %w", c.name, err)
+	}
+	c.putConn(cc)
+	return rs, nil
+}
+
+// Close closes all idle connections. Requests sent after Close fail.
+func (c *Client[Rq, Rs]) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	for _, cc := range c.idle {
+		_ = cc.conn.Close()
+	}
+	c.idle = nil
+	return nil
+}
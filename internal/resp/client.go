@@ -0,0 +1,173 @@
+package resp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// ErrClientClosed is returned when a command is sent on a closed Client.
+var ErrClientClosed = errors.New("resp: client closed")
+
+// NewClient returns a new Client which connects to the given network address.
+// Up to poolSize connections are kept open. The setup commands (like AUTH or SELECT) are sent on every new connection.
+func NewClient(network string, address string, poolSize int, setup ...[]string) *Client {
+	return &Client{
+		network: network,
+		address: address,
+		setup:   setup,
+		idle:    make(chan *conn, max(poolSize, 1)),
+		closed:  make(chan struct{}),
+	}
+}
+
+// Client is a pooled RESP client which is safe for concurrent use.
+type Client struct {
+	network string
+	address string
+	setup   [][]string
+	dialer  net.Dialer
+
+	idle   chan *conn
+	closed chan struct{}
+}
+
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+// Do sends the given command & returns its reply. Error replies are returned as Error.
+func (c *Client) Do(ctx context.Context, args ...string) (any, error) {
+	values, err := c.Pipeline(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return values[0], nil
+}
+
+// Pipeline sends all given commands at once & returns their replies in order.
+// Error replies are returned as Error values in the replies, the returned error is only set if the connection failed.
+func (c *Client) Pipeline(ctx context.Context, cmds ...[]string) ([]any, error) {
+	cn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := cn.pipeline(ctx, cmds)
+	if err != nil {
+		// the connection is in an unknown state, don't reuse it
+		_ = cn.Close()
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, err
+	}
+	c.put(cn)
+	return values, nil
+}
+
+// Close closes all idle connections. Connections which are in use are closed when they are returned.
+func (c *Client) Close() error {
+	select {
+	case <-c.closed:
+		return nil
+	default:
+		close(c.closed)
+	}
+
+	var err error
+	for {
+		select {
+		case cn := <-c.idle:
+			err = errors.Join(err, cn.Close())
+		default:
+			return err
+		}
+	}
+}
+
+func (c *Client) get(ctx context.Context) (*conn, error) {
+	select {
+	case <-c.closed:
+		return nil, ErrClientClosed
+	case cn := <-c.idle:
+		return cn, nil
+	default:
+	}
+
+	netConn, err := c.dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return nil, fmt.Errorf("resp: failed to connect: %w", err)
+	}
+	cn := &conn{
+		Conn: netConn,
+		r:    bufio.NewReader(netConn),
+		w:    bufio.NewWriter(netConn),
+	}
+	if len(c.setup) == 0 {
+		return cn, nil
+	}
+
+	values, err := cn.pipeline(ctx, c.setup)
+	if err != nil {
+		_ = cn.Close()
+		return nil, err
+	}
+	for _, v := range values {
+		if err, ok := v.(Error); ok {
+			_ = cn.Close()
+			return nil, fmt.Errorf("resp: failed to set up connection: %w", err)
+		}
+	}
+	return cn, nil
+}
+
+func (c *Client) put(cn *conn) {
+	select {
+	case <-c.closed:
+		_ = cn.Close()
+		return
+	default:
+	}
+
+	select {
+	case c.idle <- cn:
+	default:
+		// the pool is full
+		_ = cn.Close()
+	}
+}
+
+func (c *conn) pipeline(ctx context.Context, cmds [][]string) ([]any, error) {
+	deadline, _ := ctx.Deadline()
+	_ = c.SetDeadline(deadline)
+	// abort pending reads & writes when the context is cancelled
+	stop := context.AfterFunc(ctx, func() {
+		_ = c.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	for _, cmd := range cmds {
+		if err := WriteCommand(c.w, cmd...); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.w.Flush(); err != nil {
+		return nil, err
+	}
+
+	values := make([]any, len(cmds))
+	for i := range values {
+		v, err := ReadValue(c.r)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
@@ -0,0 +1,157 @@
+// Package resp implements a minimal client & server for the Redis serialization protocol (RESP2).
+//
+// Replies are decoded into the following go types:
+//   - simple strings into string
+//   - errors into Error
+//   - integers into int64
+//   - bulk strings into []byte
+//   - arrays into []any
+//   - null bulk strings & null arrays into nil
+//
+// See https://redis.io/docs/latest/develop/reference/protocol-spec/ for the protocol specification.
+package resp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ErrProtocol is returned when a malformed RESP value is read.
+var ErrProtocol = errors.New("resp: protocol error")
+
+// Error is an error reply sent by the server.
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// WriteCommand writes the given command as an array of bulk strings.
+func WriteCommand(w *bufio.Writer, args ...string) error {
+	w.WriteByte('*')
+	w.WriteString(strconv.Itoa(len(args)))
+	w.WriteString("\r\n")
+	for _, arg := range args {
+		writeBulk(w, arg)
+	}
+	return nil
+}
+
+// WriteValue writes the given value. Supported are the same types ReadValue returns.
+func WriteValue(w *bufio.Writer, v any) error {
+	switch v := v.(type) {
+	case nil:
+		w.WriteString("$-1\r\n")
+	case string:
+		w.WriteByte('+')
+		w.WriteString(v)
+		w.WriteString("\r\n")
+	case Error:
+		w.WriteByte('-')
+		w.WriteString(string(v))
+		w.WriteString("\r\n")
+	case int64:
+		w.WriteByte(':')
+		w.WriteString(strconv.FormatInt(v, 10))
+		w.WriteString("\r\n")
+	case int:
+		return WriteValue(w, int64(v))
+	case []byte:
+		writeBulk(w, string(v))
+	case []any:
+		w.WriteByte('*')
+		w.WriteString(strconv.Itoa(len(v)))
+		w.WriteString("\r\n")
+		for _, item := range v {
+			if err := WriteValue(w, item); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("resp: unsupported value %T", v)
+	}
+	return nil
+}
+
+func writeBulk(w *bufio.Writer, s string) {
+	w.WriteByte('$')
+	w.WriteString(strconv.Itoa(len(s)))
+	w.WriteString("\r\n")
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
+
+// ReadValue reads a single value. Error replies are returned as Error value & not as error.
+func ReadValue(r *bufio.Reader) (any, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, ErrProtocol
+	}
+
+	switch line[0] {
+	case '+':
+		return string(line[1:]), nil
+	case '-':
+		return Error(line[1:]), nil
+	case ':':
+		return parseInt(line[1:])
+	case '$':
+		n, err := parseInt(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err = io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := parseInt(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		values := make([]any, n)
+		for i := range values {
+			if values[i], err = ReadValue(r); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown type %q", ErrProtocol, line[0])
+	}
+}
+
+func readLine(r *bufio.Reader) ([]byte, error) {
+	line, err := r.ReadSlice('\n')
+	if err != nil {
+		if errors.Is(err, bufio.ErrBufferFull) {
+			return nil, fmt.Errorf("%w: line too long", ErrProtocol)
+		}
+		return nil, err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("%w: invalid line ending", ErrProtocol)
+	}
+	return line[:len(line)-2], nil
+}
+
+func parseInt(b []byte) (int64, error) {
+	n, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid integer %q", ErrProtocol, b)
+	}
+	return n, nil
+}
@@ -0,0 +1,444 @@
+package resp
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"io"
+	"net"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NewServer returns a new in-memory Server.
+func NewServer() *Server {
+	return &Server{
+		data:  map[string]entry{},
+		conns: map[net.Conn]struct{}{},
+	}
+}
+
+// Server is a minimal in-memory stand-in for a Redis server.
+// It supports the string commands PING, AUTH, SELECT, GET, SET (with EX & PX), GETDEL, DEL, MGET, EXISTS, SCAN (with MATCH & COUNT), DBSIZE & FLUSHDB
+// and the sorted set commands ZADD, ZREM, ZCARD & ZREMRANGEBYSCORE.
+// It is meant for tests & local development and not as a replacement for a real server.
+type Server struct {
+	mu   sync.Mutex
+	data map[string]entry
+
+	connsMu sync.Mutex
+	ln      net.Listener
+	conns   map[net.Conn]struct{}
+}
+
+type entry struct {
+	value     []byte
+	expiresAt time.Time
+	// members are the scores of the members of a sorted set, value is unused for sorted sets
+	members map[string]float64
+}
+
+func (e entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// Serve accepts connections on the given net.Listener until the Server is closed.
+func (s *Server) Serve(ln net.Listener) error {
+	s.connsMu.Lock()
+	s.ln = ln
+	s.connsMu.Unlock()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		s.connsMu.Lock()
+		s.conns[conn] = struct{}{}
+		s.connsMu.Unlock()
+		go s.handleConn(conn)
+	}
+}
+
+// Close stops the listener & closes all open connections.
+func (s *Server) Close() error {
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+	var err error
+	if s.ln != nil {
+		err = s.ln.Close()
+	}
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	clear(s.conns)
+	return err
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer func() {
+		s.connsMu.Lock()
+		delete(s.conns, conn)
+		s.connsMu.Unlock()
+		_ = conn.Close()
+	}()
+
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	for {
+		v, err := ReadValue(r)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				_ = WriteValue(w, Error("ERR "+err.Error()))
+				_ = w.Flush()
+			}
+			return
+		}
+
+		args, ok := commandArgs(v)
+		if !ok {
+			_ = WriteValue(w, Error("ERR invalid command"))
+		} else if err = WriteValue(w, s.exec(args)); err != nil {
+			return
+		}
+
+		// only flush after the last pipelined command
+		if r.Buffered() == 0 {
+			if err = w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func commandArgs(v any) ([]string, bool) {
+	values, ok := v.([]any)
+	if !ok || len(values) == 0 {
+		return nil, false
+	}
+	args := make([]string, len(values))
+	for i, value := range values {
+		b, ok := value.([]byte)
+		if !ok {
+			return nil, false
+		}
+		args[i] = string(b)
+	}
+	return args, true
+}
+
+func (s *Server) exec(args []string) any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	cmd := strings.ToUpper(args[0])
+	args = args[1:]
+	switch cmd {
+	case "PING":
+		if len(args) > 0 {
+			return []byte(args[0])
+		}
+		return "PONG"
+	case "AUTH", "SELECT":
+		return "OK"
+	case "GET":
+		if len(args) != 1 {
+			return errWrongArgs(cmd)
+		}
+		e, ok := s.get(args[0], now)
+		if !ok {
+			return nil
+		}
+		if e.members != nil {
+			return errWrongType
+		}
+		return e.value
+	case "SET":
+		if len(args) < 2 {
+			return errWrongArgs(cmd)
+		}
+		e := entry{value: []byte(args[1])}
+		var get bool
+		for i := 2; i < len(args); i++ {
+			opt := strings.ToUpper(args[i])
+			if opt == "GET" {
+				get = true
+				continue
+			}
+			if (opt != "EX" && opt != "PX") || i+1 >= len(args) {
+				return Error("ERR syntax error")
+			}
+			n, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil || n <= 0 {
+				return Error("ERR invalid expire time in 'set' command")
+			}
+			unit := time.Millisecond
+			if opt == "EX" {
+				unit = time.Second
+			}
+			e.expiresAt = now.Add(time.Duration(n) * unit)
+			i++
+		}
+		old, ok := s.get(args[0], now)
+		if ok && old.members != nil {
+			return errWrongType
+		}
+		s.data[args[0]] = e
+		if get {
+			if !ok {
+				return nil
+			}
+			return old.value
+		}
+		return "OK"
+	case "GETDEL":
+		if len(args) != 1 {
+			return errWrongArgs(cmd)
+		}
+		e, ok := s.get(args[0], now)
+		if !ok {
+			return nil
+		}
+		if e.members != nil {
+			return errWrongType
+		}
+		delete(s.data, args[0])
+		return e.value
+	case "DEL", "EXISTS":
+		if len(args) == 0 {
+			return errWrongArgs(cmd)
+		}
+		var n int64
+		for _, key := range args {
+			if _, ok := s.get(key, now); ok {
+				n++
+				if cmd == "DEL" {
+					delete(s.data, key)
+				}
+			}
+		}
+		return n
+	case "MGET":
+		if len(args) == 0 {
+			return errWrongArgs(cmd)
+		}
+		values := make([]any, len(args))
+		for i, key := range args {
+			if e, ok := s.get(key, now); ok && e.members == nil {
+				values[i] = e.value
+			}
+		}
+		return values
+	case "SCAN":
+		return s.scan(args, now)
+	case "ZADD":
+		return s.zadd(args, now)
+	case "ZREM", "ZCARD", "ZREMRANGEBYSCORE":
+		return s.zset(cmd, args, now)
+	case "ZRANGEBYSCORE":
+		return s.zrangeByScore(args, now)
+	case "DBSIZE":
+		var n int64
+		for key := range s.data {
+			if _, ok := s.get(key, now); ok {
+				n++
+			}
+		}
+		return n
+	case "FLUSHDB":
+		clear(s.data)
+		return "OK"
+	default:
+		return Error("ERR unknown command '" + cmd + "'")
+	}
+}
+
+// get returns the entry with the given key & removes it if it is expired. It must be called with s.mu held.
+func (s *Server) get(key string, now time.Time) (entry, bool) {
+	e, ok := s.data[key]
+	if !ok {
+		return entry{}, false
+	}
+	if e.expired(now) {
+		delete(s.data, key)
+		return entry{}, false
+	}
+	return e, true
+}
+
+// scan iterates the sorted keys, the cursor is the index of the next key.
+func (s *Server) scan(args []string, now time.Time) any {
+	if len(args) == 0 {
+		return errWrongArgs("SCAN")
+	}
+	cursor, err := strconv.Atoi(args[0])
+	if err != nil || cursor < 0 {
+		return Error("ERR invalid cursor")
+	}
+	pattern := "*"
+	count := 10
+	for i := 1; i < len(args); i += 2 {
+		if i+1 >= len(args) {
+			return Error("ERR syntax error")
+		}
+		switch strings.ToUpper(args[i]) {
+		case "MATCH":
+			pattern = args[i+1]
+		case "COUNT":
+			if count, err = strconv.Atoi(args[i+1]); err != nil || count < 1 {
+				return Error("ERR syntax error")
+			}
+		default:
+			return Error("ERR syntax error")
+		}
+	}
+
+	keys := make([]string, 0, len(s.data))
+	for key, e := range s.data {
+		if !e.expired(now) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var matched []any
+	next := min(cursor+count, len(keys))
+	for _, key := range keys[min(cursor, len(keys)):next] {
+		if ok, _ := path.Match(pattern, key); ok {
+			matched = append(matched, []byte(key))
+		}
+	}
+	if next == len(keys) {
+		next = 0
+	}
+	return []any{[]byte(strconv.Itoa(next)), matched}
+}
+
+// zadd adds the given score & member pairs to a sorted set or updates their scores.
+func (s *Server) zadd(args []string, now time.Time) any {
+	if len(args) < 3 || len(args)%2 != 1 {
+		return errWrongArgs("ZADD")
+	}
+	e, ok := s.get(args[0], now)
+	if ok && e.members == nil {
+		return errWrongType
+	}
+	if !ok {
+		e = entry{members: map[string]float64{}}
+	}
+
+	var added int64
+	for i := 1; i < len(args); i += 2 {
+		score, err := strconv.ParseFloat(args[i], 64)
+		if err != nil {
+			return Error("ERR value is not a valid float")
+		}
+		if _, ok = e.members[args[i+1]]; !ok {
+			added++
+		}
+		e.members[args[i+1]] = score
+	}
+	s.data[args[0]] = e
+	return added
+}
+
+// zset executes the sorted set commands which remove or count members. Empty sorted sets are removed.
+func (s *Server) zset(cmd string, args []string, now time.Time) any {
+	if len(args) == 0 || (cmd == "ZREM" && len(args) < 2) || (cmd == "ZCARD" && len(args) != 1) || (cmd == "ZREMRANGEBYSCORE" && len(args) != 3) {
+		return errWrongArgs(cmd)
+	}
+	e, ok := s.get(args[0], now)
+	if !ok {
+		return int64(0)
+	}
+	if e.members == nil {
+		return errWrongType
+	}
+
+	var n int64
+	switch cmd {
+	case "ZREM":
+		for _, member := range args[1:] {
+			if _, ok = e.members[member]; ok {
+				delete(e.members, member)
+				n++
+			}
+		}
+	case "ZCARD":
+		return int64(len(e.members))
+	case "ZREMRANGEBYSCORE":
+		minScore, err := strconv.ParseFloat(args[1], 64)
+		if err != nil {
+			return Error("ERR min or max is not a float")
+		}
+		maxScore, err := strconv.ParseFloat(args[2], 64)
+		if err != nil {
+			return Error("ERR min or max is not a float")
+		}
+		for member, score := range e.members {
+			if score >= minScore && score <= maxScore {
+				delete(e.members, member)
+				n++
+			}
+		}
+	}
+	if len(e.members) == 0 {
+		delete(s.data, args[0])
+	}
+	return n
+}
+
+// zrangeByScore returns the members of a sorted set with a score within the given range, ordered by score & member.
+func (s *Server) zrangeByScore(args []string, now time.Time) any {
+	if len(args) != 3 {
+		return errWrongArgs("ZRANGEBYSCORE")
+	}
+	minScore, err := strconv.ParseFloat(args[1], 64)
+	if err != nil {
+		return Error("ERR min or max is not a float")
+	}
+	maxScore, err := strconv.ParseFloat(args[2], 64)
+	if err != nil {
+		return Error("ERR min or max is not a float")
+	}
+	e, ok := s.get(args[0], now)
+	if !ok {
+		return []any{}
+	}
+	if e.members == nil {
+		return errWrongType
+	}
+
+	members := make([]string, 0, len(e.members))
+	for member, score := range e.members {
+		if score >= minScore && score <= maxScore {
+			members = append(members, member)
+		}
+	}
+	slices.SortFunc(members, func(a, b string) int {
+		if c := cmp.Compare(e.members[a], e.members[b]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+
+	values := make([]any, len(members))
+	for i, member := range members {
+		values[i] = []byte(member)
+	}
+	return values
+}
+
+var errWrongType = Error("WRONGTYPE Operation against a key holding the wrong kind of value")
+
+func errWrongArgs(cmd string) Error {
+	return Error("ERR wrong number of arguments for '" + strings.ToLower(cmd) + "' command")
+}
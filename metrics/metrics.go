@@ -0,0 +1,50 @@
+// Package metrics provides a small metrics interface which is used to instrument the rest, gateway, bot & cache packages.
+//
+// By default, all packages use Noop which discards all measurements.
+// Prometheus can be used to expose all measurements in the Prometheus text exposition format.
+package metrics
+
+// DefaultBuckets are the default Histogram buckets in seconds.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Metrics creates Counter(s), Gauge(s) & Histogram(s).
+// Creating a metric with a name that already exists returns the existing metric.
+type Metrics interface {
+	// Counter returns a Counter with the given name, help text & label names.
+	Counter(name string, help string, labelNames ...string) Counter
+
+	// Gauge returns a Gauge with the given name, help text & label names.
+	Gauge(name string, help string, labelNames ...string) Gauge
+
+	// GaugeFunc registers a gauge with the given name, help text & constant labels whose value is computed by fn when it is collected.
+	// Registering the same name & labels again replaces fn.
+	GaugeFunc(name string, help string, labels Labels, fn func() float64)
+
+	// Histogram returns a Histogram with the given name, help text, bucket upper bounds & label names.
+	// If buckets is nil, DefaultBuckets are used.
+	Histogram(name string, help string, buckets []float64, labelNames ...string) Histogram
+}
+
+// Labels are constant label names & values of a metric.
+type Labels map[string]string
+
+// Counter is a metric which can only increase.
+type Counter interface {
+	// Add adds the given value to the counter with the given label values.
+	Add(value float64, labelValues ...string)
+}
+
+// Gauge is a metric which can arbitrarily go up and down.
+type Gauge interface {
+	// Set sets the gauge with the given label values to the given value.
+	Set(value float64, labelValues ...string)
+
+	// Add adds the given value to the gauge with the given label values.
+	Add(value float64, labelValues ...string)
+}
+
+// Histogram samples observations and counts them in configurable buckets.
+type Histogram interface {
+	// Observe adds a single observation to the histogram with the given label values.
+	Observe(value float64, labelValues ...string)
+}
@@ -0,0 +1,26 @@
+package metrics
+
+var _ Metrics = (*noopMetrics)(nil)
+
+// Noop is a Metrics implementation which discards all measurements.
+var Noop Metrics = noopMetrics{}
+
+type noopMetrics struct{}
+
+func (noopMetrics) Counter(_ string, _ string, _ ...string) Counter { return noopMetric{} }
+
+func (noopMetrics) Gauge(_ string, _ string, _ ...string) Gauge { return noopMetric{} }
+
+func (noopMetrics) GaugeFunc(_ string, _ string, _ Labels, _ func() float64) {}
+
+func (noopMetrics) Histogram(_ string, _ string, _ []float64, _ ...string) Histogram {
+	return noopMetric{}
+}
+
+type noopMetric struct{}
+
+func (noopMetric) Add(_ float64, _ ...string) {}
+
+func (noopMetric) Set(_ float64, _ ...string) {}
+
+func (noopMetric) Observe(_ float64, _ ...string) {}
@@ -0,0 +1,319 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	typeCounter   = "counter"
+	typeGauge     = "gauge"
+	typeHistogram = "histogram"
+)
+
+var (
+	_ Metrics      = (*Prometheus)(nil)
+	_ http.Handler = (*Prometheus)(nil)
+	_ io.WriterTo  = (*Prometheus)(nil)
+)
+
+// NewPrometheus returns a new Prometheus which keeps all measurements in memory.
+func NewPrometheus() *Prometheus {
+	return &Prometheus{
+		families: map[string]*family{},
+	}
+}
+
+// Prometheus is a Metrics implementation which exposes all measurements in the Prometheus text exposition format.
+// It implements http.Handler so it can be mounted as a scrape endpoint.
+type Prometheus struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+func (p *Prometheus) getFamily(name string, help string, typ string, buckets []float64, labelNames []string) *family {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f, ok := p.families[name]
+	if ok {
+		if f.typ != typ {
+			panic(fmt.Sprintf("metrics: %s is already registered as %s", name, f.typ))
+		}
+		return f
+	}
+
+	f = &family{
+		name:       name,
+		help:       help,
+		typ:        typ,
+		buckets:    buckets,
+		labelNames: labelNames,
+		series:     map[string]*series{},
+		funcs:      map[string]*gaugeFunc{},
+	}
+	p.families[name] = f
+	return f
+}
+
+func (p *Prometheus) Counter(name string, help string, labelNames ...string) Counter {
+	return p.getFamily(name, help, typeCounter, nil, labelNames)
+}
+
+func (p *Prometheus) Gauge(name string, help string, labelNames ...string) Gauge {
+	return p.getFamily(name, help, typeGauge, nil, labelNames)
+}
+
+func (p *Prometheus) GaugeFunc(name string, help string, labels Labels, fn func() float64) {
+	f := p.getFamily(name, help, typeGauge, nil, nil)
+
+	names := make([]string, 0, len(labels))
+	for labelName := range labels {
+		names = append(names, labelName)
+	}
+	slices.Sort(names)
+	values := make([]string, len(names))
+	for i, labelName := range names {
+		values[i] = labels[labelName]
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.funcs[formatLabels(names, values, "", "")] = &gaugeFunc{
+		labelNames:  names,
+		labelValues: values,
+		fn:          fn,
+	}
+}
+
+func (p *Prometheus) Histogram(name string, help string, buckets []float64, labelNames ...string) Histogram {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+	return p.getFamily(name, help, typeHistogram, buckets, labelNames)
+}
+
+// ServeHTTP writes all measurements in the Prometheus text exposition format.
+func (p *Prometheus) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = p.WriteTo(w)
+}
+
+// WriteTo writes all measurements in the Prometheus text exposition format to the given io.Writer.
+func (p *Prometheus) WriteTo(w io.Writer) (int64, error) {
+	p.mu.Lock()
+	families := make([]*family, 0, len(p.families))
+	for _, f := range p.families {
+		families = append(families, f)
+	}
+	p.mu.Unlock()
+
+	slices.SortFunc(families, func(a, b *family) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	for _, f := range families {
+		f.writeTo(cw)
+	}
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+	return cw.n, cw.err
+}
+
+type family struct {
+	name       string
+	help       string
+	typ        string
+	buckets    []float64
+	labelNames []string
+
+	mu     sync.Mutex
+	series map[string]*series
+	funcs  map[string]*gaugeFunc
+}
+
+type series struct {
+	labelValues  []string
+	value        float64
+	bucketCounts []uint64
+	count        uint64
+}
+
+type gaugeFunc struct {
+	labelNames  []string
+	labelValues []string
+	fn          func() float64
+}
+
+// getSeries returns the series with the given label values. It must be called with f.mu held.
+func (f *family) getSeries(labelValues []string) *series {
+	if len(labelValues) != len(f.labelNames) {
+		// normalize the label values, so we never produce invalid output
+		values := make([]string, len(f.labelNames))
+		copy(values, labelValues)
+		labelValues = values
+	}
+
+	key := strings.Join(labelValues, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{
+			labelValues: slices.Clone(labelValues),
+		}
+		if f.typ == typeHistogram {
+			s.bucketCounts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+func (f *family) Add(value float64, labelValues ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getSeries(labelValues).value += value
+}
+
+func (f *family) Set(value float64, labelValues ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getSeries(labelValues).value = value
+}
+
+func (f *family) Observe(value float64, labelValues ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s := f.getSeries(labelValues)
+	for i, upperBound := range f.buckets {
+		if value <= upperBound {
+			s.bucketCounts[i]++
+		}
+	}
+	s.count++
+	s.value += value
+}
+
+func (f *family) writeTo(w *countingWriter) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.series) == 0 && len(f.funcs) == 0 {
+		return
+	}
+
+	w.printf("# HELP %s %s\n", f.name, escapeHelp(f.help))
+	w.printf("# TYPE %s %s\n", f.name, f.typ)
+
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		s := f.series[key]
+		if f.typ != typeHistogram {
+			w.printf("%s%s %s\n", f.name, formatLabels(f.labelNames, s.labelValues, "", ""), formatFloat(s.value))
+			continue
+		}
+		for i, upperBound := range f.buckets {
+			w.printf("%s_bucket%s %d\n", f.name, formatLabels(f.labelNames, s.labelValues, "le", formatFloat(upperBound)), s.bucketCounts[i])
+		}
+		w.printf("%s_bucket%s %d\n", f.name, formatLabels(f.labelNames, s.labelValues, "le", "+Inf"), s.count)
+		w.printf("%s_sum%s %s\n", f.name, formatLabels(f.labelNames, s.labelValues, "", ""), formatFloat(s.value))
+		w.printf("%s_count%s %d\n", f.name, formatLabels(f.labelNames, s.labelValues, "", ""), s.count)
+	}
+
+	funcKeys := make([]string, 0, len(f.funcs))
+	for key := range f.funcs {
+		funcKeys = append(funcKeys, key)
+	}
+	slices.Sort(funcKeys)
+
+	for _, key := range funcKeys {
+		g := f.funcs[key]
+		w.printf("%s%s %s\n", f.name, formatLabels(g.labelNames, g.labelValues, "", ""), formatFloat(g.fn()))
+	}
+}
+
+// formatLabels formats the given label names & values as {name="value",...}. If extraName is not empty, it is appended as an additional label.
+func formatLabels(names []string, values []string, extraName string, extraValue string) string {
+	if len(names) == 0 && extraName == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(name)
+		sb.WriteString(`="`)
+		sb.WriteString(escapeLabelValue(values[i]))
+		sb.WriteByte('"')
+	}
+	if extraName != "" {
+		if len(names) > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(extraName)
+		sb.WriteString(`="`)
+		sb.WriteString(escapeLabelValue(extraValue))
+		sb.WriteByte('"')
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+var (
+	labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+	helpReplacer       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func escapeLabelValue(s string) string {
+	return labelValueReplacer.Replace(s)
+}
+
+func escapeHelp(s string) string {
+	return helpReplacer.Replace(s)
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	case math.IsNaN(f):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (w *countingWriter) printf(format string, args ...any) {
+	if w.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(w.w, format, args...)
+	w.n += int64(n)
+	w.err = err
+}
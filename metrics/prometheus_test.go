@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrometheus_WriteTo(t *testing.T) {
+	p := NewPrometheus()
+
+	counter := p.Counter("test_requests_total", "Total requests.", "route")
+	counter.Add(1, "/a")
+	counter.Add(2, "/a")
+	counter.Add(1, `/b"c`)
+
+	p.Gauge("test_latency_seconds", "Latency.").Set(0.5)
+	p.GaugeFunc("test_entries", "Entries.", Labels{"cache": "guilds"}, func() float64 { return 3 })
+
+	histogram := p.Histogram("test_duration_seconds", "Duration.", []float64{0.1, 1}, "route")
+	histogram.Observe(0.05, "/a")
+	histogram.Observe(0.5, "/a")
+
+	var sb strings.Builder
+	if _, err := p.WriteTo(&sb); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# HELP test_duration_seconds Duration.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{route="/a",le="0.1"} 1
+test_duration_seconds_bucket{route="/a",le="1"} 2
+test_duration_seconds_bucket{route="/a",le="+Inf"} 2
+test_duration_seconds_sum{route="/a"} 0.55
+test_duration_seconds_count{route="/a"} 2
+# HELP test_entries Entries.
+# TYPE test_entries gauge
+test_entries{cache="guilds"} 3
+# HELP test_latency_seconds Latency.
+# TYPE test_latency_seconds gauge
+test_latency_seconds 0.5
+# HELP test_requests_total Total requests.
+# TYPE test_requests_total counter
+test_requests_total{route="/a"} 3
+test_requests_total{route="/b\"c"} 1
+`
+	if sb.String() != expected {
+		t.Errorf("unexpected output:\n%s\nexpected:\n%s", sb.String(), expected)
+	}
+}
@@ -1,6 +1,9 @@
 package rest
 
 import (
+	"context"
+	"iter"
+
 	"github.com/disgoorg/snowflake/v2"
 
 	"github.com/disgoorg/disgo/discord"
@@ -38,6 +41,8 @@ type Applications interface {
 	UpdateApplicationRoleConnectionMetadata(applicationID snowflake.ID, newRecords []discord.ApplicationRoleConnectionMetadata, opts ...RequestOpt) ([]discord.ApplicationRoleConnectionMetadata, error)
 
 	GetEntitlements(applicationID snowflake.ID, params GetEntitlementsParams, opts ...RequestOpt) ([]discord.Entitlement, error)
+	// GetEntitlementsIter iterates over all entitlements matching params starting after params.After. params.Before & params.Limit are ignored.
+	GetEntitlementsIter(ctx context.Context, applicationID snowflake.ID, params GetEntitlementsParams, limit int, opts ...RequestOpt) iter.Seq2[discord.Entitlement, error]
 	GetEntitlement(applicationID snowflake.ID, entitlementID snowflake.ID, opts ...RequestOpt) (*discord.Entitlement, error)
 	CreateTestEntitlement(applicationID snowflake.ID, entitlementCreate discord.TestEntitlementCreate, opts ...RequestOpt) (*discord.Entitlement, error)
 	DeleteTestEntitlement(applicationID snowflake.ID, entitlementID snowflake.ID, opts ...RequestOpt) error
@@ -225,6 +230,21 @@ func (s *applicationsImpl) GetEntitlements(applicationID snowflake.ID, params Ge
 	return
 }
 
+func (s *applicationsImpl) GetEntitlementsIter(ctx context.Context, applicationID snowflake.ID, params GetEntitlementsParams, limit int, opts ...RequestOpt) iter.Seq2[discord.Entitlement, error] {
+	opts = withIterCtx(ctx, opts)
+	return pageIter(ctx, snowflake.ID(params.After), true, limit, 100,
+		func(after snowflake.ID, limit int) ([]discord.Entitlement, error) {
+			params.Before = 0
+			params.After = int(after)
+			params.Limit = limit
+			return s.GetEntitlements(applicationID, params, opts...)
+		},
+		func(entitlement discord.Entitlement) snowflake.ID {
+			return entitlement.ID
+		},
+	)
+}
+
 func (s *applicationsImpl) GetEntitlement(applicationID snowflake.ID, entitlementID snowflake.ID, opts ...RequestOpt) (entitlement *discord.Entitlement, err error) {
 	err = s.client.Do(GetEntitlement.Compile(nil, applicationID, entitlementID), nil, &entitlement, opts...)
 	return
@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"context"
+	"iter"
 	"time"
 
 	"github.com/disgoorg/snowflake/v2"
@@ -30,6 +32,7 @@ type Channels interface {
 	GetMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error)
 	GetMessages(channelID snowflake.ID, around snowflake.ID, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.Message, error)
 	GetMessagesPage(channelID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) Page[discord.Message]
+	GetMessagesIter(ctx context.Context, channelID snowflake.ID, before snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.Message, error]
 	CreateMessage(channelID snowflake.ID, messageCreate discord.MessageCreate, opts ...RequestOpt) (*discord.Message, error)
 	UpdateMessage(channelID snowflake.ID, messageID snowflake.ID, messageUpdate discord.MessageUpdate, opts ...RequestOpt) (*discord.Message, error)
 	DeleteMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) error
@@ -37,6 +40,7 @@ type Channels interface {
 	CrosspostMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error)
 
 	GetReactions(channelID snowflake.ID, messageID snowflake.ID, emoji string, reactionType discord.MessageReactionType, after int, limit int, opts ...RequestOpt) ([]discord.User, error)
+	GetReactionsIter(ctx context.Context, channelID snowflake.ID, messageID snowflake.ID, emoji string, reactionType discord.MessageReactionType, limit int, opts ...RequestOpt) iter.Seq2[discord.User, error]
 	AddReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) error
 	RemoveOwnReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) error
 	RemoveUserReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, userID snowflake.ID, opts ...RequestOpt) error
@@ -54,6 +58,7 @@ type Channels interface {
 
 	GetPollAnswerVotes(channelID snowflake.ID, messageID snowflake.ID, answerID int, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.User, error)
 	GetPollAnswerVotesPage(channelID snowflake.ID, messageID snowflake.ID, answerID int, startID snowflake.ID, limit int, opts ...RequestOpt) PollAnswerVotesPage
+	GetPollAnswerVotesIter(ctx context.Context, channelID snowflake.ID, messageID snowflake.ID, answerID int, limit int, opts ...RequestOpt) iter.Seq2[discord.User, error]
 	ExpirePoll(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error)
 }
 
@@ -147,6 +152,18 @@ func (s *channelImpl) GetMessagesPage(channelID snowflake.ID, startID snowflake.
 	}
 }
 
+func (s *channelImpl) GetMessagesIter(ctx context.Context, channelID snowflake.ID, before snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.Message, error] {
+	opts = withIterCtx(ctx, opts)
+	return pageIter(ctx, before, false, limit, 100,
+		func(before snowflake.ID, limit int) ([]discord.Message, error) {
+			return s.GetMessages(channelID, 0, before, 0, limit, opts...)
+		},
+		func(message discord.Message) snowflake.ID {
+			return message.ID
+		},
+	)
+}
+
 func (s *channelImpl) CreateMessage(channelID snowflake.ID, messageCreate discord.MessageCreate, opts ...RequestOpt) (message *discord.Message, err error) {
 	body, err := messageCreate.ToBody()
 	if err != nil {
@@ -192,6 +209,18 @@ func (s *channelImpl) GetReactions(channelID snowflake.ID, messageID snowflake.I
 	return
 }
 
+func (s *channelImpl) GetReactionsIter(ctx context.Context, channelID snowflake.ID, messageID snowflake.ID, emoji string, reactionType discord.MessageReactionType, limit int, opts ...RequestOpt) iter.Seq2[discord.User, error] {
+	opts = withIterCtx(ctx, opts)
+	return pageIter(ctx, 0, true, limit, 100,
+		func(after snowflake.ID, limit int) ([]discord.User, error) {
+			return s.GetReactions(channelID, messageID, emoji, reactionType, int(after), limit, opts...)
+		},
+		func(user discord.User) snowflake.ID {
+			return user.ID
+		},
+	)
+}
+
 func (s *channelImpl) AddReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) error {
 	return s.client.Do(AddReaction.Compile(nil, channelID, messageID, emoji), nil, nil, opts...)
 }
@@ -268,6 +297,18 @@ func (s *channelImpl) GetPollAnswerVotesPage(channelID snowflake.ID, messageID s
 	}
 }
 
+func (s *channelImpl) GetPollAnswerVotesIter(ctx context.Context, channelID snowflake.ID, messageID snowflake.ID, answerID int, limit int, opts ...RequestOpt) iter.Seq2[discord.User, error] {
+	opts = withIterCtx(ctx, opts)
+	return pageIter(ctx, 0, true, limit, 100,
+		func(after snowflake.ID, limit int) ([]discord.User, error) {
+			return s.GetPollAnswerVotes(channelID, messageID, answerID, after, limit, opts...)
+		},
+		func(user discord.User) snowflake.ID {
+			return user.ID
+		},
+	)
+}
+
 func (s *channelImpl) ExpirePoll(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (message *discord.Message, err error) {
 	err = s.client.Do(ExpirePoll.Compile(nil, channelID, messageID), nil, &message, opts...)
 	return
@@ -1,6 +1,9 @@
 package rest
 
 import (
+	"context"
+	"iter"
+
 	"github.com/disgoorg/snowflake/v2"
 
 	"github.com/disgoorg/disgo/discord"
@@ -21,6 +24,7 @@ type GuildScheduledEvents interface {
 
 	GetGuildScheduledEventUsers(guildID snowflake.ID, guildScheduledEventID snowflake.ID, withMember bool, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.GuildScheduledEventUser, error)
 	GetGuildScheduledEventUsersPage(guildID snowflake.ID, guildScheduledEventID snowflake.ID, withMember bool, startID snowflake.ID, limit int, opts ...RequestOpt) Page[discord.GuildScheduledEventUser]
+	GetGuildScheduledEventUsersIter(ctx context.Context, guildID snowflake.ID, guildScheduledEventID snowflake.ID, withMember bool, limit int, opts ...RequestOpt) iter.Seq2[discord.GuildScheduledEventUser, error]
 }
 
 type guildScheduledEventImpl struct {
@@ -65,7 +69,7 @@ func (s *guildScheduledEventImpl) GetGuildScheduledEventUsers(guildID snowflake.
 		queryValues["limit"] = limit
 	}
 	if withMember {
-		queryValues["withMember"] = true
+		queryValues["with_member"] = true
 	}
 	if before != 0 {
 		queryValues["before"] = before
@@ -73,7 +77,7 @@ func (s *guildScheduledEventImpl) GetGuildScheduledEventUsers(guildID snowflake.
 	if after != 0 {
 		queryValues["after"] = after
 	}
-	err = s.client.Do(GetGuildScheduledEventUsers.Compile(nil, guildID, guildScheduledEventID), nil, &guildScheduledEventUsers, opts...)
+	err = s.client.Do(GetGuildScheduledEventUsers.Compile(queryValues, guildID, guildScheduledEventID), nil, &guildScheduledEventUsers, opts...)
 	return
 }
 
@@ -88,3 +92,15 @@ func (s *guildScheduledEventImpl) GetGuildScheduledEventUsersPage(guildID snowfl
 		ID: startID,
 	}
 }
+
+func (s *guildScheduledEventImpl) GetGuildScheduledEventUsersIter(ctx context.Context, guildID snowflake.ID, guildScheduledEventID snowflake.ID, withMember bool, limit int, opts ...RequestOpt) iter.Seq2[discord.GuildScheduledEventUser, error] {
+	opts = withIterCtx(ctx, opts)
+	return pageIter(ctx, 0, true, limit, 100,
+		func(after snowflake.ID, limit int) ([]discord.GuildScheduledEventUser, error) {
+			return s.GetGuildScheduledEventUsers(guildID, guildScheduledEventID, withMember, 0, after, limit, opts...)
+		},
+		func(user discord.GuildScheduledEventUser) snowflake.ID {
+			return user.User.ID
+		},
+	)
+}
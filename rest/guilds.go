@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"context"
+	"iter"
 	"time"
 
 	"github.com/disgoorg/snowflake/v2"
@@ -35,6 +37,7 @@ type Guilds interface {
 
 	GetBans(guildID snowflake.ID, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.Ban, error)
 	GetBansPage(guildID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) Page[discord.Ban]
+	GetBansIter(ctx context.Context, guildID snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.Ban, error]
 	GetBan(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) (*discord.Ban, error)
 	AddBan(guildID snowflake.ID, userID snowflake.ID, deleteMessageDuration time.Duration, opts ...RequestOpt) error
 	DeleteBan(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) error
@@ -52,6 +55,7 @@ type Guilds interface {
 
 	GetAuditLog(guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) (*discord.AuditLog, error)
 	GetAuditLogPage(guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, startID snowflake.ID, limit int, opts ...RequestOpt) AuditLogPage
+	GetAuditLogIter(ctx context.Context, guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, limit int, opts ...RequestOpt) iter.Seq2[discord.AuditLogEntry, error]
 
 	GetGuildWelcomeScreen(guildID snowflake.ID, opts ...RequestOpt) (*discord.GuildWelcomeScreen, error)
 	UpdateGuildWelcomeScreen(guildID snowflake.ID, screenUpdate discord.GuildWelcomeScreenUpdate, opts ...RequestOpt) (*discord.GuildWelcomeScreen, error)
@@ -189,6 +193,18 @@ func (s *guildImpl) GetBansPage(guildID snowflake.ID, startID snowflake.ID, limi
 	}
 }
 
+func (s *guildImpl) GetBansIter(ctx context.Context, guildID snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.Ban, error] {
+	opts = withIterCtx(ctx, opts)
+	return pageIter(ctx, 0, true, limit, 1000,
+		func(after snowflake.ID, limit int) ([]discord.Ban, error) {
+			return s.GetBans(guildID, 0, after, limit, opts...)
+		},
+		func(ban discord.Ban) snowflake.ID {
+			return ban.User.ID
+		},
+	)
+}
+
 func (s *guildImpl) GetBan(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) (ban *discord.Ban, err error) {
 	err = s.client.Do(GetBan.Compile(nil, guildID, userID), nil, &ban, opts...)
 	return
@@ -282,6 +298,22 @@ func (s *guildImpl) GetAuditLogPage(guildID snowflake.ID, userID snowflake.ID, a
 	}
 }
 
+func (s *guildImpl) GetAuditLogIter(ctx context.Context, guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, limit int, opts ...RequestOpt) iter.Seq2[discord.AuditLogEntry, error] {
+	opts = withIterCtx(ctx, opts)
+	return pageIter(ctx, 0, false, limit, 100,
+		func(before snowflake.ID, limit int) ([]discord.AuditLogEntry, error) {
+			auditLog, err := s.GetAuditLog(guildID, userID, actionType, before, 0, limit, opts...)
+			if err != nil {
+				return nil, err
+			}
+			return auditLog.AuditLogEntries, nil
+		},
+		func(entry discord.AuditLogEntry) snowflake.ID {
+			return entry.ID
+		},
+	)
+}
+
 func (s *guildImpl) GetGuildWelcomeScreen(guildID snowflake.ID, opts ...RequestOpt) (welcomeScreen *discord.GuildWelcomeScreen, err error) {
 	err = s.client.Do(GetGuildWelcomeScreen.Compile(nil, guildID), nil, &welcomeScreen, opts...)
 	return
@@ -1,7 +1,9 @@
 package rest
 
 import (
+	"context"
 	"errors"
+	"iter"
 	"net/url"
 
 	"github.com/disgoorg/snowflake/v2"
@@ -32,6 +34,9 @@ type OAuth2 interface {
 	// GetCurrentUserGuildsPage returns a Page of guilds the current user is a member of. Requires the discord.OAuth2ScopeGuilds scope.
 	// Leave bearerToken empty to use the bot token.
 	GetCurrentUserGuildsPage(bearerToken string, startID snowflake.ID, limit int, withCounts bool, opts ...RequestOpt) Page[discord.OAuth2Guild]
+	// GetCurrentUserGuildsIter iterates over all guilds the current user is a member of. Requires the discord.OAuth2ScopeGuilds scope.
+	// Leave bearerToken empty to use the bot token.
+	GetCurrentUserGuildsIter(ctx context.Context, bearerToken string, withCounts bool, limit int, opts ...RequestOpt) iter.Seq2[discord.OAuth2Guild, error]
 	GetCurrentUserConnections(bearerToken string, opts ...RequestOpt) ([]discord.Connection, error)
 
 	SetGuildCommandPermissions(bearerToken string, applicationID snowflake.ID, guildID snowflake.ID, commandID snowflake.ID, commandPermissions []discord.ApplicationCommandPermission, opts ...RequestOpt) (*discord.ApplicationCommandPermissions, error)
@@ -109,6 +114,18 @@ func (s *oAuth2Impl) GetCurrentUserGuildsPage(bearerToken string, startID snowfl
 	}
 }
 
+func (s *oAuth2Impl) GetCurrentUserGuildsIter(ctx context.Context, bearerToken string, withCounts bool, limit int, opts ...RequestOpt) iter.Seq2[discord.OAuth2Guild, error] {
+	opts = withIterCtx(ctx, opts)
+	return pageIter(ctx, 0, true, limit, 200,
+		func(after snowflake.ID, limit int) ([]discord.OAuth2Guild, error) {
+			return s.GetCurrentUserGuilds(bearerToken, 0, after, limit, withCounts, opts...)
+		},
+		func(guild discord.OAuth2Guild) snowflake.ID {
+			return guild.ID
+		},
+	)
+}
+
 func (s *oAuth2Impl) GetCurrentUserConnections(bearerToken string, opts ...RequestOpt) (connections []discord.Connection, err error) {
 	if bearerToken == "" {
 		return nil, ErrMissingBearerToken
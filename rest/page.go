@@ -1,7 +1,9 @@
 package rest
 
 import (
+	"context"
 	"errors"
+	"iter"
 
 	"github.com/disgoorg/snowflake/v2"
 
@@ -142,3 +144,107 @@ func (p *PollAnswerVotesPage) Next() bool {
 	}
 	return p.Err == nil
 }
+
+// pageIter returns an iter.Seq2 which yields all items returned by getItems until no items are left, limit items were yielded or ctx is done.
+// getItems is called with the cursor & the amount of items to request.
+// The cursor starts at startID and is moved to the highest (after) or lowest (before) ID of the previous page.
+// A limit <= 0 yields all items.
+func pageIter[T any](ctx context.Context, startID snowflake.ID, after bool, limit int, pageSize int, getItems func(cursor snowflake.ID, limit int) ([]T, error), getID func(t T) snowflake.ID) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		cursor := startID
+		yielded := 0
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			size := pageSize
+			if limit > 0 && limit-yielded < size {
+				size = limit - yielded
+			}
+
+			items, err := getItems(cursor, size)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			next := cursor
+			for i, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+				yielded++
+				if limit > 0 && yielded >= limit {
+					return
+				}
+
+				id := getID(item)
+				if i == 0 || (after && id > next) || (!after && id < next) {
+					next = id
+				}
+			}
+
+			// stop if the page was not full or the cursor did not move, which would request the same page again
+			if len(items) < size || next == cursor {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
+// threadsIter returns an iter.Seq2 which yields all archived threads returned by getThreads until discord reports no more threads, limit threads were yielded or ctx is done.
+// The cursor of the next page is the smallest cursor of the threads of a page according to less. A limit <= 0 yields all threads.
+func threadsIter[C any](ctx context.Context, before C, limit int, getThreads func(before C, limit int) (*discord.GetThreads, error), cursor func(thread discord.GuildThread) C, less func(a C, b C) bool) iter.Seq2[discord.GuildThread, error] {
+	return func(yield func(discord.GuildThread, error) bool) {
+		yielded := 0
+		for page := 0; ; page++ {
+			if err := ctx.Err(); err != nil {
+				yield(discord.GuildThread{}, err)
+				return
+			}
+
+			size := 100
+			if limit > 0 && limit-yielded < size {
+				size = limit - yielded
+			}
+
+			threads, err := getThreads(before, size)
+			if err != nil {
+				yield(discord.GuildThread{}, err)
+				return
+			}
+
+			var (
+				next  C
+				found bool
+			)
+			for _, thread := range threads.Threads {
+				if !yield(thread, nil) {
+					return
+				}
+				yielded++
+				if limit > 0 && yielded >= limit {
+					return
+				}
+				if c := cursor(thread); !found || less(c, next) {
+					next, found = c, true
+				}
+			}
+
+			// stop if the cursor did not move, which would request the same page again
+			if !threads.HasMore || !found || (page > 0 && !less(next, before)) {
+				return
+			}
+			before = next
+		}
+	}
+}
+
+// withIterCtx appends WithCtx to the given RequestOpt(s), so all requests of an iterator use the iterator's context.
+func withIterCtx(ctx context.Context, opts []RequestOpt) []RequestOpt {
+	return append(opts[:len(opts):len(opts)], WithCtx(ctx))
+}
@@ -0,0 +1,187 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestPageIter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		cursors []snowflake.ID
+		limits  []int
+	)
+	getItems := func(after snowflake.ID, limit int) ([]snowflake.ID, error) {
+		cursors = append(cursors, after)
+		limits = append(limits, limit)
+		var ids []snowflake.ID
+		for id := after + 1; id <= 250 && len(ids) < limit; id++ {
+			ids = append(ids, id)
+		}
+		return ids, nil
+	}
+	getID := func(id snowflake.ID) snowflake.ID { return id }
+
+	var count int
+	for id, err := range pageIter(context.Background(), 0, true, 230, 100, getItems, getID) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		count++
+		if id != snowflake.ID(count) {
+			t.Fatalf("expected id %d, got %d", count, id)
+		}
+	}
+	if count != 230 {
+		t.Errorf("expected 230 items, got %d", count)
+	}
+	if fmt.Sprint(cursors) != "[0 100 200]" || fmt.Sprint(limits) != "[100 100 30]" {
+		t.Errorf("expected cursors [0 100 200] & limits [100 100 30], got %v & %v", cursors, limits)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	count = 0
+	for _, err := range pageIter(ctx, 0, true, 0, 100, getItems, getID) {
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected context.Canceled, got %v", err)
+			}
+			break
+		}
+		count++
+		if count == 100 {
+			cancel()
+		}
+	}
+	if count != 100 {
+		t.Errorf("expected iteration to stop after 100 items, got %d", count)
+	}
+}
+
+func TestChannels_GetMessagesIter(t *testing.T) {
+	t.Parallel()
+
+	var befores []string
+	mock := func(next RequestHandler) RequestHandler {
+		return func(rq *Request) (*Response, error) {
+			query := rq.Request.URL.Query()
+			befores = append(befores, query.Get("before"))
+
+			before, _ := strconv.Atoi(query.Get("before"))
+			if before == 0 {
+				before = 151
+			}
+			limit, _ := strconv.Atoi(query.Get("limit"))
+
+			var messages []discord.Message
+			for id := before - 1; id > 0 && len(messages) < limit; id-- {
+				messages = append(messages, discord.Message{ID: snowflake.ID(id)})
+			}
+			body, _ := json.Marshal(messages)
+			return &Response{
+				Response: &http.Response{StatusCode: http.StatusOK, Status: "200 OK"},
+				RawBody:  body,
+			}, nil
+		}
+	}
+	channels := NewChannels(NewClient("", WithMiddlewares(mock)))
+
+	var ids []snowflake.ID
+	for message, err := range channels.GetMessagesIter(context.Background(), 1, 0, 0) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, message.ID)
+	}
+	if len(ids) != 150 || ids[0] != 150 || ids[149] != 1 {
+		t.Errorf("expected messages 150 to 1, got %d messages", len(ids))
+	}
+	if fmt.Sprint(befores) != "[ 51]" {
+		t.Errorf("expected before cursors [ 51], got %v", befores)
+	}
+}
+
+func TestThreads_GetArchivedThreadsIter(t *testing.T) {
+	t.Parallel()
+
+	// all threads were archived within the same second
+	archived := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var befores []string
+	mock := func(next RequestHandler) RequestHandler {
+		return func(rq *Request) (*Response, error) {
+			query := rq.Request.URL.Query()
+			befores = append(befores, query.Get("before"))
+			limit, _ := strconv.Atoi(query.Get("limit"))
+
+			var before func(id int) bool
+			switch rq.Endpoint.Endpoint {
+			case GetJoinedPrivateArchivedThreads:
+				cursor, _ := strconv.Atoi(query.Get("before"))
+				before = func(id int) bool { return cursor == 0 || id < cursor }
+			default:
+				cursor, _ := time.Parse(time.RFC3339Nano, query.Get("before"))
+				before = func(id int) bool {
+					return cursor.IsZero() || archived.Add(time.Duration(id)*time.Millisecond).Before(cursor)
+				}
+			}
+
+			var threads []string
+			hasMore := false
+			for id := 150; id > 0; id-- {
+				if !before(id) {
+					continue
+				}
+				if len(threads) == limit {
+					hasMore = true
+					break
+				}
+				threads = append(threads, fmt.Sprintf(`{"id": "%d", "type": 11, "thread_metadata": {"archive_timestamp": "%s"}}`, id, archived.Add(time.Duration(id)*time.Millisecond).Format(time.RFC3339Nano)))
+			}
+			return &Response{
+				Response: &http.Response{StatusCode: http.StatusOK, Status: "200 OK"},
+				RawBody:  []byte(fmt.Sprintf(`{"threads": [%s], "members": [], "has_more": %t}`, strings.Join(threads, ","), hasMore)),
+			}, nil
+		}
+	}
+	threads := NewThreads(NewClient("", WithMiddlewares(mock)))
+
+	count := func(seq iter.Seq2[discord.GuildThread, error]) int {
+		n := 0
+		for _, err := range seq {
+			if err != nil {
+				t.Fatal(err)
+			}
+			n++
+		}
+		return n
+	}
+
+	if n := count(threads.GetJoinedPrivateArchivedThreadsIter(context.Background(), 1, 0, 0)); n != 150 {
+		t.Errorf("expected 150 joined threads, got %d", n)
+	}
+	if fmt.Sprint(befores) != "[ 51]" {
+		t.Errorf("expected thread id cursors [ 51], got %v", befores)
+	}
+
+	befores = nil
+	if n := count(threads.GetPublicArchivedThreadsIter(context.Background(), 1, time.Time{}, 0)); n != 150 {
+		t.Errorf("expected 150 public threads, got %d", n)
+	}
+	if len(befores) != 2 || befores[1] != archived.Add(51*time.Millisecond).Format(time.RFC3339Nano) {
+		t.Errorf("expected sub second archive timestamp cursor, got %v", befores)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/disgoorg/json/v2"
 
 	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/tracing"
 )
 
 // NewClient constructs a new Client with the given config struct
@@ -59,10 +60,9 @@ func (c *clientImpl) RateLimiter() RateLimiter {
 	return c.config.RateLimiter
 }
 
-func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, tries int, opts []RequestOpt) error {
+func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, tries int, span tracing.Span, opts []RequestOpt) (err error) {
 	var (
 		rawRqBody   []byte
-		err         error
 		contentType string
 	)
 
@@ -103,6 +103,22 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 	cfg := defaultRequestConfig(rq)
 	cfg.apply(opts)
 
+	if span == nil {
+		cfg.Ctx, span = c.config.Tracer.Start(cfg.Ctx, "rest "+endpoint.Endpoint.Method+" "+endpoint.Endpoint.Route,
+			tracing.String("disgo.rest.method", endpoint.Endpoint.Method),
+			tracing.String("disgo.rest.route", endpoint.Endpoint.Route),
+		)
+		// retries should use the context of the span
+		opts = append(opts, WithCtx(cfg.Ctx))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+			}
+			span.End()
+		}()
+	}
+	span.SetAttributes(tracing.Int("disgo.rest.retries", tries-1))
+
 	if cfg.Delay > 0 {
 		timer := time.NewTimer(cfg.Delay)
 		defer timer.Stop()
@@ -113,41 +129,33 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 		}
 	}
 
-	// wait for rate limits
-	err = c.RateLimiter().Wait(cfg.Ctx, endpoint)
-	if err != nil {
-		return fmt.Errorf("error locking bucket in rest client: %w", err)
-	}
 	rq = cfg.Request.WithContext(cfg.Ctx)
 
-	for _, check := range cfg.Checks {
-		if !check() {
-			_ = c.RateLimiter().Unlock(endpoint, nil)
-			return discord.ErrCheckFailed
-		}
+	request := &Request{
+		Endpoint: endpoint,
+		Body:     rqBody,
+		RawBody:  rawRqBody,
+		Request:  rq,
+		Try:      tries,
 	}
-
-	rs, err := c.HTTPClient().Do(rq)
+	response, err := c.config.Middlewares.Then(c.roundTrip(cfg.Checks))(request)
 	if err != nil {
-		_ = c.RateLimiter().Unlock(endpoint, nil)
-		return fmt.Errorf("error doing request in rest client: %w", err)
-	}
-
-	if err = c.RateLimiter().Unlock(endpoint, rs); err != nil {
-		return fmt.Errorf("error unlocking bucket in rest client: %w", err)
+		return err
 	}
-
-	var rawRsBody []byte
-	if rs.Body != nil {
-		if rawRsBody, err = io.ReadAll(rs.Body); err != nil {
-			return fmt.Errorf("error reading response body in rest client: %w", err)
-		}
+	rq = request.Request
+	rs := response.Response
+	rawRsBody := response.RawBody
+	span.SetAttributes(
+		tracing.Int("http.status_code", rs.StatusCode),
+		tracing.String("disgo.rest.bucket", rs.Header.Get("X-RateLimit-Bucket")),
+	)
+	if rawRsBody != nil {
 		c.config.Logger.Debug("new response", slog.String("endpoint", endpoint.URL), slog.String("code", rs.Status), slog.String("body", string(rawRsBody)))
 	}
 
 	switch rs.StatusCode {
 	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
-		if rsBody != nil && rs.Body != nil {
+		if rsBody != nil && rawRsBody != nil {
 			if err = json.Unmarshal(rawRsBody, rsBody); err != nil {
 				c.config.Logger.Error("error unmarshalling response body", slog.Any("err", err), slog.String("endpoint", endpoint.URL), slog.String("code", rs.Status), slog.String("body", string(rawRsBody)))
 				return fmt.Errorf("error unmarshalling response body: %w", err)
@@ -159,13 +167,51 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 		if tries >= c.RateLimiter().MaxRetries() {
 			return newError(rq, rawRqBody, rs, rawRsBody)
 		}
-		return c.retry(endpoint, rqBody, rsBody, tries+1, opts)
+		return c.retry(endpoint, rqBody, rsBody, tries+1, span, opts)
 
 	default:
 		return newError(rq, rawRqBody, rs, rawRsBody)
 	}
 }
 
+// roundTrip returns the innermost RequestHandler, which waits for the rate limit, runs the given Check(s) and does the request.
+func (c *clientImpl) roundTrip(checks []Check) RequestHandler {
+	return func(rq *Request) (*Response, error) {
+		// wait for rate limits
+		if err := c.RateLimiter().Wait(rq.Request.Context(), rq.Endpoint); err != nil {
+			return nil, fmt.Errorf("error locking bucket in rest client: %w", err)
+		}
+
+		for _, check := range checks {
+			if !check() {
+				_ = c.RateLimiter().Unlock(rq.Endpoint, nil)
+				return nil, discord.ErrCheckFailed
+			}
+		}
+
+		rs, err := c.HTTPClient().Do(rq.Request)
+		if err != nil {
+			_ = c.RateLimiter().Unlock(rq.Endpoint, nil)
+			return nil, fmt.Errorf("error doing request in rest client: %w", err)
+		}
+
+		if err = c.RateLimiter().Unlock(rq.Endpoint, rs); err != nil {
+			return nil, fmt.Errorf("error unlocking bucket in rest client: %w", err)
+		}
+
+		var rawRsBody []byte
+		if rs.Body != nil {
+			if rawRsBody, err = io.ReadAll(rs.Body); err != nil {
+				return nil, fmt.Errorf("error reading response body in rest client: %w", err)
+			}
+		}
+		return &Response{
+			Response: rs,
+			RawBody:  rawRsBody,
+		}, nil
+	}
+}
+
 func (c *clientImpl) Do(endpoint *CompiledEndpoint, rqBody any, rsBody any, opts ...RequestOpt) error {
-	return c.retry(endpoint, rqBody, rsBody, 1, opts)
+	return c.retry(endpoint, rqBody, rsBody, 1, nil, opts)
 }
@@ -5,6 +5,9 @@ import (
 	"log/slog"
 	"net/http"
 	"time"
+
+	"github.com/disgoorg/disgo/metrics"
+	"github.com/disgoorg/disgo/tracing"
 )
 
 func defaultConfig() config {
@@ -12,6 +15,8 @@ func defaultConfig() config {
 		Logger:     slog.Default(),
 		HTTPClient: &http.Client{Timeout: 20 * time.Second},
 		URL:        fmt.Sprintf("%sv%d", API, Version),
+		Metrics:    metrics.Noop,
+		Tracer:     tracing.Noop,
 	}
 }
 
@@ -22,6 +27,9 @@ type config struct {
 	RateLimiterConfigOpts []RateLimiterConfigOpt
 	URL                   string
 	UserAgent             string
+	Middlewares           Middlewares
+	Metrics               metrics.Metrics
+	Tracer                tracing.Tracer
 }
 
 // ConfigOpt can be used to supply optional parameters to NewClient
@@ -33,7 +41,10 @@ func (c *config) apply(opts []ConfigOpt) {
 	}
 	c.Logger = c.Logger.With(slog.String("name", "rest_client"))
 	if c.RateLimiter == nil {
-		c.RateLimiter = NewRateLimiter(c.RateLimiterConfigOpts...)
+		c.RateLimiter = NewRateLimiter(append([]RateLimiterConfigOpt{WithRateLimiterMetrics(c.Metrics)}, c.RateLimiterConfigOpts...)...)
+	}
+	if c.Metrics != metrics.Noop {
+		c.Middlewares = append(Middlewares{newMetricsMiddleware(c.Metrics)}, c.Middlewares...)
 	}
 }
 
@@ -85,3 +96,25 @@ func WithUserAgent(userAgent string) ConfigOpt {
 		config.UserAgent = userAgent
 	}
 }
+
+// WithMiddlewares adds the given Middleware(s) to the rest client. They are called in the given order for every request attempt.
+func WithMiddlewares(middlewares ...Middleware) ConfigOpt {
+	return func(config *config) {
+		config.Middlewares = append(config.Middlewares, middlewares...)
+	}
+}
+
+// WithMetrics sets the metrics.Metrics the rest client and its default RateLimiter record requests, responses & rate limits to.
+func WithMetrics(m metrics.Metrics) ConfigOpt {
+	return func(config *config) {
+		config.Metrics = m
+	}
+}
+
+// WithTracer sets the tracing.Tracer the rest client starts a span with for every request.
+// The span is a child of the span in the context passed via WithCtx.
+func WithTracer(tracer tracing.Tracer) ConfigOpt {
+	return func(config *config) {
+		config.Tracer = tracer
+	}
+}
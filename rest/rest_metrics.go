@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/disgoorg/disgo/metrics"
+)
+
+// newMetricsMiddleware returns a Middleware which records the number, duration & rate limits of all request attempts.
+func newMetricsMiddleware(m metrics.Metrics) Middleware {
+	requests := m.Counter("disgo_rest_requests_total", "Total number of rest request attempts by route & status code.", "method", "route", "status")
+	rateLimited := m.Counter("disgo_rest_rate_limited_total", "Total number of 429 responses by route & rate limit scope.", "method", "route", "scope")
+	duration := m.Histogram("disgo_rest_request_duration_seconds", "Duration of rest request attempts including rate limit waits.", nil, "method", "route")
+
+	return func(next RequestHandler) RequestHandler {
+		return func(rq *Request) (*Response, error) {
+			start := time.Now()
+			rs, err := next(rq)
+
+			method, route := rq.Endpoint.Endpoint.Method, rq.Endpoint.Endpoint.Route
+			duration.Observe(time.Since(start).Seconds(), method, route)
+
+			status := "error"
+			if err == nil {
+				status = strconv.Itoa(rs.Response.StatusCode)
+			}
+			requests.Add(1, method, route, status)
+
+			if err == nil && rs.Response.StatusCode == http.StatusTooManyRequests {
+				scope := "unknown"
+				if rs.Response.Header != nil && rs.Response.Header.Get("X-RateLimit-Scope") != "" {
+					scope = rs.Response.Header.Get("X-RateLimit-Scope")
+				}
+				rateLimited.Add(1, method, route, scope)
+			}
+			return rs, err
+		}
+	}
+}
+
+// rateLimiterMetrics are the metrics recorded by the RateLimiter implementations.
+type rateLimiterMetrics struct {
+	wait metrics.Histogram
+}
+
+func newRateLimiterMetrics(m metrics.Metrics, buckets func() int) rateLimiterMetrics {
+	m.GaugeFunc("disgo_rest_rate_limit_buckets", "Number of rest rate limit buckets currently tracked.", nil, func() float64 {
+		return float64(buckets())
+	})
+	return rateLimiterMetrics{
+		wait: m.Histogram("disgo_rest_rate_limit_wait_seconds", "Time spent waiting for rest rate limit buckets.", nil, "method", "route"),
+	}
+}
+
+func (m rateLimiterMetrics) observeWait(endpoint *CompiledEndpoint, start time.Time) {
+	m.wait.Observe(time.Since(start).Seconds(), endpoint.Endpoint.Method, endpoint.Endpoint.Route)
+}
@@ -0,0 +1,48 @@
+package rest
+
+import (
+	"net/http"
+)
+
+// Request is a single request attempt done by the Client. It is passed through all Middlewares of the Client.
+type Request struct {
+	// Endpoint is the CompiledEndpoint the request is made to.
+	Endpoint *CompiledEndpoint
+	// Body is the request body as passed to Client.Do.
+	Body any
+	// RawBody is the encoded request body.
+	RawBody []byte
+	// Request is the http.Request which will be sent. Its context is the one supplied via WithCtx.
+	Request *http.Request
+	// Try is the number of the attempt, starting at 1. It is increased every time the request is retried.
+	Try int
+}
+
+// Response is the response to a Request.
+type Response struct {
+	// Response is the received http.Response. Its body has already been read into RawBody.
+	Response *http.Response
+	// RawBody is the raw response body.
+	RawBody []byte
+}
+
+// RequestHandler does a single request attempt and returns the response.
+// If the returned error is nil, the returned Response must not be nil.
+type RequestHandler func(rq *Request) (*Response, error)
+
+type (
+	// Middleware is a function that wraps a RequestHandler to intercept and short-circuit requests made by the Client.
+	// The innermost RequestHandler waits for the RateLimiter, runs the Check(s) and sends the request with the http.Client.
+	Middleware func(next RequestHandler) RequestHandler
+
+	// Middlewares is a list of middlewares.
+	Middlewares []Middleware
+)
+
+// Then wraps the given RequestHandler with all Middlewares. The first Middleware is the outermost one.
+func (m Middlewares) Then(handler RequestHandler) RequestHandler {
+	for i := len(m) - 1; i >= 0; i-- {
+		handler = m[i](handler)
+	}
+	return handler
+}
@@ -0,0 +1,54 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestClient_Middlewares(t *testing.T) {
+	var (
+		order []string
+		tries []int
+	)
+	record := func(name string) Middleware {
+		return func(next RequestHandler) RequestHandler {
+			return func(rq *Request) (*Response, error) {
+				order = append(order, name)
+				return next(rq)
+			}
+		}
+	}
+	mock := func(next RequestHandler) RequestHandler {
+		return func(rq *Request) (*Response, error) {
+			tries = append(tries, rq.Try)
+			if rq.Try == 1 {
+				return &Response{
+					Response: &http.Response{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests"},
+					RawBody:  []byte(`{}`),
+				}, nil
+			}
+			return &Response{
+				Response: &http.Response{StatusCode: http.StatusOK, Status: "200 OK"},
+				RawBody:  []byte(`{"id":"1","username":"test"}`),
+			}, nil
+		}
+	}
+
+	client := NewClient("", WithRateLimiter(NewRateLimiter(WithMaxRetries(2))), WithMiddlewares(record("first"), record("second"), mock))
+
+	var user discord.User
+	if err := client.Do(GetCurrentUser.Compile(nil), nil, &user); err != nil {
+		t.Fatal(err)
+	}
+	if user.Username != "test" {
+		t.Errorf("expected username to be test, got %s", user.Username)
+	}
+	if len(tries) != 2 || tries[0] != 1 || tries[1] != 2 {
+		t.Errorf("expected middleware to see tries [1 2], got %v", tries)
+	}
+	if len(order) != 4 || order[0] != "first" || order[1] != "second" {
+		t.Errorf("expected middlewares to be called in order, got %v", order)
+	}
+}
@@ -0,0 +1,363 @@
+package rest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// MissingPermissionsError is returned by the Middleware of NewPermissionCheckMiddleware if the bot lacks permissions required by the request.
+type MissingPermissionsError struct {
+	Endpoint *CompiledEndpoint
+	GuildID  snowflake.ID
+	// ChannelID is 0 if the permissions are required guild wide.
+	ChannelID snowflake.ID
+	// Missing are the required permissions the bot lacks.
+	Missing discord.Permissions
+	// Denials explain why the bot lacks every one of the Missing permissions.
+	Denials []discord.PermissionDenial
+}
+
+func (e *MissingPermissionsError) Error() string {
+	denials := make([]string, len(e.Denials))
+	for i, denial := range e.Denials {
+		denials[i] = denial.String()
+	}
+	return fmt.Sprintf("missing permissions for %s %s: %s", e.Endpoint.Endpoint.Method, e.Endpoint.Endpoint.Route, strings.Join(denials, "; "))
+}
+
+// RoleHierarchyError is returned by the Middleware of NewPermissionCheckMiddleware if the request targets a member or role which is not below the highest role of the bot.
+type RoleHierarchyError struct {
+	Endpoint *CompiledEndpoint
+	GuildID  snowflake.ID
+	// UserID is the targeted member. It is 0 if the request targets a role, like adding or removing it from a member.
+	UserID snowflake.ID
+	// RoleID is the targeted role or the highest role of the targeted member. It is 0 if the targeted member owns the guild.
+	RoleID snowflake.ID
+}
+
+func (e *RoleHierarchyError) Error() string {
+	var target string
+	switch {
+	case e.UserID != 0 && e.RoleID == 0:
+		target = "the owner of the guild"
+	case e.UserID != 0:
+		target = fmt.Sprintf("member %s with role %s", e.UserID, e.RoleID)
+	default:
+		target = fmt.Sprintf("role %s", e.RoleID)
+	}
+	return fmt.Sprintf("role hierarchy prevents %s %s: %s is not below the highest role of the bot", e.Endpoint.Endpoint.Method, e.Endpoint.Endpoint.Route, target)
+}
+
+// permissionRequirement describes the permissions an Endpoint requires.
+// The {role.id} of an Endpoint always has to be below the highest role of the bot.
+type permissionRequirement struct {
+	// channel is true if the permissions are required in the {channel.id} instead of the {guild.id}.
+	channel bool
+	// permissions returns the permissions required for the request body & whether the member {user.id} has to be below the highest role of the bot.
+	permissions func(body any) (discord.Permissions, bool)
+}
+
+func requires(permissions discord.Permissions, hierarchy bool) func(body any) (discord.Permissions, bool) {
+	return func(any) (discord.Permissions, bool) {
+		return permissions, hierarchy
+	}
+}
+
+var permissionRequirements = map[*Endpoint]permissionRequirement{
+	AddBan:       {permissions: requires(discord.PermissionBanMembers, true)},
+	DeleteBan:    {permissions: requires(discord.PermissionBanMembers, false)},
+	BulkBan:      {permissions: requires(discord.PermissionBanMembers, false)},
+	RemoveMember: {permissions: requires(discord.PermissionKickMembers, true)},
+	UpdateMember: {permissions: memberUpdatePermissions},
+
+	AddMemberRole:    {permissions: requires(discord.PermissionManageRoles, false)},
+	RemoveMemberRole: {permissions: requires(discord.PermissionManageRoles, false)},
+	CreateRole:       {permissions: requires(discord.PermissionManageRoles, false)},
+	UpdateRole:       {permissions: requires(discord.PermissionManageRoles, false)},
+	DeleteRole:       {permissions: requires(discord.PermissionManageRoles, false)},
+
+	CreateMessage:           {channel: true, permissions: messageCreatePermissions},
+	BulkDeleteMessages:      {channel: true, permissions: requires(discord.PermissionViewChannel|discord.PermissionManageMessages, false)},
+	PinMessage:              {channel: true, permissions: requires(discord.PermissionViewChannel|discord.PermissionPinMessages, false)},
+	UnpinMessage:            {channel: true, permissions: requires(discord.PermissionViewChannel|discord.PermissionPinMessages, false)},
+	CreateThread:            {channel: true, permissions: threadCreatePermissions},
+	CreateThreadWithMessage: {channel: true, permissions: requires(discord.PermissionViewChannel|discord.PermissionCreatePublicThreads, false)},
+	CreateWebhook:           {channel: true, permissions: requires(discord.PermissionViewChannel|discord.PermissionManageWebhooks, false)},
+	CreateInvite:            {channel: true, permissions: requires(discord.PermissionViewChannel|discord.PermissionCreateInstantInvite, false)},
+}
+
+func memberUpdatePermissions(body any) (discord.Permissions, bool) {
+	memberUpdate, ok := body.(discord.MemberUpdate)
+	if !ok {
+		return discord.PermissionsNone, false
+	}
+	var (
+		permissions discord.Permissions
+		hierarchy   bool
+	)
+	if memberUpdate.ChannelID != nil {
+		permissions = permissions.Add(discord.PermissionMoveMembers)
+	}
+	if memberUpdate.Mute != nil {
+		permissions = permissions.Add(discord.PermissionMuteMembers)
+	}
+	if memberUpdate.Deaf != nil {
+		permissions = permissions.Add(discord.PermissionDeafenMembers)
+	}
+	if memberUpdate.Nick != nil {
+		permissions = permissions.Add(discord.PermissionManageNicknames)
+		hierarchy = true
+	}
+	if memberUpdate.Roles != nil {
+		// the added & removed roles are checked instead of the member
+		permissions = permissions.Add(discord.PermissionManageRoles)
+	}
+	if !memberUpdate.CommunicationDisabledUntil.IsZero() {
+		permissions = permissions.Add(discord.PermissionModerateMembers)
+		hierarchy = true
+	}
+	return permissions, hierarchy
+}
+
+func messageCreatePermissions(body any) (discord.Permissions, bool) {
+	permissions := discord.PermissionViewChannel | discord.PermissionSendMessages
+	switch messageCreate := body.(type) {
+	case *discord.MultipartBuffer:
+		permissions = permissions.Add(discord.PermissionAttachFiles)
+	case discord.MessageCreate:
+		if messageCreate.MessageReference != nil {
+			permissions = permissions.Add(discord.PermissionReadMessageHistory)
+		}
+	}
+	return permissions, false
+}
+
+func threadCreatePermissions(body any) (discord.Permissions, bool) {
+	threadCreate, ok := body.(discord.ThreadCreate)
+	if !ok {
+		// posts in forum & media channels
+		return discord.PermissionViewChannel | discord.PermissionSendMessages, false
+	}
+	if threadCreate.Type() == discord.ChannelTypeGuildPrivateThread {
+		return discord.PermissionViewChannel | discord.PermissionCreatePrivateThreads, false
+	}
+	return discord.PermissionViewChannel | discord.PermissionCreatePublicThreads, false
+}
+
+// routeParams returns the values of all url params of the CompiledEndpoint by their name.
+func routeParams(endpoint *CompiledEndpoint) map[string]string {
+	path, _, _ := strings.Cut(endpoint.URL, "?")
+	routeSegments := strings.Split(endpoint.Endpoint.Route, "/")
+	pathSegments := strings.Split(path, "/")
+	if len(routeSegments) != len(pathSegments) {
+		return nil
+	}
+
+	params := map[string]string{}
+	for i, segment := range routeSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params[segment[1:len(segment)-1]] = pathSegments[i]
+		}
+	}
+	return params
+}
+
+// PermissionCheckCache provides the cached entities NewPermissionCheckMiddleware checks the requests against. It is implemented by cache.Caches.
+type PermissionCheckCache interface {
+	Guild(guildID snowflake.ID) (discord.Guild, bool)
+	Channel(channelID snowflake.ID) (discord.GuildChannel, bool)
+	Role(guildID snowflake.ID, roleID snowflake.ID) (discord.Role, bool)
+	Member(guildID snowflake.ID, userID snowflake.ID) (discord.Member, bool)
+	SelfMember(guildID snowflake.ID) (discord.Member, bool)
+	MemberPermissionCalculator(member discord.Member) discord.PermissionCalculator
+}
+
+// NewPermissionCheckMiddleware returns a Middleware which checks the permissions & role hierarchy of the bot in the PermissionCheckCache before sending requests
+// to endpoints with known permission requirements, like bans, kicks, role updates, messages & threads.
+// If the bot lacks a permission, a *MissingPermissionsError is returned without making the request.
+// If the request bans, kicks, renames or times out a member which is not below the highest role of the bot, a *RoleHierarchyError is returned.
+// The same applies to updating or deleting roles and adding or removing roles from members, where the roles themselves have to be below the highest role of the bot.
+// Requests are only checked if the guild, channel & bot member are cached, otherwise they are sent as usual.
+func NewPermissionCheckMiddleware(caches PermissionCheckCache) Middleware {
+	return func(next RequestHandler) RequestHandler {
+		return func(rq *Request) (*Response, error) {
+			requirement, ok := permissionRequirements[rq.Endpoint.Endpoint]
+			if !ok {
+				return next(rq)
+			}
+			if err := checkPermissions(caches, rq, requirement); err != nil {
+				return nil, err
+			}
+			return next(rq)
+		}
+	}
+}
+
+func checkPermissions(caches PermissionCheckCache, rq *Request, requirement permissionRequirement) error {
+	params := routeParams(rq.Endpoint)
+
+	var (
+		guildID snowflake.ID
+		channel discord.GuildChannel
+	)
+	if requirement.channel {
+		channelID, err := snowflake.Parse(params["channel.id"])
+		if err != nil {
+			return nil
+		}
+		var ok bool
+		if channel, ok = caches.Channel(channelID); !ok {
+			return nil
+		}
+		guildID = channel.GuildID()
+	} else {
+		var err error
+		if guildID, err = snowflake.Parse(params["guild.id"]); err != nil {
+			return nil
+		}
+	}
+
+	guild, ok := caches.Guild(guildID)
+	if !ok {
+		return nil
+	}
+	self, ok := caches.SelfMember(guildID)
+	if !ok {
+		return nil
+	}
+
+	required, hierarchy := requirement.permissions(rq.Body)
+	calculator := caches.MemberPermissionCalculator(self)
+
+	var (
+		permissions discord.Permissions
+		explain     func(permissions discord.Permissions) []discord.PermissionDenial
+	)
+	switch c := channel.(type) {
+	case nil:
+		permissions = calculator.Permissions(self)
+		explain = func(permissions discord.Permissions) []discord.PermissionDenial {
+			return calculator.Explain(self, permissions)
+		}
+	case discord.GuildThread:
+		var parent discord.GuildChannel = c
+		if p, ok := caches.Channel(*c.ParentID()); ok {
+			parent = p
+		}
+		permissions = calculator.PermissionsInThread(self, parent)
+		explain = func(permissions discord.Permissions) []discord.PermissionDenial {
+			return calculator.ExplainInThread(self, parent, permissions)
+		}
+	default:
+		permissions = calculator.PermissionsInChannel(self, c)
+		explain = func(permissions discord.Permissions) []discord.PermissionDenial {
+			return calculator.ExplainInChannel(self, c, permissions)
+		}
+	}
+
+	if missing := required.Remove(permissions); missing != discord.PermissionsNone {
+		err := &MissingPermissionsError{
+			Endpoint: rq.Endpoint,
+			GuildID:  guildID,
+			Missing:  missing,
+			Denials:  explain(missing),
+		}
+		if channel != nil {
+			err.ChannelID = channel.ID()
+		}
+		return err
+	}
+
+	if guild.OwnerID == self.User.ID {
+		return nil
+	}
+	return checkRoleHierarchy(caches, rq, guild, self, params, hierarchy)
+}
+
+// higherRole returns whether the role a is higher than the role b. Roles with the same position are sorted by their ID.
+func higherRole(a discord.Role, b discord.Role) bool {
+	if a.Position != b.Position {
+		return a.Position > b.Position
+	}
+	return a.ID < b.ID
+}
+
+// highestRole returns the highest role of the member, or false if the member has no cached roles.
+func highestRole(caches PermissionCheckCache, member discord.Member) (discord.Role, bool) {
+	var (
+		highest discord.Role
+		found   bool
+	)
+	for _, roleID := range member.RoleIDs {
+		if role, ok := caches.Role(member.GuildID, roleID); ok && (!found || higherRole(role, highest)) {
+			highest = role
+			found = true
+		}
+	}
+	return highest, found
+}
+
+// checkRoleHierarchy checks that the {role.id}, the roles added or removed by a discord.MemberUpdate and, if memberHierarchy is set, the member {user.id} are below the highest role of the bot.
+func checkRoleHierarchy(caches PermissionCheckCache, rq *Request, guild discord.Guild, self discord.Member, params map[string]string, memberHierarchy bool) error {
+	selfHighest, selfHasRoles := highestRole(caches, self)
+	below := func(role discord.Role) bool {
+		return selfHasRoles && higherRole(selfHighest, role)
+	}
+
+	userID, _ := snowflake.Parse(params["user.id"])
+	if memberHierarchy && userID != 0 && userID != self.User.ID {
+		if userID == guild.OwnerID {
+			return &RoleHierarchyError{Endpoint: rq.Endpoint, GuildID: guild.ID, UserID: userID}
+		}
+		if member, ok := caches.Member(guild.ID, userID); ok {
+			if highest, ok := highestRole(caches, member); ok && !below(highest) {
+				return &RoleHierarchyError{Endpoint: rq.Endpoint, GuildID: guild.ID, UserID: userID, RoleID: highest.ID}
+			}
+		}
+	}
+
+	roleIDs := changedRoleIDs(caches, guild.ID, userID, rq.Body)
+	if roleID, err := snowflake.Parse(params["role.id"]); err == nil {
+		roleIDs = append(roleIDs, roleID)
+	}
+	for _, roleID := range roleIDs {
+		if roleID == guild.ID {
+			// the @everyone role
+			continue
+		}
+		if role, ok := caches.Role(guild.ID, roleID); ok && !below(role) {
+			return &RoleHierarchyError{Endpoint: rq.Endpoint, GuildID: guild.ID, RoleID: roleID}
+		}
+	}
+	return nil
+}
+
+// changedRoleIDs returns the roles a discord.MemberUpdate adds to or removes from the member. All roles of the update are returned if the member is not cached.
+func changedRoleIDs(caches PermissionCheckCache, guildID snowflake.ID, userID snowflake.ID, body any) []snowflake.ID {
+	memberUpdate, ok := body.(discord.MemberUpdate)
+	if !ok || memberUpdate.Roles == nil {
+		return nil
+	}
+	member, ok := caches.Member(guildID, userID)
+	if !ok {
+		return slices.Clone(*memberUpdate.Roles)
+	}
+
+	var roleIDs []snowflake.ID
+	for _, roleID := range *memberUpdate.Roles {
+		if !slices.Contains(member.RoleIDs, roleID) {
+			roleIDs = append(roleIDs, roleID)
+		}
+	}
+	for _, roleID := range member.RoleIDs {
+		if !slices.Contains(*memberUpdate.Roles, roleID) {
+			roleIDs = append(roleIDs, roleID)
+		}
+	}
+	return roleIDs
+}
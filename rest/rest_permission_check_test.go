@@ -0,0 +1,106 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestPermissionCheckMiddleware(t *testing.T) {
+	const (
+		guildID   snowflake.ID = 1
+		ownerID   snowflake.ID = 2
+		botID     snowflake.ID = 3
+		userID    snowflake.ID = 4
+		modID     snowflake.ID = 5
+		channelID snowflake.ID = 10
+		botRoleID snowflake.ID = 20
+		modRoleID snowflake.ID = 21
+		lowRoleID snowflake.ID = 22
+	)
+
+	var channel discord.UnmarshalChannel
+	if err := json.Unmarshal([]byte(`{"id":"10","guild_id":"1","type":0,"name":"general","permission_overwrites":[{"id":"1","type":0,"allow":"0","deny":"2048"}]}`), &channel); err != nil {
+		t.Fatal(err)
+	}
+
+	caches := cache.New(cache.WithCaches(cache.FlagsAll))
+	caches.SetSelfUser(discord.OAuth2User{User: discord.User{ID: botID}})
+	caches.AddGuild(discord.Guild{ID: guildID, OwnerID: ownerID})
+	caches.AddChannel(channel.Channel.(discord.GuildChannel))
+	caches.AddRole(discord.Role{ID: guildID, GuildID: guildID, Permissions: discord.PermissionViewChannel | discord.PermissionSendMessages})
+	caches.AddRole(discord.Role{ID: botRoleID, GuildID: guildID, Position: 1, Permissions: discord.PermissionKickMembers | discord.PermissionManageRoles})
+	caches.AddRole(discord.Role{ID: modRoleID, GuildID: guildID, Position: 2})
+	caches.AddRole(discord.Role{ID: lowRoleID, GuildID: guildID})
+	caches.AddMember(discord.Member{GuildID: guildID, User: discord.User{ID: botID}, RoleIDs: []snowflake.ID{botRoleID}})
+	caches.AddMember(discord.Member{GuildID: guildID, User: discord.User{ID: userID}})
+	caches.AddMember(discord.Member{GuildID: guildID, User: discord.User{ID: modID}, RoleIDs: []snowflake.ID{modRoleID}})
+
+	var requests int
+	mock := func(next RequestHandler) RequestHandler {
+		return func(rq *Request) (*Response, error) {
+			requests++
+			return &Response{Response: &http.Response{StatusCode: http.StatusNoContent, Status: "204 No Content"}}, nil
+		}
+	}
+	client := NewClient("", WithMiddlewares(NewPermissionCheckMiddleware(caches), mock))
+
+	if err := client.Do(RemoveMember.Compile(nil, guildID, userID), nil, nil); err != nil {
+		t.Errorf("expected kick to be allowed, got %v", err)
+	}
+	if err := client.Do(GetMember.Compile(nil, guildID, userID), nil, nil); err != nil {
+		t.Errorf("expected unchecked endpoint to be allowed, got %v", err)
+	}
+
+	var missingErr *MissingPermissionsError
+	if err := client.Do(AddBan.Compile(nil, guildID, userID), nil, nil); !errors.As(err, &missingErr) {
+		t.Errorf("expected missing permissions error, got %v", err)
+	} else if missingErr.Missing != discord.PermissionBanMembers || len(missingErr.Denials) != 1 || missingErr.Denials[0].Reason != discord.PermissionDenialReasonNotGranted {
+		t.Errorf("expected missing ban members, got %+v", missingErr)
+	}
+
+	if err := client.Do(CreateMessage.Compile(nil, channelID), discord.MessageCreate{Content: "test"}, nil); !errors.As(err, &missingErr) {
+		t.Errorf("expected missing permissions error, got %v", err)
+	} else if missingErr.ChannelID != channelID || missingErr.Missing != discord.PermissionSendMessages || missingErr.Denials[0].Reason != discord.PermissionDenialReasonEveryoneOverwrite {
+		t.Errorf("expected send messages to be denied by the everyone overwrite, got %+v", missingErr)
+	}
+
+	var hierarchyErr *RoleHierarchyError
+	if err := client.Do(RemoveMember.Compile(nil, guildID, modID), nil, nil); !errors.As(err, &hierarchyErr) {
+		t.Errorf("expected role hierarchy error, got %v", err)
+	} else if hierarchyErr.UserID != modID || hierarchyErr.RoleID != modRoleID {
+		t.Errorf("expected member with mod role, got %+v", hierarchyErr)
+	}
+	if err := client.Do(RemoveMember.Compile(nil, guildID, ownerID), nil, nil); !errors.As(err, &hierarchyErr) {
+		t.Errorf("expected role hierarchy error for the owner, got %v", err)
+	}
+	if err := client.Do(AddMemberRole.Compile(nil, guildID, userID, modRoleID), nil, nil); !errors.As(err, &hierarchyErr) || hierarchyErr.RoleID != modRoleID {
+		t.Errorf("expected role hierarchy error for the mod role, got %v", err)
+	}
+
+	if err := client.Do(UpdateMember.Compile(nil, guildID, userID), discord.MemberUpdate{Roles: &[]snowflake.ID{modRoleID}}, nil); !errors.As(err, &hierarchyErr) || hierarchyErr.RoleID != modRoleID || hierarchyErr.UserID != 0 {
+		t.Errorf("expected role hierarchy error for the added mod role, got %v", err)
+	}
+
+	// roles below the bot can be added to & removed from members above the bot
+	if err := client.Do(AddMemberRole.Compile(nil, guildID, modID, lowRoleID), nil, nil); err != nil {
+		t.Errorf("expected adding the low role to the mod to be allowed, got %v", err)
+	}
+	if err := client.Do(UpdateMember.Compile(nil, guildID, modID), discord.MemberUpdate{Roles: &[]snowflake.ID{modRoleID, lowRoleID}}, nil); err != nil {
+		t.Errorf("expected keeping the mod role & adding the low role to be allowed, got %v", err)
+	}
+
+	if err := client.Do(RemoveMember.Compile(nil, 100, userID), nil, nil); err != nil {
+		t.Errorf("expected request in uncached guild to be allowed, got %v", err)
+	}
+
+	if requests != 5 {
+		t.Errorf("expected 5 requests to be sent, got %d", requests)
+	}
+}
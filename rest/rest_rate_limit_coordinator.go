@@ -0,0 +1,178 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/disgoorg/disgo/internal/linejson"
+)
+
+// maxIdleRemoteConns is the maximum number of idle connections a remote RateLimitStore keeps open.
+const maxIdleRemoteConns = 16
+
+const (
+	rateLimitOpTryLock        = "try_lock"
+	rateLimitOpUnlock         = "unlock"
+	rateLimitOpGlobalReset    = "global_reset"
+	rateLimitOpSetGlobalReset = "set_global_reset"
+	rateLimitOpReset          = "reset"
+)
+
+// rateLimitStoreRequest is a single request sent from a remote RateLimitStore to a RateLimitCoordinator.
+type rateLimitStoreRequest struct {
+	Op     string          `json:"op"`
+	Key    string          `json:"key,omitempty"`
+	Token  string          `json:"token,omitempty"`
+	Lease  time.Duration   `json:"lease,omitempty"`
+	Bucket RateLimitBucket `json:"bucket"`
+	Reset  time.Time       `json:"reset"`
+}
+
+// rateLimitStoreResponse is the response of a RateLimitCoordinator to a rateLimitStoreRequest.
+type rateLimitStoreResponse struct {
+	Bucket RateLimitBucket `json:"bucket"`
+	OK     bool            `json:"ok"`
+	Reset  time.Time       `json:"reset"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// NewRateLimitCoordinator returns a new RateLimitCoordinator which exposes the given RateLimitStore to other processes.
+// If store is nil, a new in-memory RateLimitStore is used.
+func NewRateLimitCoordinator(store RateLimitStore, logger *slog.Logger) *RateLimitCoordinator {
+	if store == nil {
+		store = NewMemoryRateLimitStore()
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	c := &RateLimitCoordinator{
+		store: store,
+	}
+	c.server = linejson.NewServer(c.handleRequest, logger.With(slog.String("name", "rest_rate_limit_coordinator")))
+	return c
+}
+
+// RateLimitCoordinator serves a RateLimitStore over TCP or Unix sockets.
+// Other processes can connect to it with NewRemoteRateLimitStore and share their rest rate limits through NewSharedRateLimiter.
+//
+// The protocol is newline-delimited JSON and is only meant to be used in trusted networks.
+type RateLimitCoordinator struct {
+	store  RateLimitStore
+	server *linejson.Server[rateLimitStoreRequest, rateLimitStoreResponse]
+}
+
+// ListenAndServe listens on the given network address & serves the RateLimitStore until the RateLimitCoordinator is closed.
+func (c *RateLimitCoordinator) ListenAndServe(network string, address string) error {
+	return c.server.ListenAndServe(network, address)
+}
+
+// Serve accepts connections on the given net.Listener & serves the RateLimitStore until the RateLimitCoordinator is closed.
+func (c *RateLimitCoordinator) Serve(ln net.Listener) error {
+	return c.server.Serve(ln)
+}
+
+// Close stops all listeners & closes all open connections. The underlying RateLimitStore is closed as well.
+func (c *RateLimitCoordinator) Close(ctx context.Context) error {
+	return errors.Join(c.server.Close(), c.store.Close(ctx))
+}
+
+func (c *RateLimitCoordinator) handleRequest(rq rateLimitStoreRequest) rateLimitStoreResponse {
+	ctx := context.Background()
+
+	var (
+		rs  rateLimitStoreResponse
+		err error
+	)
+	switch rq.Op {
+	case rateLimitOpTryLock:
+		rs.Bucket, rs.OK, err = c.store.TryLock(ctx, rq.Key, rq.Token, rq.Lease)
+	case rateLimitOpUnlock:
+		err = c.store.Unlock(ctx, rq.Key, rq.Token, rq.Bucket)
+	case rateLimitOpGlobalReset:
+		rs.Reset, err = c.store.GlobalReset(ctx)
+	case rateLimitOpSetGlobalReset:
+		err = c.store.SetGlobalReset(ctx, rq.Reset)
+	case rateLimitOpReset:
+		err = c.store.Reset(ctx)
+	default:
+		err = fmt.Errorf("unknown rate limit store operation: %s", rq.Op)
+	}
+	if err != nil {
+		rs.Error = err.Error()
+	}
+	return rs
+}
+
+var _ RateLimitStore = (*remoteRateLimitStore)(nil)
+
+// NewRemoteRateLimitStore returns a new RateLimitStore which connects to a RateLimitCoordinator listening on the given network address.
+func NewRemoteRateLimitStore(network string, address string) RateLimitStore {
+	return &remoteRateLimitStore{
+		client: linejson.NewClient[rateLimitStoreRequest, rateLimitStoreResponse](network, address, "rate limit coordinator", maxIdleRemoteConns),
+	}
+}
+
+type remoteRateLimitStore struct {
+	client *linejson.Client[rateLimitStoreRequest, rateLimitStoreResponse]
+}
+
+func (s *remoteRateLimitStore) do(ctx context.Context, rq rateLimitStoreRequest) (rateLimitStoreResponse, error) {
+	rs, err := s.client.Do(ctx, rq)
+	if err != nil {
+		return rateLimitStoreResponse{}, err
+	}
+	if rs.Error != "" {
+		return rs, errors.New(rs.Error)
+	}
+	return rs, nil
+}
+
+func (s *remoteRateLimitStore) TryLock(ctx context.Context, key string, token string, lease time.Duration) (RateLimitBucket, bool, error) {
+	rs, err := s.do(ctx, rateLimitStoreRequest{
+		Op:    rateLimitOpTryLock,
+		Key:   key,
+		Token: token,
+		Lease: lease,
+	})
+	return rs.Bucket, rs.OK, err
+}
+
+func (s *remoteRateLimitStore) Unlock(ctx context.Context, key string, token string, bucket RateLimitBucket) error {
+	_, err := s.do(ctx, rateLimitStoreRequest{
+		Op:     rateLimitOpUnlock,
+		Key:    key,
+		Token:  token,
+		Bucket: bucket,
+	})
+	return err
+}
+
+func (s *remoteRateLimitStore) GlobalReset(ctx context.Context) (time.Time, error) {
+	rs, err := s.do(ctx, rateLimitStoreRequest{
+		Op: rateLimitOpGlobalReset,
+	})
+	return rs.Reset, err
+}
+
+func (s *remoteRateLimitStore) SetGlobalReset(ctx context.Context, reset time.Time) error {
+	_, err := s.do(ctx, rateLimitStoreRequest{
+		Op:    rateLimitOpSetGlobalReset,
+		Reset: reset,
+	})
+	return err
+}
+
+func (s *remoteRateLimitStore) Reset(ctx context.Context) error {
+	_, err := s.do(ctx, rateLimitStoreRequest{
+		Op: rateLimitOpReset,
+	})
+	return err
+}
+
+func (s *remoteRateLimitStore) Close(_ context.Context) error {
+	return s.client.Close()
+}
@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimitStore is used by the shared RateLimiter to keep rate limit state outside the current process.
+// This allows multiple processes using the same token to share the same per-route & global rate limits.
+//
+// Buckets are locked with leases. A lease is identified by a token and automatically expires after the given duration,
+// so a crashed process can't block a bucket forever.
+type RateLimitStore interface {
+	// TryLock tries to acquire a lease on the bucket with the given key.
+	// If the bucket is currently leased by someone else, ok is false.
+	// If the bucket does not exist yet, a new bucket with an unknown limit is returned.
+	TryLock(ctx context.Context, key string, token string, lease time.Duration) (bucket RateLimitBucket, ok bool, err error)
+
+	// Unlock stores the given bucket and releases the lease identified by token.
+	// If the lease has expired or is held by someone else, the bucket is not updated.
+	Unlock(ctx context.Context, key string, token string, bucket RateLimitBucket) error
+
+	// GlobalReset returns the time until all requests are globally rate limited.
+	GlobalReset(ctx context.Context) (time.Time, error)
+
+	// SetGlobalReset sets the time until all requests are globally rate limited.
+	SetGlobalReset(ctx context.Context, reset time.Time) error
+
+	// Reset removes all buckets & the global rate limit from the store.
+	Reset(ctx context.Context) error
+
+	// Close closes the store and releases all of its resources.
+	Close(ctx context.Context) error
+}
+
+var _ RateLimitStore = (*memoryRateLimitStore)(nil)
+
+// NewMemoryRateLimitStore returns a new in-memory RateLimitStore.
+// On its own it only shares rate limits within the current process, but it can be exposed to other processes with a RateLimitCoordinator.
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &memoryRateLimitStore{
+		buckets: map[string]*storedBucket{},
+	}
+}
+
+type storedBucket struct {
+	bucket      RateLimitBucket
+	token       string
+	leaseExpiry time.Time
+}
+
+type memoryRateLimitStore struct {
+	mu          sync.Mutex
+	buckets     map[string]*storedBucket
+	globalReset time.Time
+	lastCleanup time.Time
+}
+
+func (s *memoryRateLimitStore) TryLock(_ context.Context, key string, token string, lease time.Duration) (RateLimitBucket, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.cleanup(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &storedBucket{
+			bucket: newRateLimitBucket(),
+		}
+		s.buckets[key] = b
+	}
+
+	if b.token != "" && b.token != token && b.leaseExpiry.After(now) {
+		return b.bucket, false, nil
+	}
+
+	b.token = token
+	b.leaseExpiry = now.Add(lease)
+	return b.bucket, true, nil
+}
+
+func (s *memoryRateLimitStore) Unlock(_ context.Context, key string, token string, bucket RateLimitBucket) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok || b.token != token {
+		return nil
+	}
+
+	b.bucket = bucket
+	b.token = ""
+	b.leaseExpiry = time.Time{}
+	return nil
+}
+
+func (s *memoryRateLimitStore) GlobalReset(_ context.Context) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.globalReset, nil
+}
+
+func (s *memoryRateLimitStore) SetGlobalReset(_ context.Context, reset time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if reset.After(s.globalReset) {
+		s.globalReset = reset
+	}
+	return nil
+}
+
+func (s *memoryRateLimitStore) Reset(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.globalReset = time.Time{}
+	clear(s.buckets)
+	return nil
+}
+
+func (s *memoryRateLimitStore) Close(_ context.Context) error {
+	return nil
+}
+
+// cleanup removes all buckets which are not leased and have already been reset.
+// It runs at most once per CleanupInterval and must be called with s.mu held.
+func (s *memoryRateLimitStore) cleanup(now time.Time) {
+	if now.Sub(s.lastCleanup) < CleanupInterval {
+		return
+	}
+	s.lastCleanup = now
+	for key, b := range s.buckets {
+		if b.leaseExpiry.Before(now) && b.bucket.Reset.Before(now) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -17,6 +17,10 @@ const (
 	MaxRetries = 10
 	// CleanupInterval is the interval at which the rate limiter cleans up old buckets
 	CleanupInterval = time.Second * 10
+	// LeaseDuration is the duration a bucket lease in a RateLimitStore is valid
+	LeaseDuration = time.Second * 30
+	// PollInterval is the interval at which the shared rate limiter retries locking a leased bucket
+	PollInterval = time.Millisecond * 50
 )
 
 // RateLimiter can be used to supply your own rate limit implementation
@@ -49,13 +53,16 @@ func NewRateLimiter(opts ...RateLimiterConfigOpt) RateLimiter {
 		buckets: map[string]*bucket{},
 	}
 
+	rateLimiter.metrics = newRateLimiterMetrics(cfg.Metrics, rateLimiter.bucketsLen)
+
 	go rateLimiter.cleanup()
 
 	return rateLimiter
 }
 
 type rateLimiterImpl struct {
-	config rateLimiterConfig
+	config  rateLimiterConfig
+	metrics rateLimiterMetrics
 
 	// global Rate Limit
 	global time.Time
@@ -72,6 +79,12 @@ func (l *rateLimiterImpl) MaxRetries() int {
 	return l.config.MaxRetries
 }
 
+func (l *rateLimiterImpl) bucketsLen() int {
+	l.bucketsMu.Lock()
+	defer l.bucketsMu.Unlock()
+	return len(l.buckets)
+}
+
 func (l *rateLimiterImpl) cleanup() {
 	ticker := time.NewTicker(l.config.CleanupInterval)
 	for range ticker.C {
@@ -155,9 +168,7 @@ func (l *rateLimiterImpl) getBucket(endpoint *CompiledEndpoint, create bool) *bu
 		}
 
 		b = &bucket{
-			Remaining: 1,
-			// we don't know the limit yet
-			Limit: -1,
+			RateLimitBucket: newRateLimitBucket(),
 		}
 		l.buckets[hash] = b
 	}
@@ -165,6 +176,8 @@ func (l *rateLimiterImpl) getBucket(endpoint *CompiledEndpoint, create bool) *bu
 }
 
 func (l *rateLimiterImpl) Wait(ctx context.Context, endpoint *CompiledEndpoint) error {
+	defer l.metrics.observeWait(endpoint, time.Now())
+
 	b := l.getBucket(endpoint, true)
 	l.config.Logger.Debug("locking rest bucket", slog.String("id", b.ID), slog.Int("limit", b.Limit), slog.Int("remaining", b.Remaining), slog.Time("reset", b.Reset))
 	if err := b.mu.CLock(ctx); err != nil {
@@ -207,15 +220,25 @@ func (l *rateLimiterImpl) Unlock(endpoint *CompiledEndpoint, rs *http.Response)
 		b.mu.Unlock()
 	}()
 
-	// no response provided means we can't update anything and just unlock it
+	globalReset, err := updateBucket(l.config.Logger, endpoint, rs, &b.RateLimitBucket)
+	if !globalReset.IsZero() {
+		l.global = globalReset
+	}
+	return err
+}
+
+// updateBucket updates the given RateLimitBucket from the rate limit headers of the given http.Response.
+// If the response indicates a global or cloudflare rate limit, the returned time is when it resets.
+func updateBucket(logger *slog.Logger, endpoint *CompiledEndpoint, rs *http.Response, b *RateLimitBucket) (globalReset time.Time, err error) {
+	// no response provided means we can't update anything
 	if rs == nil || rs.Header == nil {
-		return nil
+		return time.Time{}, nil
 	}
 	bucketHeader := rs.Header.Get("X-RateLimit-Bucket")
 
 	// if we don't have a bucket header, we can't update anything
 	if bucketHeader == "" {
-		return nil
+		return time.Time{}, nil
 	}
 
 	b.ID = bucketHeader
@@ -228,33 +251,33 @@ func (l *rateLimiterImpl) Unlock(endpoint *CompiledEndpoint, rs *http.Response)
 	resetAfterHeader := rs.Header.Get("X-RateLimit-Reset-After")
 	retryAfterHeader := rs.Header.Get("Retry-After")
 
-	l.config.Logger.Debug("ratelimit response headers", slog.Int("code", rs.StatusCode), slog.Bool("global", global), slog.Bool("cloudflare", cloudflare), slog.String("remaining", remainingHeader), slog.String("limit", limitHeader), slog.String("reset", resetHeader), slog.String("reset_after", resetAfterHeader), slog.String("retry_after", retryAfterHeader))
+	logger.Debug("ratelimit response headers", slog.Int("code", rs.StatusCode), slog.Bool("global", global), slog.Bool("cloudflare", cloudflare), slog.String("remaining", remainingHeader), slog.String("limit", limitHeader), slog.String("reset", resetHeader), slog.String("reset_after", resetAfterHeader), slog.String("retry_after", retryAfterHeader))
 
 	// we hit a rate limit. let's see if it was global cloudflare or a route specific one
 	if rs.StatusCode == http.StatusTooManyRequests {
 		retryAfter, err := strconv.Atoi(retryAfterHeader)
 		if err != nil {
-			return fmt.Errorf("invalid retryAfter %s: %w", retryAfterHeader, err)
+			return time.Time{}, fmt.Errorf("invalid retryAfter %s: %w", retryAfterHeader, err)
 		}
 		reset := time.Now().Add(time.Second * time.Duration(retryAfter))
 		if global {
-			l.global = reset
-			l.config.Logger.Warn("global rate limit exceeded", slog.Int("retry_after", retryAfter))
+			globalReset = reset
+			logger.Warn("global rate limit exceeded", slog.Int("retry_after", retryAfter))
 		} else if cloudflare {
-			l.global = reset
-			l.config.Logger.Warn("cloudflare rate limit exceeded", slog.Int("retry_after", retryAfter))
+			globalReset = reset
+			logger.Warn("cloudflare rate limit exceeded", slog.Int("retry_after", retryAfter))
 		} else {
 			b.Remaining = 0
 			b.Reset = reset
-			l.config.Logger.Warn("rate limit exceeded", slog.String("endpoint", endpoint.URL), slog.Int("retry_after", retryAfter))
+			logger.Warn("rate limit exceeded", slog.String("endpoint", endpoint.URL), slog.Int("retry_after", retryAfter))
 		}
-		return nil
+		return globalReset, nil
 	}
 
 	if limitHeader != "" {
 		limit, err := strconv.Atoi(limitHeader)
 		if err != nil {
-			return fmt.Errorf("invalid limit %s: %w", limitHeader, err)
+			return time.Time{}, fmt.Errorf("invalid limit %s: %w", limitHeader, err)
 		}
 		b.Limit = limit
 	}
@@ -262,7 +285,7 @@ func (l *rateLimiterImpl) Unlock(endpoint *CompiledEndpoint, rs *http.Response)
 	if remainingHeader != "" {
 		remaining, err := strconv.Atoi(remainingHeader)
 		if err != nil {
-			return fmt.Errorf("invalid remaining %s: %w", remainingHeader, err)
+			return time.Time{}, fmt.Errorf("invalid remaining %s: %w", remainingHeader, err)
 		}
 		b.Remaining = remaining
 	}
@@ -271,28 +294,41 @@ func (l *rateLimiterImpl) Unlock(endpoint *CompiledEndpoint, rs *http.Response)
 	if resetAfterHeader != "" {
 		resetAfter, err := strconv.ParseFloat(resetAfterHeader, 64)
 		if err != nil {
-			return fmt.Errorf("invalid reset after %s: %w", resetAfterHeader, err)
+			return time.Time{}, fmt.Errorf("invalid reset after %s: %w", resetAfterHeader, err)
 		}
 
 		b.Reset = time.Now().Add(time.Duration(resetAfter) * time.Second)
 	} else if resetHeader != "" {
 		reset, err := strconv.ParseFloat(resetHeader, 64)
 		if err != nil {
-			return fmt.Errorf("invalid reset %s: %w", resetHeader, err)
+			return time.Time{}, fmt.Errorf("invalid reset %s: %w", resetHeader, err)
 		}
 
 		sec := int64(reset)
 		b.Reset = time.Unix(sec, int64((reset-float64(sec))*float64(time.Second)))
 	} else {
-		return fmt.Errorf("no reset or reset after header found in response")
+		return time.Time{}, fmt.Errorf("no reset or reset after header found in response")
+	}
+	return time.Time{}, nil
+}
+
+// RateLimitBucket is the state of a single rate limit bucket.
+type RateLimitBucket struct {
+	ID        string    `json:"id"`
+	Reset     time.Time `json:"reset"`
+	Remaining int       `json:"remaining"`
+	Limit     int       `json:"limit"`
+}
+
+func newRateLimitBucket() RateLimitBucket {
+	return RateLimitBucket{
+		Remaining: 1,
+		// we don't know the limit yet
+		Limit: -1,
 	}
-	return nil
 }
 
 type bucket struct {
-	mu        csync.Mutex
-	ID        string
-	Reset     time.Time
-	Remaining int
-	Limit     int
+	mu csync.Mutex
+	RateLimitBucket
 }
@@ -3,6 +3,8 @@ package rest
 import (
 	"log/slog"
 	"time"
+
+	"github.com/disgoorg/disgo/metrics"
 )
 
 func defaultRateLimiterConfig() rateLimiterConfig {
@@ -10,6 +12,9 @@ func defaultRateLimiterConfig() rateLimiterConfig {
 		Logger:          slog.Default(),
 		MaxRetries:      MaxRetries,
 		CleanupInterval: CleanupInterval,
+		LeaseDuration:   LeaseDuration,
+		PollInterval:    PollInterval,
+		Metrics:         metrics.Noop,
 	}
 }
 
@@ -17,6 +22,9 @@ type rateLimiterConfig struct {
 	Logger          *slog.Logger
 	MaxRetries      int
 	CleanupInterval time.Duration
+	LeaseDuration   time.Duration
+	PollInterval    time.Duration
+	Metrics         metrics.Metrics
 }
 
 // RateLimiterConfigOpt can be used to supply optional parameters to NewRateLimiter.
@@ -49,3 +57,25 @@ func WithCleanupInterval(cleanupInterval time.Duration) RateLimiterConfigOpt {
 		config.CleanupInterval = cleanupInterval
 	}
 }
+
+// WithLeaseDuration tells the shared rest rate limiter how long a bucket lease in the RateLimitStore is valid.
+// It should be longer than the timeout of the http.Client used by the rest client.
+func WithLeaseDuration(leaseDuration time.Duration) RateLimiterConfigOpt {
+	return func(config *rateLimiterConfig) {
+		config.LeaseDuration = leaseDuration
+	}
+}
+
+// WithPollInterval tells the shared rest rate limiter how often to retry locking a bucket which is leased by someone else.
+func WithPollInterval(pollInterval time.Duration) RateLimiterConfigOpt {
+	return func(config *rateLimiterConfig) {
+		config.PollInterval = pollInterval
+	}
+}
+
+// WithRateLimiterMetrics sets the metrics.Metrics the rest rate limiter records wait times & bucket counts to.
+func WithRateLimiterMetrics(m metrics.Metrics) RateLimiterConfigOpt {
+	return func(config *rateLimiterConfig) {
+		config.Metrics = m
+	}
+}
@@ -0,0 +1,241 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sasha-s/go-csync"
+
+	"github.com/disgoorg/disgo/internal/insecurerandstr"
+)
+
+var _ RateLimiter = (*sharedRateLimiterImpl)(nil)
+
+// NewSharedRateLimiter returns a new RateLimiter which keeps its bucket state in the given RateLimitStore.
+// Multiple processes using the same token & a RateLimitStore backed by the same storage share their rate limits.
+func NewSharedRateLimiter(store RateLimitStore, opts ...RateLimiterConfigOpt) RateLimiter {
+	cfg := defaultRateLimiterConfig()
+	cfg.apply(opts)
+
+	rateLimiter := &sharedRateLimiterImpl{
+		config:  cfg,
+		store:   store,
+		buckets: map[string]*sharedBucket{},
+	}
+
+	rateLimiter.metrics = newRateLimiterMetrics(cfg.Metrics, rateLimiter.bucketsLen)
+
+	go rateLimiter.cleanup()
+
+	return rateLimiter
+}
+
+type sharedRateLimiterImpl struct {
+	config  rateLimiterConfig
+	metrics rateLimiterMetrics
+	store   RateLimitStore
+
+	// Hash + Major Parameter -> local bucket
+	buckets   map[string]*sharedBucket
+	bucketsMu sync.Mutex
+}
+
+func (l *sharedRateLimiterImpl) MaxRetries() int {
+	return l.config.MaxRetries
+}
+
+func (l *sharedRateLimiterImpl) bucketsLen() int {
+	l.bucketsMu.Lock()
+	defer l.bucketsMu.Unlock()
+	return len(l.buckets)
+}
+
+func (l *sharedRateLimiterImpl) cleanup() {
+	ticker := time.NewTicker(l.config.CleanupInterval)
+	for range ticker.C {
+		l.doCleanup()
+	}
+}
+
+func (l *sharedRateLimiterImpl) doCleanup() {
+	l.bucketsMu.Lock()
+	defer l.bucketsMu.Unlock()
+	for key, b := range l.buckets {
+		if !b.mu.TryLock() {
+			continue
+		}
+		delete(l.buckets, key)
+		b.mu.Unlock()
+	}
+}
+
+func (l *sharedRateLimiterImpl) Close(ctx context.Context) {
+	var wg sync.WaitGroup
+	l.bucketsMu.Lock()
+	for i := range l.buckets {
+		wg.Add(1)
+		b := l.buckets[i]
+		go func() {
+			_ = b.mu.CLock(ctx)
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+
+	if err := l.store.Close(ctx); err != nil {
+		l.config.Logger.Error("failed to close rate limit store", slog.Any("err", err))
+	}
+}
+
+func (l *sharedRateLimiterImpl) Reset() {
+	l.bucketsMu.Lock()
+	defer l.bucketsMu.Unlock()
+
+	clear(l.buckets)
+	if err := l.store.Reset(context.Background()); err != nil {
+		l.config.Logger.Error("failed to reset rate limit store", slog.Any("err", err))
+	}
+}
+
+func (l *sharedRateLimiterImpl) getBucket(key string, create bool) *sharedBucket {
+	l.bucketsMu.Lock()
+	defer l.bucketsMu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		if !create {
+			return nil
+		}
+
+		b = &sharedBucket{
+			token: insecurerandstr.RandStr(32),
+		}
+		l.buckets[key] = b
+	}
+	return b
+}
+
+func (l *sharedRateLimiterImpl) Wait(ctx context.Context, endpoint *CompiledEndpoint) error {
+	defer l.metrics.observeWait(endpoint, time.Now())
+
+	key := rateLimitKey(endpoint)
+	b := l.getBucket(key, true)
+	l.config.Logger.Debug("locking rest bucket", slog.String("key", key))
+	if err := b.mu.CLock(ctx); err != nil {
+		return err
+	}
+
+	if err := l.acquire(ctx, key, b); err != nil {
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// acquire waits for the global rate limit & leases the bucket in the RateLimitStore once it has requests remaining.
+func (l *sharedRateLimiterImpl) acquire(ctx context.Context, key string, b *sharedBucket) error {
+	for {
+		globalReset, err := l.store.GlobalReset(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get global rate limit: %w", err)
+		}
+		if err = sleepUntil(ctx, globalReset); err != nil {
+			return err
+		}
+
+		state, ok, err := l.store.TryLock(ctx, key, b.token, l.config.LeaseDuration)
+		if err != nil {
+			return fmt.Errorf("failed to lock rate limit bucket: %w", err)
+		}
+		if !ok {
+			// someone else is using the bucket right now
+			if err = sleepUntil(ctx, time.Now().Add(l.config.PollInterval)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if state.Remaining == 0 && state.Reset.After(time.Now()) {
+			// release the lease while waiting, so we don't block the bucket longer than needed
+			if err = l.store.Unlock(ctx, key, b.token, state); err != nil {
+				return fmt.Errorf("failed to unlock rate limit bucket: %w", err)
+			}
+			if err = sleepUntil(ctx, state.Reset); err != nil {
+				return err
+			}
+			continue
+		}
+
+		b.state = state
+		l.config.Logger.Debug("locked rest bucket", slog.String("key", key), slog.String("id", state.ID), slog.Int("limit", state.Limit), slog.Int("remaining", state.Remaining), slog.Time("reset", state.Reset))
+		return nil
+	}
+}
+
+func (l *sharedRateLimiterImpl) Unlock(endpoint *CompiledEndpoint, rs *http.Response) error {
+	key := rateLimitKey(endpoint)
+	b := l.getBucket(key, false)
+	if b == nil {
+		return nil
+	}
+	defer func() {
+		l.config.Logger.Debug("unlocking rest bucket", slog.String("key", key), slog.String("id", b.state.ID), slog.Int("limit", b.state.Limit), slog.Int("remaining", b.state.Remaining), slog.Time("reset", b.state.Reset))
+		b.mu.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.config.LeaseDuration)
+	defer cancel()
+
+	globalReset, err := updateBucket(l.config.Logger, endpoint, rs, &b.state)
+	if !globalReset.IsZero() {
+		if setErr := l.store.SetGlobalReset(ctx, globalReset); setErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to set global rate limit: %w", setErr))
+		}
+	}
+
+	if unlockErr := l.store.Unlock(ctx, key, b.token, b.state); unlockErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to unlock rate limit bucket: %w", unlockErr))
+	}
+	return err
+}
+
+// rateLimitKey returns the key of the bucket the given CompiledEndpoint belongs to.
+func rateLimitKey(endpoint *CompiledEndpoint) string {
+	key := endpoint.Endpoint.Method + "+" + endpoint.Endpoint.Route
+	if endpoint.MajorParams != "" {
+		key += "+" + endpoint.MajorParams
+	}
+	return key
+}
+
+// sleepUntil blocks until the given time has passed or the context is done.
+// If the context deadline is before the given time, it returns immediately.
+func sleepUntil(ctx context.Context, until time.Time) error {
+	now := time.Now()
+	if !until.After(now) {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && until.After(deadline) {
+		return context.DeadlineExceeded
+	}
+
+	timer := time.NewTimer(until.Sub(now))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type sharedBucket struct {
+	mu    csync.Mutex
+	token string
+	state RateLimitBucket
+}
@@ -0,0 +1,87 @@
+package rest
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSharedRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	coordinator := NewRateLimitCoordinator(NewMemoryRateLimitStore(), nil)
+	go func() {
+		_ = coordinator.Serve(ln)
+	}()
+	defer coordinator.Close(context.Background())
+
+	r1 := NewSharedRateLimiter(NewRemoteRateLimitStore("tcp", ln.Addr().String()))
+	r2 := NewSharedRateLimiter(NewRemoteRateLimitStore("tcp", ln.Addr().String()))
+	defer r1.Close(context.Background())
+	defer r2.Close(context.Background())
+
+	endpoint := GetMessage.Compile(nil, 1, 2)
+
+	if err = r1.Wait(context.Background(), endpoint); err != nil {
+		t.Fatal(err)
+	}
+	rs := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"X-Ratelimit-Bucket":      []string{"abc"},
+			"X-Ratelimit-Limit":       []string{"1"},
+			"X-Ratelimit-Remaining":   []string{"0"},
+			"X-Ratelimit-Reset-After": []string{"1"},
+		},
+	}
+	start := time.Now()
+	if err = r1.Unlock(endpoint, rs); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = r2.Wait(context.Background(), endpoint); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("expected second rate limiter to wait for the shared bucket reset, waited %s", elapsed)
+	}
+	if err = r2.Unlock(endpoint, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSharedRateLimiter_Global(t *testing.T) {
+	t.Parallel()
+
+	store := NewMemoryRateLimitStore()
+	r1 := NewSharedRateLimiter(store)
+	r2 := NewSharedRateLimiter(store)
+
+	endpoint := GetMessage.Compile(nil, 1, 2)
+	if err := r1.Wait(context.Background(), endpoint); err != nil {
+		t.Fatal(err)
+	}
+	rs := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header: http.Header{
+			"X-Ratelimit-Bucket": []string{"abc"},
+			"X-Ratelimit-Global": []string{"true"},
+			"Retry-After":        []string{"5"},
+		},
+	}
+	if err := r1.Unlock(endpoint, rs); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := r2.Wait(ctx, GetGateway.Compile(nil)); err != context.DeadlineExceeded {
+		t.Errorf("expected global rate limit to be shared, got %v", err)
+	}
+}
@@ -1,6 +1,9 @@
 package rest
 
 import (
+	"context"
+	"iter"
+
 	"github.com/disgoorg/snowflake/v2"
 
 	"github.com/disgoorg/disgo/discord"
@@ -17,6 +20,7 @@ type SKUs interface {
 
 	GetSKUSubscriptions(skuID snowflake.ID, before snowflake.ID, after snowflake.ID, limit int, userID snowflake.ID, opts ...RequestOpt) ([]discord.Subscription, error)
 	GetSKUSubscriptionsPage(skuID snowflake.ID, userID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) Page[discord.Subscription]
+	GetSKUSubscriptionsIter(ctx context.Context, skuID snowflake.ID, userID snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.Subscription, error]
 	GetSKUSubscription(skuID snowflake.ID, subscriptionID snowflake.ID, opts ...RequestOpt) (*discord.Subscription, error)
 }
 
@@ -59,6 +63,18 @@ func (s *skusImpl) GetSKUSubscriptionsPage(skuID snowflake.ID, userID snowflake.
 	}
 }
 
+func (s *skusImpl) GetSKUSubscriptionsIter(ctx context.Context, skuID snowflake.ID, userID snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.Subscription, error] {
+	opts = withIterCtx(ctx, opts)
+	return pageIter(ctx, 0, true, limit, 100,
+		func(after snowflake.ID, limit int) ([]discord.Subscription, error) {
+			return s.GetSKUSubscriptions(skuID, 0, after, limit, userID, opts...)
+		},
+		func(subscription discord.Subscription) snowflake.ID {
+			return subscription.ID
+		},
+	)
+}
+
 func (s *skusImpl) GetSKUSubscription(skuID snowflake.ID, subscriptionID snowflake.ID, opts ...RequestOpt) (subscription *discord.Subscription, err error) {
 	err = s.client.Do(GetSKUSubscription.Compile(nil, skuID, subscriptionID), nil, &subscription, opts...)
 	return
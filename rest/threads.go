@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"context"
+	"iter"
 	"time"
 
 	"github.com/disgoorg/snowflake/v2"
@@ -26,10 +28,14 @@ type Threads interface {
 	GetThreadMember(threadID snowflake.ID, userID snowflake.ID, withMember bool, opts ...RequestOpt) (threadMember *discord.ThreadMember, err error)
 	GetThreadMembers(threadID snowflake.ID, opts ...RequestOpt) (threadMembers []discord.ThreadMember, err error)
 	GetThreadMembersPage(threadID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) ThreadMemberPage
+	GetThreadMembersIter(ctx context.Context, threadID snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.ThreadMember, error]
 
 	GetPublicArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
 	GetPrivateArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
 	GetJoinedPrivateArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
+	GetPublicArchivedThreadsIter(ctx context.Context, channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error]
+	GetPrivateArchivedThreadsIter(ctx context.Context, channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error]
+	GetJoinedPrivateArchivedThreadsIter(ctx context.Context, channelID snowflake.ID, before snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error]
 	GetActiveGuildThreads(guildID snowflake.ID, opts ...RequestOpt) (*discord.GuildActiveThreads, error)
 }
 
@@ -98,6 +104,22 @@ func (s *threadImpl) GetThreadMembersPage(threadID snowflake.ID, startID snowfla
 	}
 }
 
+func (s *threadImpl) GetThreadMembersIter(ctx context.Context, threadID snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.ThreadMember, error] {
+	opts = withIterCtx(ctx, opts)
+	return pageIter(ctx, 0, true, limit, 100,
+		func(after snowflake.ID, limit int) ([]discord.ThreadMember, error) {
+			return s.getThreadMembers(threadID, discord.QueryValues{
+				"with_member": true,
+				"after":       after,
+				"limit":       limit,
+			}, opts...)
+		},
+		func(threadMember discord.ThreadMember) snowflake.ID {
+			return threadMember.UserID
+		},
+	)
+}
+
 func (s *threadImpl) GetPublicArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error) {
 	queryValues := discord.QueryValues{}
 	if !before.IsZero() {
@@ -134,6 +156,58 @@ func (s *threadImpl) GetJoinedPrivateArchivedThreads(channelID snowflake.ID, bef
 	return
 }
 
+func (s *threadImpl) GetPublicArchivedThreadsIter(ctx context.Context, channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error] {
+	opts = withIterCtx(ctx, opts)
+	return threadsIter(ctx, before, limit, func(before time.Time, limit int) (*discord.GetThreads, error) {
+		return s.getArchivedThreads(GetPublicArchivedThreads, channelID, formatThreadsBefore(before), limit, opts...)
+	}, threadArchiveTimestamp, time.Time.Before)
+}
+
+func (s *threadImpl) GetPrivateArchivedThreadsIter(ctx context.Context, channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error] {
+	opts = withIterCtx(ctx, opts)
+	return threadsIter(ctx, before, limit, func(before time.Time, limit int) (*discord.GetThreads, error) {
+		return s.getArchivedThreads(GetPrivateArchivedThreads, channelID, formatThreadsBefore(before), limit, opts...)
+	}, threadArchiveTimestamp, time.Time.Before)
+}
+
+func (s *threadImpl) GetJoinedPrivateArchivedThreadsIter(ctx context.Context, channelID snowflake.ID, before snowflake.ID, limit int, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error] {
+	opts = withIterCtx(ctx, opts)
+	return threadsIter(ctx, before, limit, func(before snowflake.ID, limit int) (*discord.GetThreads, error) {
+		var cursor string
+		if before != 0 {
+			cursor = before.String()
+		}
+		return s.getArchivedThreads(GetJoinedPrivateArchivedThreads, channelID, cursor, limit, opts...)
+	}, discord.GuildThread.ID, func(a snowflake.ID, b snowflake.ID) bool {
+		return a < b
+	})
+}
+
+// getArchivedThreads requests archived threads with the before cursor as is, unlike the exported methods which format it as a timestamp with second precision.
+func (s *threadImpl) getArchivedThreads(endpoint *Endpoint, channelID snowflake.ID, before string, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error) {
+	queryValues := discord.QueryValues{}
+	if before != "" {
+		queryValues["before"] = before
+	}
+	if limit != 0 {
+		queryValues["limit"] = limit
+	}
+	err = s.client.Do(endpoint.Compile(queryValues, channelID), nil, &threads, opts...)
+	return
+}
+
+// formatThreadsBefore formats the archive timestamp cursor with sub second precision, so threads archived in the same second are not skipped.
+func formatThreadsBefore(before time.Time) string {
+	if before.IsZero() {
+		return ""
+	}
+	return before.Format(time.RFC3339Nano)
+}
+
+func threadArchiveTimestamp(thread discord.GuildThread) time.Time {
+	return thread.ThreadMetadata.ArchiveTimestamp
+}
+
 func (s *threadImpl) GetActiveGuildThreads(guildID snowflake.ID, opts ...RequestOpt) (activeThreads *discord.GuildActiveThreads, err error) {
 	err = s.client.Do(GetActiveGuildThreads.Compile(nil, guildID), nil, &activeThreads, opts...)
 	return
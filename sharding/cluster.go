@@ -0,0 +1,311 @@
+package sharding
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// ErrInvalidShardCount is returned when a shard count lower than 1 is set.
+var ErrInvalidShardCount = errors.New("shard count must be at least 1")
+
+// ErrInvalidMaxConcurrency is returned when a max concurrency lower than 1 is set.
+var ErrInvalidMaxConcurrency = errors.New("max concurrency must be at least 1")
+
+// Assignment are the shards a member of a cluster should run.
+type Assignment struct {
+	// Version increases every time the shards of the cluster are reassigned.
+	Version int `json:"version"`
+	// ShardCount is the total shard count of the cluster.
+	ShardCount int `json:"shard_count"`
+	// ShardIDs are the shard IDs the member should run.
+	ShardIDs []int `json:"shard_ids"`
+}
+
+// Coordinator distributes the shards of a bot across multiple processes (members).
+// Members call Join periodically to receive their Assignment. Members which stop calling Join are removed after a timeout & their shards are reassigned to the remaining members.
+//
+// Shards are only moved away from a member if it runs more than its share or left the cluster.
+// A moved shard is only assigned to its new member after the previous member stopped running it, so no shard runs twice.
+//
+// Use NewCoordinator for a Coordinator within a single process, and NewCoordinatorServer & NewRemoteCoordinator to share it across processes.
+type Coordinator interface {
+	// Join registers the member with the cluster or refreshes its membership & returns its current Assignment.
+	// shardIDs are the shards of the current shard count the member runs. Shards which were assigned to the member before & are missing are released, so they can be assigned to another member.
+	Join(ctx context.Context, memberID string, shardIDs []int) (Assignment, error)
+
+	// Leave removes the member from the cluster. Its shards are released & reassigned to the remaining members.
+	Leave(ctx context.Context, memberID string) error
+
+	// SetShardCount sets the total shard count of the cluster & reassigns all shards.
+	SetShardCount(ctx context.Context, shardCount int) error
+
+	// SetMaxConcurrency sets the number of shards which can identify at the same time across the cluster.
+	SetMaxConcurrency(ctx context.Context, maxConcurrency int) error
+
+	// ReserveIdentify reserves an identify for the given shard & returns the time at which the shard may identify.
+	// This respects the max concurrency buckets across the whole cluster.
+	ReserveIdentify(ctx context.Context, shardID int) (time.Time, error)
+}
+
+var _ Coordinator = (*coordinatorImpl)(nil)
+
+// NewCoordinator returns a new in-memory Coordinator for the given shard count with the given CoordinatorConfigOpt(s).
+func NewCoordinator(shardCount int, opts ...CoordinatorConfigOpt) Coordinator {
+	cfg := defaultCoordinatorConfig()
+	cfg.apply(opts)
+
+	return &coordinatorImpl{
+		config:     cfg,
+		shardCount: max(shardCount, 1),
+		members:    map[string]time.Time{},
+		targets:    map[int]string{},
+		owners:     map[int]string{},
+		identifies: map[int]time.Time{},
+	}
+}
+
+type coordinatorImpl struct {
+	config coordinatorConfig
+
+	mu         sync.Mutex
+	shardCount int
+	version    int
+	members    map[string]time.Time
+	// targets are the members the shards should run on
+	targets map[int]string
+	// owners are the members the shards were handed out to & which did not release them yet
+	owners     map[int]string
+	identifies map[int]time.Time
+}
+
+func (c *coordinatorImpl) Join(_ context.Context, memberID string, shardIDs []int) (Assignment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	c.expireMembers(now)
+
+	_, ok := c.members[memberID]
+	c.members[memberID] = now
+	if !ok {
+		c.config.Logger.Debug("member joined", slog.String("member_id", memberID))
+		c.rebalance()
+	}
+
+	// release the shards the member stopped running after they were moved to another member
+	var released bool
+	for shardID, owner := range c.owners {
+		if owner == memberID && c.targets[shardID] != memberID && !slices.Contains(shardIDs, shardID) {
+			delete(c.owners, shardID)
+			released = true
+		}
+	}
+	if released {
+		c.version++
+	}
+
+	var assigned []int
+	for shardID := range c.shardCount {
+		if c.targets[shardID] != memberID {
+			continue
+		}
+		if owner, ok := c.owners[shardID]; ok && owner != memberID {
+			// wait until the previous member released the shard
+			continue
+		}
+		c.owners[shardID] = memberID
+		assigned = append(assigned, shardID)
+	}
+
+	return Assignment{
+		Version:    c.version,
+		ShardCount: c.shardCount,
+		ShardIDs:   assigned,
+	}, nil
+}
+
+func (c *coordinatorImpl) Leave(_ context.Context, memberID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.members[memberID]; !ok {
+		return nil
+	}
+	c.removeMember(memberID)
+	c.config.Logger.Debug("member left", slog.String("member_id", memberID))
+	c.rebalance()
+	return nil
+}
+
+func (c *coordinatorImpl) SetShardCount(_ context.Context, shardCount int) error {
+	if shardCount < 1 {
+		return ErrInvalidShardCount
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.shardCount == shardCount {
+		return nil
+	}
+	c.config.Logger.Debug("shard count changed", slog.Int("old_shard_count", c.shardCount), slog.Int("shard_count", shardCount))
+	c.shardCount = shardCount
+	// the shards of the new shard count are different shards, members close the shards of the old shard count on their own
+	clear(c.targets)
+	clear(c.owners)
+	c.rebalance()
+	return nil
+}
+
+func (c *coordinatorImpl) SetMaxConcurrency(_ context.Context, maxConcurrency int) error {
+	if maxConcurrency < 1 {
+		return ErrInvalidMaxConcurrency
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.config.MaxConcurrency == maxConcurrency {
+		return nil
+	}
+	c.config.Logger.Debug("max concurrency changed", slog.Int("old_max_concurrency", c.config.MaxConcurrency), slog.Int("max_concurrency", maxConcurrency))
+	c.config.MaxConcurrency = maxConcurrency
+	return nil
+}
+
+func (c *coordinatorImpl) ReserveIdentify(_ context.Context, shardID int) (time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := gateway.MaxConcurrencyKey(shardID, c.config.MaxConcurrency)
+	at := time.Now()
+	if next := c.identifies[key]; next.After(at) {
+		at = next
+	}
+	c.identifies[key] = at.Add(c.config.IdentifyWait)
+	return at, nil
+}
+
+// expireMembers removes all members which did not join within the MemberTimeout. It must be called with c.mu held.
+func (c *coordinatorImpl) expireMembers(now time.Time) {
+	var expired bool
+	for memberID, lastSeen := range c.members {
+		if now.Sub(lastSeen) > c.config.MemberTimeout {
+			c.config.Logger.Warn("member timed out", slog.String("member_id", memberID), slog.Time("last_seen", lastSeen))
+			c.removeMember(memberID)
+			expired = true
+		}
+	}
+	if expired {
+		c.rebalance()
+	}
+}
+
+// removeMember removes the member & releases its shards, as it doesn't run them anymore. It must be called with c.mu held.
+func (c *coordinatorImpl) removeMember(memberID string) {
+	delete(c.members, memberID)
+	for shardID, owner := range c.owners {
+		if owner == memberID {
+			delete(c.owners, shardID)
+		}
+	}
+}
+
+// rebalance distributes all shards evenly across all members while moving as few shards as possible.
+// Members keep their shards up to their share, only shards of removed members & shards above the share of a member are moved. It must be called with c.mu held.
+func (c *coordinatorImpl) rebalance() {
+	c.version++
+
+	counts := make(map[string]int, len(c.members))
+	for shardID, memberID := range c.targets {
+		if _, ok := c.members[memberID]; ok && shardID < c.shardCount {
+			counts[memberID]++
+		}
+	}
+
+	// members which already run the most shards get the remaining shards which can't be split evenly, so they don't have to give them up
+	memberIDs := make([]string, 0, len(c.members))
+	for memberID := range c.members {
+		memberIDs = append(memberIDs, memberID)
+	}
+	slices.SortFunc(memberIDs, func(a string, b string) int {
+		if n := cmp.Compare(counts[b], counts[a]); n != 0 {
+			return n
+		}
+		return strings.Compare(a, b)
+	})
+
+	quotas := make(map[string]int, len(memberIDs))
+	for i, memberID := range memberIDs {
+		quotas[memberID] = c.shardCount / len(memberIDs)
+		if i < c.shardCount%len(memberIDs) {
+			quotas[memberID]++
+		}
+	}
+
+	targets := make(map[int]string, c.shardCount)
+	kept := make(map[string]int, len(memberIDs))
+	var orphans []int
+	for shardID := range c.shardCount {
+		memberID, ok := c.targets[shardID]
+		if ok && kept[memberID] < quotas[memberID] {
+			targets[shardID] = memberID
+			kept[memberID]++
+			continue
+		}
+		orphans = append(orphans, shardID)
+	}
+
+	slices.Sort(memberIDs)
+	for _, memberID := range memberIDs {
+		for ; kept[memberID] < quotas[memberID]; kept[memberID]++ {
+			targets[orphans[0]] = memberID
+			orphans = orphans[1:]
+		}
+	}
+	c.targets = targets
+
+	c.config.Logger.Debug("rebalanced shards", slog.Int("version", c.version), slog.Int("members", len(memberIDs)), slog.Int("shard_count", c.shardCount))
+}
+
+var _ gateway.IdentifyRateLimiter = (*clusterIdentifyRateLimiter)(nil)
+
+// NewClusterIdentifyRateLimiter returns a gateway.IdentifyRateLimiter which reserves identifies through the given Coordinator,
+// so max concurrency buckets are respected across the whole cluster.
+func NewClusterIdentifyRateLimiter(coordinator Coordinator) gateway.IdentifyRateLimiter {
+	return &clusterIdentifyRateLimiter{
+		coordinator: coordinator,
+	}
+}
+
+type clusterIdentifyRateLimiter struct {
+	coordinator Coordinator
+}
+
+func (r *clusterIdentifyRateLimiter) Close(_ context.Context) {}
+
+func (r *clusterIdentifyRateLimiter) Wait(ctx context.Context, shardID int) error {
+	at, err := r.coordinator.ReserveIdentify(ctx, shardID)
+	if err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (r *clusterIdentifyRateLimiter) Unlock(_ int) {}
@@ -0,0 +1,70 @@
+package sharding
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+func defaultCoordinatorConfig() coordinatorConfig {
+	return coordinatorConfig{
+		Logger:         slog.Default(),
+		MemberTimeout:  30 * time.Second,
+		MaxConcurrency: gateway.DefaultMaxConcurrency,
+		IdentifyWait:   5 * time.Second,
+	}
+}
+
+type coordinatorConfig struct {
+	// Logger is the logger of the Coordinator. Defaults to slog.Default().
+	Logger *slog.Logger
+	// MemberTimeout is the duration after which a member which did not call Coordinator.Join is removed from the cluster. Defaults to 30 seconds.
+	MemberTimeout time.Duration
+	// MaxConcurrency is the number of shards which can identify at the same time across the cluster. Defaults to gateway.DefaultMaxConcurrency.
+	MaxConcurrency int
+	// IdentifyWait is the duration between two identifies of the same max concurrency bucket. Defaults to 5 seconds.
+	IdentifyWait time.Duration
+}
+
+// CoordinatorConfigOpt is a type alias for a function that takes a coordinatorConfig and is used to configure your Coordinator.
+type CoordinatorConfigOpt func(config *coordinatorConfig)
+
+func (c *coordinatorConfig) apply(opts []CoordinatorConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Logger = c.Logger.With(slog.String("name", "sharding_coordinator"))
+	if c.MaxConcurrency < 1 {
+		c.MaxConcurrency = gateway.DefaultMaxConcurrency
+	}
+}
+
+// WithCoordinatorLogger sets the logger of the Coordinator.
+func WithCoordinatorLogger(logger *slog.Logger) CoordinatorConfigOpt {
+	return func(config *coordinatorConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithMemberTimeout sets the duration after which a member which did not call Coordinator.Join is removed from the cluster.
+func WithMemberTimeout(memberTimeout time.Duration) CoordinatorConfigOpt {
+	return func(config *coordinatorConfig) {
+		config.MemberTimeout = memberTimeout
+	}
+}
+
+// WithMaxConcurrency sets the number of shards which can identify at the same time across the cluster.
+// This should be set to the max_concurrency returned by Discord. ShardManagers configured with WithClusterMaxConcurrency update it when they join the cluster.
+func WithMaxConcurrency(maxConcurrency int) CoordinatorConfigOpt {
+	return func(config *coordinatorConfig) {
+		config.MaxConcurrency = maxConcurrency
+	}
+}
+
+// WithIdentifyWait sets the duration between two identifies of the same max concurrency bucket.
+func WithIdentifyWait(identifyWait time.Duration) CoordinatorConfigOpt {
+	return func(config *coordinatorConfig) {
+		config.IdentifyWait = identifyWait
+	}
+}
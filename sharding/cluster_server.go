@@ -0,0 +1,165 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/disgoorg/disgo/internal/linejson"
+)
+
+const (
+	coordinatorOpJoin              = "join"
+	coordinatorOpLeave             = "leave"
+	coordinatorOpSetShardCount     = "set_shard_count"
+	coordinatorOpSetMaxConcurrency = "set_max_concurrency"
+	coordinatorOpReserveIdentify   = "reserve_identify"
+)
+
+// coordinatorRequest is a single request sent from a remote Coordinator to a CoordinatorServer.
+type coordinatorRequest struct {
+	Op             string `json:"op"`
+	MemberID       string `json:"member_id,omitempty"`
+	ShardID        int    `json:"shard_id,omitempty"`
+	ShardIDs       []int  `json:"shard_ids,omitempty"`
+	ShardCount     int    `json:"shard_count,omitempty"`
+	MaxConcurrency int    `json:"max_concurrency,omitempty"`
+}
+
+// coordinatorResponse is the response of a CoordinatorServer to a coordinatorRequest.
+type coordinatorResponse struct {
+	Assignment Assignment `json:"assignment"`
+	At         time.Time  `json:"at"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// NewCoordinatorServer returns a new CoordinatorServer which exposes the given Coordinator to other processes.
+func NewCoordinatorServer(coordinator Coordinator, logger *slog.Logger) *CoordinatorServer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	s := &CoordinatorServer{
+		coordinator: coordinator,
+	}
+	s.server = linejson.NewServer(s.handleRequest, logger.With(slog.String("name", "sharding_coordinator_server")))
+	return s
+}
+
+// CoordinatorServer serves a Coordinator over TCP or Unix sockets.
+// Other processes can connect to it with NewRemoteCoordinator.
+//
+// Like rest.RateLimitCoordinator, it speaks newline-delimited JSON and must only be exposed to trusted networks.
+type CoordinatorServer struct {
+	coordinator Coordinator
+	server      *linejson.Server[coordinatorRequest, coordinatorResponse]
+}
+
+// ListenAndServe listens on the given network address & serves the Coordinator until the CoordinatorServer is closed.
+func (s *CoordinatorServer) ListenAndServe(network string, address string) error {
+	return s.server.ListenAndServe(network, address)
+}
+
+// Serve accepts connections on the given net.Listener & serves the Coordinator until the CoordinatorServer is closed.
+func (s *CoordinatorServer) Serve(ln net.Listener) error {
+	return s.server.Serve(ln)
+}
+
+// Close stops all listeners & closes all open connections.
+func (s *CoordinatorServer) Close(_ context.Context) error {
+	return s.server.Close()
+}
+
+func (s *CoordinatorServer) handleRequest(rq coordinatorRequest) coordinatorResponse {
+	ctx := context.Background()
+
+	var (
+		rs  coordinatorResponse
+		err error
+	)
+	switch rq.Op {
+	case coordinatorOpJoin:
+		rs.Assignment, err = s.coordinator.Join(ctx, rq.MemberID, rq.ShardIDs)
+	case coordinatorOpLeave:
+		err = s.coordinator.Leave(ctx, rq.MemberID)
+	case coordinatorOpSetShardCount:
+		err = s.coordinator.SetShardCount(ctx, rq.ShardCount)
+	case coordinatorOpSetMaxConcurrency:
+		err = s.coordinator.SetMaxConcurrency(ctx, rq.MaxConcurrency)
+	case coordinatorOpReserveIdentify:
+		rs.At, err = s.coordinator.ReserveIdentify(ctx, rq.ShardID)
+	default:
+		err = fmt.Errorf("unknown coordinator operation: %s", rq.Op)
+	}
+	if err != nil {
+		rs.Error = err.Error()
+	}
+	return rs
+}
+
+var _ Coordinator = (*remoteCoordinator)(nil)
+
+// NewRemoteCoordinator returns a new Coordinator which connects to a CoordinatorServer listening on the given network address.
+func NewRemoteCoordinator(network string, address string) Coordinator {
+	return &remoteCoordinator{
+		client: linejson.NewClient[coordinatorRequest, coordinatorResponse](network, address, "coordinator", 1),
+	}
+}
+
+type remoteCoordinator struct {
+	client *linejson.Client[coordinatorRequest, coordinatorResponse]
+}
+
+func (c *remoteCoordinator) do(ctx context.Context, rq coordinatorRequest) (coordinatorResponse, error) {
+	rs, err := c.client.Do(ctx, rq)
+	if err != nil {
+		return coordinatorResponse{}, err
+	}
+	if rs.Error != "" {
+		return rs, errors.New(rs.Error)
+	}
+	return rs, nil
+}
+
+func (c *remoteCoordinator) Join(ctx context.Context, memberID string, shardIDs []int) (Assignment, error) {
+	rs, err := c.do(ctx, coordinatorRequest{
+		Op:       coordinatorOpJoin,
+		MemberID: memberID,
+		ShardIDs: shardIDs,
+	})
+	return rs.Assignment, err
+}
+
+func (c *remoteCoordinator) Leave(ctx context.Context, memberID string) error {
+	_, err := c.do(ctx, coordinatorRequest{
+		Op:       coordinatorOpLeave,
+		MemberID: memberID,
+	})
+	return err
+}
+
+func (c *remoteCoordinator) SetShardCount(ctx context.Context, shardCount int) error {
+	_, err := c.do(ctx, coordinatorRequest{
+		Op:         coordinatorOpSetShardCount,
+		ShardCount: shardCount,
+	})
+	return err
+}
+
+func (c *remoteCoordinator) SetMaxConcurrency(ctx context.Context, maxConcurrency int) error {
+	_, err := c.do(ctx, coordinatorRequest{
+		Op:             coordinatorOpSetMaxConcurrency,
+		MaxConcurrency: maxConcurrency,
+	})
+	return err
+}
+
+func (c *remoteCoordinator) ReserveIdentify(ctx context.Context, shardID int) (time.Time, error) {
+	rs, err := c.do(ctx, coordinatorRequest{
+		Op:      coordinatorOpReserveIdentify,
+		ShardID: shardID,
+	})
+	return rs.At, err
+}
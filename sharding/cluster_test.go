@@ -0,0 +1,221 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+func TestCoordinator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := NewCoordinator(6, WithMemberTimeout(100*time.Millisecond), WithMaxConcurrency(2), WithIdentifyWait(time.Second))
+	join := func(memberID string, shardIDs []int) []int {
+		t.Helper()
+		assignment, err := c.Join(ctx, memberID, shardIDs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return assignment.ShardIDs
+	}
+
+	a := join("a", nil)
+	if fmt.Sprint(a) != "[0 1 2 3 4 5]" {
+		t.Errorf("expected a to run all shards, got %v", a)
+	}
+
+	// b only gets its shards after a released them
+	if b := join("b", nil); len(b) != 0 {
+		t.Errorf("expected b to wait for a to release its shards, got %v", b)
+	}
+	if a = join("a", a); fmt.Sprint(a) != "[0 1 2]" {
+		t.Errorf("expected a to keep shards [0 1 2], got %v", a)
+	}
+	if b := join("b", nil); len(b) != 0 {
+		t.Errorf("expected b to wait for a to release its shards, got %v", b)
+	}
+	join("a", a)
+	b := join("b", nil)
+	if fmt.Sprint(b) != "[3 4 5]" {
+		t.Errorf("expected b to run shards [3 4 5] after a released them, got %v", b)
+	}
+
+	// only the shards above the share of a & b move to c
+	join("c", nil)
+	if a = join("a", a); fmt.Sprint(a) != "[0 1]" {
+		t.Errorf("expected a to keep shards [0 1], got %v", a)
+	}
+	if b = join("b", b); fmt.Sprint(b) != "[3 4]" {
+		t.Errorf("expected b to keep shards [3 4], got %v", b)
+	}
+	join("a", a)
+	join("b", b)
+	if cShards := join("c", nil); fmt.Sprint(cShards) != "[2 5]" {
+		t.Errorf("expected c to run shards [2 5], got %v", cShards)
+	}
+
+	// a leaves, so its shards are released right away
+	if err := c.Leave(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if b = join("b", b); fmt.Sprint(b) != "[0 3 4]" {
+		t.Errorf("expected b to run shards [0 3 4] after a left, got %v", b)
+	}
+
+	// b & c stop refreshing their membership & time out
+	time.Sleep(150 * time.Millisecond)
+	if a = join("a", nil); fmt.Sprint(a) != "[0 1 2 3 4 5]" {
+		t.Errorf("expected a to run all shards after b & c timed out, got %v", a)
+	}
+
+	if err := c.SetShardCount(ctx, 8); err != nil {
+		t.Fatal(err)
+	}
+	assignment, err := c.Join(ctx, "a", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assignment.ShardCount != 8 || len(assignment.ShardIDs) != 8 {
+		t.Errorf("expected a to run 8/8 shards, got %d/%d", len(assignment.ShardIDs), assignment.ShardCount)
+	}
+
+	// shards 0 & 2 share a bucket, 1 uses the other one
+	now := time.Now()
+	at0, _ := c.ReserveIdentify(ctx, 0)
+	at1, _ := c.ReserveIdentify(ctx, 1)
+	at2, _ := c.ReserveIdentify(ctx, 2)
+	if at0.Sub(now) > 100*time.Millisecond || at1.Sub(now) > 100*time.Millisecond {
+		t.Errorf("expected shards 0 & 1 to identify immediately, got %s & %s", at0.Sub(now), at1.Sub(now))
+	}
+	if at2.Sub(at0) != time.Second {
+		t.Errorf("expected shard 2 to identify 1s after shard 0, got %s", at2.Sub(at0))
+	}
+}
+
+func TestRemoteCoordinator(t *testing.T) {
+	t.Parallel()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewCoordinatorServer(NewCoordinator(2), nil)
+	go func() {
+		_ = server.Serve(ln)
+	}()
+	defer func() {
+		_ = server.Close(context.Background())
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	a := NewRemoteCoordinator("tcp", ln.Addr().String())
+	b := NewRemoteCoordinator("tcp", ln.Addr().String())
+
+	assignment, err := a.Join(ctx, "a", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = b.Join(ctx, "b", nil); err != nil {
+		t.Fatal(err)
+	}
+	if assignment, err = a.Join(ctx, "a", assignment.ShardIDs); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = a.Join(ctx, "a", assignment.ShardIDs); err != nil {
+		t.Fatal(err)
+	}
+	if assignment, err = b.Join(ctx, "b", nil); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(assignment.ShardIDs) != "[1]" {
+		t.Errorf("expected b to run shard [1], got %v", assignment.ShardIDs)
+	}
+
+	if err = b.Leave(ctx, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if assignment, err = a.Join(ctx, "a", []int{0}); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(assignment.ShardIDs) != "[0 1]" {
+		t.Errorf("expected a to run shards [0 1] after b left, got %v", assignment.ShardIDs)
+	}
+
+	if err = a.SetShardCount(ctx, 0); err == nil || err.Error() != ErrInvalidShardCount.Error() {
+		t.Errorf("expected %v, got %v", ErrInvalidShardCount, err)
+	}
+	if err = a.SetMaxConcurrency(ctx, 0); err == nil || err.Error() != ErrInvalidMaxConcurrency.Error() {
+		t.Errorf("expected %v, got %v", ErrInvalidMaxConcurrency, err)
+	}
+}
+
+func TestShardManager_Cluster(t *testing.T) {
+	t.Parallel()
+
+	coordinator := NewCoordinator(4, WithMemberTimeout(time.Second), WithIdentifyWait(0))
+	newManager := func(memberID string) ShardManager {
+		return New("", nil,
+			WithCoordinator(coordinator, memberID),
+			WithClusterMaxConcurrency(16),
+			WithClusterHeartbeatInterval(20*time.Millisecond),
+			WithGatewayCreateFunc(newTestGateway),
+		)
+	}
+	shardIDs := func(m ShardManager) string {
+		var ids []int
+		for shard := range m.Shards() {
+			ids = append(ids, shard.ShardID())
+		}
+		slices.Sort(ids)
+		return fmt.Sprint(ids)
+	}
+
+	ctx := context.Background()
+	a := newManager("a")
+	a.Open(ctx)
+	if ids := shardIDs(a); ids != "[0 1 2 3]" {
+		t.Fatalf("expected a to run all shards, got %s", ids)
+	}
+	if maxConcurrency := coordinator.(*coordinatorImpl).config.MaxConcurrency; maxConcurrency != 16 {
+		t.Errorf("expected max concurrency 16 after a joined, got %d", maxConcurrency)
+	}
+
+	b := newManager("b")
+	b.Open(ctx)
+	time.Sleep(100 * time.Millisecond)
+	if ids := shardIDs(a); ids != "[0 1]" {
+		t.Errorf("expected a to run shards [0 1], got %s", ids)
+	}
+	if ids := shardIDs(b); ids != "[2 3]" {
+		t.Errorf("expected b to run shards [2 3] after a released them, got %s", ids)
+	}
+
+	b.Close(ctx)
+	time.Sleep(100 * time.Millisecond)
+	if ids := shardIDs(a); ids != "[0 1 2 3]" {
+		t.Errorf("expected a to run all shards after b left, got %s", ids)
+	}
+	a.Close(ctx)
+}
+
+func newTestGateway(_ string, _ gateway.EventHandlerFunc, _ gateway.CloseHandlerFunc, opts ...gateway.ConfigOpt) gateway.Gateway {
+	// gateway.New only connects on Open, so we can use it to read the config
+	g := gateway.New("", nil, nil, opts...)
+	return &testGateway{Gateway: g}
+}
+
+type testGateway struct {
+	gateway.Gateway
+}
+
+func (g *testGateway) Open(_ context.Context) error { return nil }
+
+func (g *testGateway) Close(_ context.Context) {}
@@ -47,9 +47,9 @@ type ShardManager interface {
 	Shards() iter.Seq[gateway.Gateway]
 }
 
-// ShardIDByGuild returns the shard ID for the given guildID and shardCount.
+// ShardIDByGuild returns the shard ID for the given guildID and shardCount. It is the same as gateway.ShardIDByGuild.
 func ShardIDByGuild(guildID snowflake.ID, shardCount int) int {
-	return int((uint64(guildID) >> 22) % uint64(shardCount))
+	return gateway.ShardIDByGuild(guildID, shardCount)
 }
 
 var _ ShardManager = (*shardManagerImpl)(nil)
@@ -74,6 +74,19 @@ type shardManagerImpl struct {
 	token            string
 	eventHandlerFunc gateway.EventHandlerFunc
 	config           config
+
+	// cluster mode
+	clusterCancel     context.CancelFunc
+	clusterDone       chan struct{}
+	assignmentVersion int
+	assignmentMu      sync.Mutex
+
+	// shadow resharding
+	reshard   *reshard
+	reshardMu sync.Mutex
+
+	shardCountCheckCancel context.CancelFunc
+	shardCountCheckDone   chan struct{}
 }
 
 func (m *shardManagerImpl) closeHandler(shard gateway.Gateway, err error, _ bool) {
@@ -82,12 +95,27 @@ func (m *shardManagerImpl) closeHandler(shard gateway.Gateway, err error, _ bool
 		return
 	}
 	m.config.Logger.Debug("shard requires re-sharding", slog.Int("shardID", shard.ShardID()))
-	// make sure shard is closed
-	shard.Close(context.TODO())
+	if m.config.Coordinator != nil {
+		// let the coordinator reassign all shards of the cluster with the new shard count
+		if err = m.config.Coordinator.SetShardCount(context.TODO(), shard.ShardCount()*m.config.ShardSplitCount); err != nil {
+			m.config.Logger.Error("failed to set cluster shard count", slog.Any("err", err))
+		}
+		return
+	}
+	// make sure shard is closed, the session is invalid anyway so there is no need to keep it resumable
+	shard.CloseWithCode(context.TODO(), websocket.CloseNormalClosure, "re-sharding")
 
+	if m.config.ShadowResharding {
+		m.startReshard(shard.ShardCount() * m.config.ShardSplitCount)
+		return
+	}
+	m.reshardShard(shard)
+}
+
+// reshardShard replaces the given shard with ShardSplitCount new shards.
+func (m *shardManagerImpl) reshardShard(shard gateway.Gateway) {
 	m.shardsMu.Lock()
 	delete(m.shards, shard.ShardID())
-	defer m.shardsMu.Unlock()
 
 	oldShardCount := m.config.ShardCount
 	newShardCount := shard.ShardCount() * m.config.ShardSplitCount
@@ -101,6 +129,8 @@ func (m *shardManagerImpl) closeHandler(shard gateway.Gateway, err error, _ bool
 		newShardIDs = append(newShardIDs, newShardID)
 		newShardID += oldShardCount
 	}
+	// unlock before opening the new shards, as they register themselves with the ShardManager
+	m.shardsMu.Unlock()
 
 	var wg sync.WaitGroup
 	for _, shardID := range newShardIDs {
@@ -121,6 +151,18 @@ func (m *shardManagerImpl) closeHandler(shard gateway.Gateway, err error, _ bool
 }
 
 func (m *shardManagerImpl) Open(ctx context.Context) {
+	if m.config.Coordinator != nil {
+		m.openCluster(ctx)
+		return
+	}
+
+	if m.config.AutoScaling && m.config.ShadowResharding && m.config.ShardCountFunc != nil && m.config.ShardCountCheckInterval > 0 && m.shardCountCheckCancel == nil {
+		checkCtx, cancel := context.WithCancel(context.Background())
+		m.shardCountCheckCancel = cancel
+		m.shardCountCheckDone = make(chan struct{})
+		go m.checkShardCount(checkCtx)
+	}
+
 	m.config.Logger.Debug("opening shards", slog.String("shard_ids", fmt.Sprint(slices.Collect(maps.Keys(m.config.ShardIDs)))), slog.Int("shard_count", m.config.ShardCount))
 
 	var wg sync.WaitGroup
@@ -147,6 +189,25 @@ func (m *shardManagerImpl) Open(ctx context.Context) {
 }
 
 func (m *shardManagerImpl) Close(ctx context.Context) {
+	if m.shardCountCheckCancel != nil {
+		m.shardCountCheckCancel()
+		<-m.shardCountCheckDone
+		m.shardCountCheckCancel = nil
+	}
+
+	m.reshardMu.Lock()
+	r := m.reshard
+	m.reshardMu.Unlock()
+	if r != nil {
+		m.abortReshard(r)
+	}
+
+	if m.clusterCancel != nil {
+		m.clusterCancel()
+		<-m.clusterDone
+		m.clusterCancel = nil
+	}
+
 	m.config.Logger.Debug("closing shards", slog.String("shard_ids", fmt.Sprint(slices.Collect(maps.Keys(m.shards)))))
 	var wg sync.WaitGroup
 
@@ -161,6 +222,16 @@ func (m *shardManagerImpl) Close(ctx context.Context) {
 	}
 	wg.Wait()
 	m.shards = map[int]gateway.Gateway{}
+
+	if m.config.Coordinator != nil {
+		// leave after closing all shards, so no shard runs twice
+		if err := m.config.Coordinator.Leave(ctx, m.config.MemberID); err != nil {
+			m.config.Logger.Error("failed to leave cluster", slog.Any("err", err))
+		}
+		m.assignmentMu.Lock()
+		m.assignmentVersion = 0
+		m.assignmentMu.Unlock()
+	}
 }
 
 func (m *shardManagerImpl) OpenShard(ctx context.Context, shardID int) error {
@@ -196,6 +267,22 @@ func (m *shardManagerImpl) ResumeShard(ctx context.Context, shardID int, state S
 }
 
 func (m *shardManagerImpl) openShard(ctx context.Context, shardID int, shardCount int, state ShardState) error {
+	return m.createShard(shardID, shardCount, state).Open(ctx)
+}
+
+// createShard creates a new gateway.Gateway for the given shard & registers it with the ShardManager without opening it.
+func (m *shardManagerImpl) createShard(shardID int, shardCount int, state ShardState) gateway.Gateway {
+	shard := m.newShard(shardID, shardCount, state, m.handleEvent)
+
+	m.shardsMu.Lock()
+	m.shards[shardID] = shard
+	m.shardsMu.Unlock()
+
+	return shard
+}
+
+// newShard creates a new gateway.Gateway for the given shard which dispatches its events to the given gateway.EventHandlerFunc.
+func (m *shardManagerImpl) newShard(shardID int, shardCount int, state ShardState, eventHandlerFunc gateway.EventHandlerFunc) gateway.Gateway {
 	m.config.Logger.Debug("opening shard",
 		slog.Int("shard_id", shardID),
 		slog.Int("shard_count", shardCount),
@@ -205,6 +292,9 @@ func (m *shardManagerImpl) openShard(ctx context.Context, shardID int, shardCoun
 	)
 
 	opts := append(m.config.GatewayConfigOpts, gateway.WithShardID(shardID), gateway.WithShardCount(shardCount), gateway.WithIdentifyRateLimiter(m.config.IdentifyRateLimiter))
+	if m.config.SessionStore != nil {
+		opts = append(opts, gateway.WithSessionStore(m.config.SessionStore))
+	}
 	if state.SessionID != "" {
 		opts = append(opts, gateway.WithSessionID(state.SessionID))
 	}
@@ -215,13 +305,7 @@ func (m *shardManagerImpl) openShard(ctx context.Context, shardID int, shardCoun
 		opts = append(opts, gateway.WithResumeURL(state.ResumeURL))
 	}
 
-	shard := m.config.GatewayCreateFunc(m.token, m.eventHandlerFunc, m.closeHandler, opts...)
-
-	m.shardsMu.Lock()
-	m.shards[shardID] = shard
-	m.shardsMu.Unlock()
-
-	return shard.Open(ctx)
+	return m.config.GatewayCreateFunc(m.token, eventHandlerFunc, m.closeHandler, opts...)
 }
 
 func (m *shardManagerImpl) CloseShard(ctx context.Context, shardID int) {
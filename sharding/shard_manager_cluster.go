@@ -0,0 +1,150 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// openCluster joins the cluster, opens the assigned shards & keeps the membership alive until Close is called.
+func (m *shardManagerImpl) openCluster(ctx context.Context) {
+	m.config.Logger.Debug("joining cluster", slog.String("member_id", m.config.MemberID))
+
+	clusterCtx, cancel := context.WithCancel(context.Background())
+	m.clusterCancel = cancel
+	m.clusterDone = make(chan struct{})
+
+	if m.config.ClusterMaxConcurrency > 0 {
+		// identifies of shards which open right after joining must already use the max concurrency of the bot
+		if err := m.config.Coordinator.SetMaxConcurrency(ctx, m.config.ClusterMaxConcurrency); err != nil {
+			m.config.Logger.Error("failed to set cluster max concurrency", slog.Any("err", err), slog.String("member_id", m.config.MemberID))
+		}
+	}
+
+	assignment, err := m.config.Coordinator.Join(ctx, m.config.MemberID, nil)
+	if err == nil && m.config.ShardCount > assignment.ShardCount {
+		// our recommended shard count is higher than the one of the cluster
+		if err = m.config.Coordinator.SetShardCount(ctx, m.config.ShardCount); err == nil {
+			assignment, err = m.config.Coordinator.Join(ctx, m.config.MemberID, nil)
+		}
+	}
+
+	// start the heartbeat before opening shards, as identifying all shards might take longer than the member timeout
+	go m.clusterHeartbeat(clusterCtx)
+
+	if err != nil {
+		m.config.Logger.Error("failed to join cluster", slog.Any("err", err), slog.String("member_id", m.config.MemberID))
+		return
+	}
+	m.applyAssignment(ctx, assignment).Wait()
+}
+
+func (m *shardManagerImpl) clusterHeartbeat(ctx context.Context) {
+	defer close(m.clusterDone)
+
+	ticker := time.NewTicker(m.config.ClusterHeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		joinCtx, cancel := context.WithTimeout(ctx, m.config.ClusterHeartbeatInterval)
+		assignment, err := m.config.Coordinator.Join(joinCtx, m.config.MemberID, m.clusterShardIDs())
+		cancel()
+		if err != nil {
+			m.config.Logger.Error("failed to refresh cluster membership", slog.Any("err", err), slog.String("member_id", m.config.MemberID))
+			continue
+		}
+		m.applyAssignment(ctx, assignment)
+	}
+}
+
+// clusterShardIDs returns the shards of the current shard count this member runs, which includes shards which are still opening.
+// Closed shards are missing, which releases them in the Coordinator.
+func (m *shardManagerImpl) clusterShardIDs() []int {
+	m.shardsMu.Lock()
+	defer m.shardsMu.Unlock()
+
+	var shardIDs []int
+	for shardID, shard := range m.shards {
+		if shard.ShardCount() == m.config.ShardCount {
+			shardIDs = append(shardIDs, shardID)
+		}
+	}
+	return shardIDs
+}
+
+// applyAssignment closes all shards which are no longer assigned to this member & opens all newly assigned shards.
+// Shards are opened in the background, the returned sync.WaitGroup can be used to wait for them.
+func (m *shardManagerImpl) applyAssignment(ctx context.Context, assignment Assignment) *sync.WaitGroup {
+	var wg sync.WaitGroup
+
+	m.assignmentMu.Lock()
+	defer m.assignmentMu.Unlock()
+	if assignment.Version == m.assignmentVersion {
+		return &wg
+	}
+	m.assignmentVersion = assignment.Version
+
+	m.config.Logger.Debug("applying cluster assignment",
+		slog.Int("version", assignment.Version),
+		slog.Int("shard_count", assignment.ShardCount),
+		slog.String("shard_ids", fmt.Sprint(assignment.ShardIDs)),
+	)
+
+	var closing []gateway.Gateway
+	m.shardsMu.Lock()
+	for shardID, shard := range m.shards {
+		if !slices.Contains(assignment.ShardIDs, shardID) || shard.ShardCount() != assignment.ShardCount {
+			closing = append(closing, shard)
+			delete(m.shards, shardID)
+		}
+	}
+	m.config.ShardCount = assignment.ShardCount
+
+	var opening []int
+	for _, shardID := range assignment.ShardIDs {
+		if _, ok := m.shards[shardID]; !ok {
+			opening = append(opening, shardID)
+		}
+	}
+	m.shardsMu.Unlock()
+
+	closeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var closeWg sync.WaitGroup
+	for _, shard := range closing {
+		closeWg.Add(1)
+		go func() {
+			defer closeWg.Done()
+			shard.Close(closeCtx)
+			m.config.Logger.Debug("closed unassigned shard", slog.Int("shard_id", shard.ShardID()), slog.Int("shard_count", shard.ShardCount()))
+		}()
+	}
+	closeWg.Wait()
+
+	for _, shardID := range opening {
+		// register the shard right away, so a following assignment can close it while it's still opening
+		shard := m.createShard(shardID, assignment.ShardCount, ShardState{})
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := shard.Open(ctx); err != nil {
+				m.config.Logger.Error("failed to open assigned shard", slog.Any("err", err), slog.Int("shard_id", shardID), slog.Int("shard_count", assignment.ShardCount))
+				return
+			}
+			m.config.Logger.Debug("opened assigned shard", slog.Int("shard_id", shardID), slog.Int("shard_count", assignment.ShardCount))
+		}()
+	}
+	return &wg
+}
@@ -1,16 +1,24 @@
 package sharding
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
+	"os"
+	"time"
 
 	"github.com/disgoorg/disgo/gateway"
 )
 
 func defaultConfig() config {
 	return config{
-		Logger:            slog.Default(),
-		GatewayCreateFunc: gateway.New,
-		ShardSplitCount:   DefaultShardSplitCount,
+		Logger:                     slog.Default(),
+		GatewayCreateFunc:          gateway.New,
+		ShardSplitCount:            DefaultShardSplitCount,
+		ClusterHeartbeatInterval:   5 * time.Second,
+		ShadowReshardingTimeout:    5 * time.Minute,
+		ShadowReshardingBufferSize: 10000,
+		ShardCountCheckInterval:    time.Hour,
 	}
 }
 
@@ -36,6 +44,16 @@ type config struct {
 	ShardSplitCount int
 	// AutoScaling will automatically re-shard shards if they are too large. This is disabled by default.
 	AutoScaling bool
+	// ShadowResharding makes AutoScaling open a complete new set of shards next to the current ones & swap to it once all new shards received their guilds, instead of re-sharding the affected shard in place. This is disabled by default.
+	ShadowResharding bool
+	// ShadowReshardingTimeout is the duration after which the new set of shards is swapped in, even if not all guilds were received yet. Defaults to 5 minutes.
+	ShadowReshardingTimeout time.Duration
+	// ShadowReshardingBufferSize is the maximum number of events of the new set of shards which are buffered because the current shards did not receive them yet. Defaults to 10000.
+	ShadowReshardingBufferSize int
+	// ShardCountFunc returns the shard count recommended by Discord. If set, ShadowResharding re-shards as soon as the recommended shard count is higher than the current one. Defaults to nil.
+	ShardCountFunc func(ctx context.Context) (int, error)
+	// ShardCountCheckInterval is the interval in which ShardCountFunc is called. Defaults to 1 hour.
+	ShardCountCheckInterval time.Duration
 	// GatewayCreateFunc is the function which is used by the ShardManager to create a new gateway.Gateway. Defaults to gateway.New.
 	GatewayCreateFunc gateway.CreateFunc
 	// GatewayConfigOpts are the ConfigOpt(s) which are applied to the gateway.Gateway.
@@ -44,6 +62,16 @@ type config struct {
 	IdentifyRateLimiter gateway.IdentifyRateLimiter
 	// IdentifyRateLimiterConfigOpts are the gateway.IdentifyRateLimiterConfigOpt(s) which are applied to the gateway.IdentifyRateLimiter.
 	IdentifyRateLimiterConfigOpts []gateway.IdentifyRateLimiterConfigOpt
+	// SessionStore is the gateway.SessionStore the shards persist their sessions to on Close and resume them from on Open. Defaults to nil (no persistence).
+	SessionStore gateway.SessionStore
+	// Coordinator enables cluster mode. The ShardManager runs the shards assigned to it by the Coordinator instead of ShardIDs. Defaults to nil (no cluster mode).
+	Coordinator Coordinator
+	// MemberID is the ID of the ShardManager in the cluster. Defaults to <hostname>-<pid>.
+	MemberID string
+	// ClusterMaxConcurrency is the max concurrency the ShardManager sets in the Coordinator when joining the cluster. Defaults to 0 (not set).
+	ClusterMaxConcurrency int
+	// ClusterHeartbeatInterval is the interval in which the ShardManager refreshes its membership & Assignment. Defaults to 5 seconds.
+	ClusterHeartbeatInterval time.Duration
 }
 
 // ConfigOpt is a type alias for a function that takes a config and is used to configure your Server.
@@ -54,6 +82,15 @@ func (c *config) apply(opts []ConfigOpt) {
 		opt(c)
 	}
 	c.Logger = c.Logger.With(slog.String("name", "sharding"))
+	if c.Coordinator != nil {
+		if c.MemberID == "" {
+			hostname, _ := os.Hostname()
+			c.MemberID = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+		}
+		if c.IdentifyRateLimiter == nil {
+			c.IdentifyRateLimiter = NewClusterIdentifyRateLimiter(c.Coordinator)
+		}
+	}
 	if c.IdentifyRateLimiter == nil {
 		c.IdentifyRateLimiter = gateway.NewIdentifyRateLimiter(c.IdentifyRateLimiterConfigOpts...)
 	}
@@ -110,6 +147,45 @@ func WithAutoScaling(autoScaling bool) ConfigOpt {
 	}
 }
 
+// WithShadowResharding sets whether AutoScaling should re-shard without an event gap.
+// Instead of closing & re-opening the affected shard, the ShardManager opens a complete new set of shards next to the current ones.
+// Events of the new shards are buffered until all of them are ready & received all their guilds, then the new shards replace the current ones.
+// Only events which the current shards did not dispatch yet are buffered, up to ShadowReshardingBufferSize.
+// The timeout is the duration after which the new shards are swapped in, even if not all guilds were received yet.
+// This is only used if AutoScaling is enabled.
+func WithShadowResharding(shadowResharding bool, timeout time.Duration) ConfigOpt {
+	return func(config *config) {
+		config.ShadowResharding = shadowResharding
+		if timeout > 0 {
+			config.ShadowReshardingTimeout = timeout
+		}
+	}
+}
+
+// WithShadowReshardingBufferSize sets the maximum number of events of the new set of shards which are buffered because the current shards did not receive them yet.
+// Events of the new set of shards which the current shards already dispatched are not buffered.
+func WithShadowReshardingBufferSize(bufferSize int) ConfigOpt {
+	return func(config *config) {
+		config.ShadowReshardingBufferSize = bufferSize
+	}
+}
+
+// WithShardCountFunc sets the function which returns the shard count recommended by Discord, like discord.GatewayBot.Shards.
+// With ShadowResharding it is called every ShardCountCheckInterval & re-shards before Discord requires it, as soon as the recommended shard count is higher than the current one.
+// The new shard count is rounded up to a multiple of the current shard count. bot.New sets this by default.
+func WithShardCountFunc(shardCountFunc func(ctx context.Context) (int, error)) ConfigOpt {
+	return func(config *config) {
+		config.ShardCountFunc = shardCountFunc
+	}
+}
+
+// WithShardCountCheckInterval sets the interval in which the function set with WithShardCountFunc is called.
+func WithShardCountCheckInterval(interval time.Duration) ConfigOpt {
+	return func(config *config) {
+		config.ShardCountCheckInterval = interval
+	}
+}
+
 // WithGatewayCreateFunc sets the function which is used by the ShardManager to create a new gateway.Gateway.
 func WithGatewayCreateFunc(gatewayCreateFunc gateway.CreateFunc) ConfigOpt {
 	return func(config *config) {
@@ -144,3 +220,37 @@ func WithDefaultIdentifyRateLimiterConfigOpt(opts ...gateway.IdentifyRateLimiter
 		config.IdentifyRateLimiterConfigOpts = append(opts, config.IdentifyRateLimiterConfigOpts...)
 	}
 }
+
+// WithSessionStore sets the gateway.SessionStore the shards persist their sessions to on Close and resume them from on Open.
+// A [ShardState] passed via WithShardIDsWithStates takes precedence over the stored session.
+func WithSessionStore(sessionStore gateway.SessionStore) ConfigOpt {
+	return func(config *config) {
+		config.SessionStore = sessionStore
+	}
+}
+
+// WithCoordinator enables cluster mode. The ShardManager joins the cluster with the given memberID & runs the shards assigned to it by the Coordinator.
+// Leave memberID empty to use <hostname>-<pid>.
+// If no IdentifyRateLimiter is set, NewClusterIdentifyRateLimiter is used, so max concurrency buckets are respected across the whole cluster.
+func WithCoordinator(coordinator Coordinator, memberID string) ConfigOpt {
+	return func(config *config) {
+		config.Coordinator = coordinator
+		config.MemberID = memberID
+	}
+}
+
+// WithClusterHeartbeatInterval sets the interval in which the ShardManager refreshes its membership & Assignment in cluster mode.
+// This must be lower than the member timeout of the Coordinator.
+func WithClusterHeartbeatInterval(interval time.Duration) ConfigOpt {
+	return func(config *config) {
+		config.ClusterHeartbeatInterval = interval
+	}
+}
+
+// WithClusterMaxConcurrency sets the max concurrency the ShardManager sets in the Coordinator when joining the cluster.
+// This should be set to the max_concurrency returned by Discord, which bot.New does by default.
+func WithClusterMaxConcurrency(maxConcurrency int) ConfigOpt {
+	return func(config *config) {
+		config.ClusterMaxConcurrency = maxConcurrency
+	}
+}
@@ -0,0 +1,432 @@
+package sharding
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/maphash"
+	"io"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json/v2"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/gorilla/websocket"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+var fingerprintSeed = maphash.MakeSeed()
+
+// reshard is a set of shadow shards which is opened next to the current shards of the ShardManager.
+// Events of the shadow shards are buffered until all of them are ready & received all their guilds.
+//
+// Events of a guild arrive in the same order on the current & the shadow shards, so they are matched by guild & event type instead of by content.
+// Only events the shadow shards received before the current shards are buffered, which are the events the current shards might miss when they are closed.
+type reshard struct {
+	shardCount int
+	shards     map[int]gateway.Gateway
+	timer      *time.Timer
+	swapOnce   sync.Once
+
+	mu sync.Mutex
+	// pending are the guilds each shadow shard is still waiting for, a shard is missing until it is ready
+	pending map[int]map[snowflake.ID]struct{}
+	// syncEvents are the READY & GUILD_CREATE events the shadow shards sent when connecting, they are always dispatched
+	syncEvents []bufferedEvent
+	// buffer are the events the shadow shards received before the current shards
+	buffer map[eventKey][]bufferedEvent
+	// buffered is the number of events in buffer, seq orders them
+	buffered int
+	seq      int
+	// overflowed is the number of events which were not buffered because the buffer was full
+	overflowed int
+	// missing is the number of events the current shards received before the shadow shards
+	missing map[eventKey]int
+	done    bool
+	aborted bool
+}
+
+// eventKey identifies the events which are matched between the current & the shadow shards.
+type eventKey struct {
+	eventType gateway.EventType
+	guildID   snowflake.ID
+	// fingerprint tells events without a guild apart, as their order is not guaranteed across shards
+	fingerprint uint64
+}
+
+type bufferedEvent struct {
+	seq            int
+	shard          gateway.Gateway
+	eventType      gateway.EventType
+	sequenceNumber int
+	event          gateway.EventData
+}
+
+// ready returns whether all shadow shards are ready & received all their guilds. It must be called with r.mu held.
+func (r *reshard) ready() bool {
+	if len(r.pending) < len(r.shards) {
+		return false
+	}
+	for _, guilds := range r.pending {
+		if len(guilds) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// matched returns whether events of the given key are matched between the current & the shadow shards. It must be called with r.mu held.
+// Events of a guild are only matched once the shadow shard of the guild is ready, as it doesn't receive any events before.
+func (r *reshard) matched(key eventKey) bool {
+	if key.guildID == 0 {
+		return true
+	}
+	_, ok := r.pending[ShardIDByGuild(key.guildID, r.shardCount)]
+	return ok
+}
+
+// record remembers an event dispatched by one of the current shards, so the same event of a shadow shard can be dropped.
+func (r *reshard) record(eventType gateway.EventType, event gateway.EventData) gateway.EventData {
+	key, event := newEventKey(eventType, event)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.done || r.aborted || !r.matched(key) {
+		return event
+	}
+	if queue := r.buffer[key]; len(queue) > 0 {
+		// the shadow shards received the event first, so it doesn't need to be dispatched again
+		if len(queue) == 1 {
+			delete(r.buffer, key)
+		} else {
+			r.buffer[key] = queue[1:]
+		}
+		r.buffered--
+		return event
+	}
+	r.missing[key]++
+	return event
+}
+
+// newEventKey returns the eventKey of the given event.
+// As the payload of a gateway.EventRaw can only be read once, the returned event should be used instead of the given one.
+func newEventKey(eventType gateway.EventType, event gateway.EventData) (eventKey, gateway.EventData) {
+	if raw, ok := event.(gateway.EventRaw); ok {
+		data, _ := io.ReadAll(raw.Payload)
+		raw.Payload = bytes.NewReader(data)
+		return eventKey{eventType: eventType + ":" + raw.EventType, fingerprint: fingerprint(data)}, raw
+	}
+	if guildID := eventGuildID(event); guildID != 0 {
+		return eventKey{eventType: eventType, guildID: guildID}, event
+	}
+	data, _ := json.Marshal(event)
+	return eventKey{eventType: eventType, fingerprint: fingerprint(data)}, event
+}
+
+func fingerprint(data []byte) uint64 {
+	return maphash.Bytes(fingerprintSeed, data)
+}
+
+var (
+	guildIDFields   sync.Map
+	snowflakeType   = reflect.TypeFor[snowflake.ID]()
+	snowflakePtType = reflect.TypeFor[*snowflake.ID]()
+)
+
+// eventGuildID returns the ID of the guild the given event belongs to or 0 if it doesn't belong to a guild.
+func eventGuildID(event gateway.EventData) snowflake.ID {
+	switch e := event.(type) {
+	case gateway.EventGuildCreate:
+		return e.ID
+	case gateway.EventGuildUpdate:
+		return e.ID
+	case gateway.EventGuildDelete:
+		return e.ID
+	}
+
+	v := reflect.ValueOf(event)
+	if v.Kind() != reflect.Struct {
+		return 0
+	}
+	index, ok := guildIDFields.Load(v.Type())
+	if !ok {
+		var fieldIndex []int
+		if field, ok := v.Type().FieldByName("GuildID"); ok && (field.Type == snowflakeType || field.Type == snowflakePtType) {
+			fieldIndex = field.Index
+		}
+		index, _ = guildIDFields.LoadOrStore(v.Type(), fieldIndex)
+	}
+	if index.([]int) == nil {
+		return 0
+	}
+
+	field, err := v.FieldByIndexErr(index.([]int))
+	if err != nil {
+		return 0
+	}
+	switch guildID := field.Interface().(type) {
+	case snowflake.ID:
+		return guildID
+	case *snowflake.ID:
+		if guildID != nil {
+			return *guildID
+		}
+	}
+	return 0
+}
+
+// handleEvent dispatches the events of the current shards & records them while a reshard is in progress.
+func (m *shardManagerImpl) handleEvent(shard gateway.Gateway, eventType gateway.EventType, sequenceNumber int, event gateway.EventData) {
+	m.reshardMu.Lock()
+	r := m.reshard
+	m.reshardMu.Unlock()
+
+	if r != nil && eventType != gateway.EventTypeHeartbeatAck {
+		event = r.record(eventType, event)
+	}
+	m.eventHandlerFunc(shard, eventType, sequenceNumber, event)
+}
+
+// handleShadowEvent buffers the events of the shadow shards until they replaced the current shards.
+func (m *shardManagerImpl) handleShadowEvent(r *reshard, shard gateway.Gateway, eventType gateway.EventType, sequenceNumber int, event gateway.EventData) {
+	var key eventKey
+	if eventType != gateway.EventTypeHeartbeatAck && eventType != gateway.EventTypeReady {
+		key, event = newEventKey(eventType, event)
+	}
+
+	r.mu.Lock()
+	if r.aborted {
+		r.mu.Unlock()
+		return
+	}
+	if r.done || eventType == gateway.EventTypeHeartbeatAck {
+		r.mu.Unlock()
+		m.eventHandlerFunc(shard, eventType, sequenceNumber, event)
+		return
+	}
+
+	r.seq++
+	e := bufferedEvent{
+		seq:            r.seq,
+		shard:          shard,
+		eventType:      eventType,
+		sequenceNumber: sequenceNumber,
+		event:          event,
+	}
+	switch event := event.(type) {
+	case gateway.EventReady:
+		guilds := make(map[snowflake.ID]struct{}, len(event.Guilds))
+		for _, guild := range event.Guilds {
+			guilds[guild.ID] = struct{}{}
+		}
+		r.pending[shard.ShardID()] = guilds
+		r.syncEvents = append(r.syncEvents, e)
+
+	case gateway.EventGuildCreate:
+		if _, ok := r.pending[shard.ShardID()][event.ID]; ok {
+			delete(r.pending[shard.ShardID()], event.ID)
+			r.syncEvents = append(r.syncEvents, e)
+			break
+		}
+		r.bufferEvent(key, e, m.config.ShadowReshardingBufferSize)
+
+	default:
+		r.bufferEvent(key, e, m.config.ShadowReshardingBufferSize)
+	}
+	ready := r.ready()
+	r.mu.Unlock()
+
+	if ready {
+		go m.swapReshard(r)
+	}
+}
+
+// bufferEvent buffers an event of a shadow shard unless the current shards already received it. It must be called with r.mu held.
+func (r *reshard) bufferEvent(key eventKey, e bufferedEvent, bufferSize int) {
+	if r.missing[key] > 0 {
+		if r.missing[key]--; r.missing[key] == 0 {
+			delete(r.missing, key)
+		}
+		return
+	}
+	if r.buffered >= bufferSize {
+		r.overflowed++
+		return
+	}
+	r.buffer[key] = append(r.buffer[key], e)
+	r.buffered++
+}
+
+// startReshard opens a new set of shards with the given shard count next to the current shards.
+// The new shards replace the current ones as soon as all of them are ready & received all their guilds or the ShadowReshardingTimeout is reached.
+func (m *shardManagerImpl) startReshard(shardCount int) {
+	m.reshardMu.Lock()
+	if m.reshard != nil {
+		m.reshardMu.Unlock()
+		// all current shards are replaced by the running reshard anyway
+		return
+	}
+	r := &reshard{
+		shardCount: shardCount,
+		shards:     map[int]gateway.Gateway{},
+		pending:    map[int]map[snowflake.ID]struct{}{},
+		buffer:     map[eventKey][]bufferedEvent{},
+		missing:    map[eventKey]int{},
+	}
+	r.timer = time.AfterFunc(m.config.ShadowReshardingTimeout, func() {
+		m.swapReshard(r)
+	})
+	m.reshard = r
+	m.reshardMu.Unlock()
+
+	m.shardsMu.Lock()
+	for shardID, shard := range m.shards {
+		// every current shard is split into the shards which handle the same guilds with the new shard count
+		for i := range max(shardCount/shard.ShardCount(), 1) {
+			newShardID := shardID + i*shard.ShardCount()
+			r.shards[newShardID] = m.newShard(newShardID, shardCount, ShardState{}, func(shard gateway.Gateway, eventType gateway.EventType, sequenceNumber int, event gateway.EventData) {
+				m.handleShadowEvent(r, shard, eventType, sequenceNumber, event)
+			})
+		}
+	}
+	m.shardsMu.Unlock()
+
+	m.config.Logger.Debug("opening shadow shards", slog.String("shard_ids", fmt.Sprint(slices.Sorted(maps.Keys(r.shards)))), slog.Int("shard_count", shardCount))
+
+	for shardID, shard := range r.shards {
+		go func() {
+			if err := shard.Open(context.Background()); err != nil {
+				m.config.Logger.Error("failed to open shadow shard", slog.Any("err", err), slog.Int("shard_id", shardID), slog.Int("shard_count", shardCount))
+				m.abortReshard(r)
+			}
+		}()
+	}
+}
+
+// checkShardCount periodically checks the recommended shard count & starts a reshard once it's higher than the current shard count.
+func (m *shardManagerImpl) checkShardCount(ctx context.Context) {
+	defer close(m.shardCountCheckDone)
+
+	ticker := time.NewTicker(m.config.ShardCountCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		shardCount, err := m.config.ShardCountFunc(ctx)
+		if err != nil {
+			m.config.Logger.Error("failed to get recommended shard count", slog.Any("err", err))
+			continue
+		}
+
+		m.shardsMu.Lock()
+		currentShardCount := m.config.ShardCount
+		m.shardsMu.Unlock()
+		if currentShardCount < 1 || shardCount <= currentShardCount {
+			continue
+		}
+		// every current shard must be split into whole shards
+		shardCount = (shardCount + currentShardCount - 1) / currentShardCount * currentShardCount
+		m.config.Logger.Debug("recommended shard count increased", slog.Int("old_shard_count", currentShardCount), slog.Int("shard_count", shardCount))
+		m.startReshard(shardCount)
+	}
+}
+
+// swapReshard replaces the current shards with the shadow shards, closes the current shards & dispatches all buffered events which were not dispatched by the current shards already.
+func (m *shardManagerImpl) swapReshard(r *reshard) {
+	r.swapOnce.Do(func() {
+		r.timer.Stop()
+
+		r.mu.Lock()
+		if !r.ready() {
+			m.config.Logger.Warn("shadow shards did not receive all guilds in time, swapping anyway", slog.Int("shard_count", r.shardCount))
+		}
+		r.mu.Unlock()
+
+		m.shardsMu.Lock()
+		oldShards := m.shards
+		m.shards = r.shards
+		m.config.ShardCount = r.shardCount
+		m.shardsMu.Unlock()
+
+		// close the old shards before flushing the buffer, so we know every event they dispatched
+		closeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		var wg sync.WaitGroup
+		for _, shard := range oldShards {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				shard.CloseWithCode(closeCtx, websocket.CloseNormalClosure, "re-sharding")
+			}()
+		}
+		wg.Wait()
+
+		m.reshardMu.Lock()
+		m.reshard = nil
+		m.reshardMu.Unlock()
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		events := r.syncEvents
+		for _, queue := range r.buffer {
+			events = append(events, queue...)
+		}
+		slices.SortFunc(events, func(a, b bufferedEvent) int {
+			return a.seq - b.seq
+		})
+		for _, e := range events {
+			m.eventHandlerFunc(e.shard, e.eventType, e.sequenceNumber, e.event)
+		}
+		if r.overflowed > 0 {
+			m.config.Logger.Warn("shadow resharding buffer overflowed, some events might have been lost", slog.Int("shard_count", r.shardCount), slog.Int("lost_events", r.overflowed))
+		}
+		m.config.Logger.Debug("swapped to shadow shards",
+			slog.Int("shard_count", r.shardCount),
+			slog.Int("dispatched_events", len(events)),
+		)
+		r.syncEvents = nil
+		r.buffer = nil
+		r.missing = nil
+		r.done = true
+	})
+}
+
+// abortReshard closes all shadow shards & keeps the current shards.
+func (m *shardManagerImpl) abortReshard(r *reshard) {
+	r.swapOnce.Do(func() {
+		r.timer.Stop()
+
+		r.mu.Lock()
+		r.aborted = true
+		r.syncEvents = nil
+		r.buffer = nil
+		r.missing = nil
+		r.mu.Unlock()
+
+		m.reshardMu.Lock()
+		m.reshard = nil
+		m.reshardMu.Unlock()
+
+		closeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		var wg sync.WaitGroup
+		for _, shard := range r.shards {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				shard.Close(closeCtx)
+			}()
+		}
+		wg.Wait()
+		m.config.Logger.Debug("aborted re-sharding", slog.Int("shard_count", r.shardCount))
+	})
+}
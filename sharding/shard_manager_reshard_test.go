@@ -0,0 +1,173 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/gorilla/websocket"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+func TestShardManager_ShadowResharding(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu         sync.Mutex
+		shards     = map[string]*eventTestGateway{}
+		dispatched []string
+	)
+	createFunc := func(_ string, eventHandlerFunc gateway.EventHandlerFunc, closeHandlerFunc gateway.CloseHandlerFunc, opts ...gateway.ConfigOpt) gateway.Gateway {
+		g := &eventTestGateway{
+			Gateway:          gateway.New("", nil, nil, opts...),
+			eventHandlerFunc: eventHandlerFunc,
+			closeHandlerFunc: closeHandlerFunc,
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		shards[fmt.Sprintf("%d/%d", g.ShardID(), g.ShardCount())] = g
+		return g
+	}
+	shard := func(key string) *eventTestGateway {
+		mu.Lock()
+		defer mu.Unlock()
+		return shards[key]
+	}
+
+	m := New("", func(shard gateway.Gateway, eventType gateway.EventType, _ int, event gateway.EventData) {
+		mu.Lock()
+		defer mu.Unlock()
+		if typing, ok := event.(gateway.EventTypingStart); ok {
+			dispatched = append(dispatched, fmt.Sprintf("%d/%d:%s:%d", shard.ShardID(), shard.ShardCount(), eventType, typing.ChannelID))
+			return
+		}
+		dispatched = append(dispatched, fmt.Sprintf("%d/%d:%s", shard.ShardID(), shard.ShardCount(), eventType))
+	},
+		WithShardIDs(0, 1),
+		WithShardCount(2),
+		WithAutoScaling(true),
+		WithShadowResharding(true, time.Minute),
+		WithGatewayCreateFunc(createFunc),
+	)
+	m.Open(context.Background())
+
+	shard("0/2").closeHandlerFunc(shard("0/2"), &websocket.CloseError{Code: gateway.CloseEventCodeShardingRequired.Code}, false)
+	for _, key := range []string{"0/4", "1/4", "2/4", "3/4"} {
+		if shard(key) == nil {
+			t.Fatalf("expected shadow shard %s to be created", key)
+		}
+	}
+	if s := m.Shard(1); s.ShardCount() != 2 {
+		t.Fatalf("expected shard 1 to keep running until all shadow shards are ready, got shard count %d", s.ShardCount())
+	}
+
+	// dispatched by the old shard & buffered by the shadow shard, should only be dispatched once
+	shard("1/2").dispatch(gateway.EventTypeTypingStart, gateway.EventTypingStart{ChannelID: 1})
+	for shardID := range 4 {
+		s := shard(fmt.Sprintf("%d/4", shardID))
+		s.dispatch(gateway.EventTypeReady, gateway.EventReady{Guilds: []discord.UnavailableGuild{{ID: snowflake.ID(shardID + 1)}}})
+		if shardID == 1 {
+			s.dispatch(gateway.EventTypeTypingStart, gateway.EventTypingStart{ChannelID: 1})
+			s.dispatch(gateway.EventTypeTypingStart, gateway.EventTypingStart{ChannelID: 2})
+		}
+	}
+	// events of a guild are matched by their order, no matter which shards receive them first
+	guildID := snowflake.ID(1)
+	shard("0/4").dispatch(gateway.EventTypeTypingStart, gateway.EventTypingStart{ChannelID: 5, GuildID: &guildID})
+	shard("1/2").dispatch(gateway.EventTypeTypingStart, gateway.EventTypingStart{ChannelID: 5, GuildID: &guildID})
+	shard("1/2").dispatch(gateway.EventTypeTypingStart, gateway.EventTypingStart{ChannelID: 6, GuildID: &guildID})
+	shard("0/4").dispatch(gateway.EventTypeTypingStart, gateway.EventTypingStart{ChannelID: 6, GuildID: &guildID})
+	// only received by the shadow shard, e.g. while the old shard is closing
+	shard("0/4").dispatch(gateway.EventTypeTypingStart, gateway.EventTypingStart{ChannelID: 7, GuildID: &guildID})
+	for shardID := range 4 {
+		shard(fmt.Sprintf("%d/4", shardID)).dispatch(gateway.EventTypeGuildCreate, gateway.EventGuildCreate{GatewayGuild: discord.GatewayGuild{RestGuild: discord.RestGuild{Guild: discord.Guild{ID: snowflake.ID(shardID + 1)}}}})
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for m.Shard(3) == nil || m.Shard(3).ShardCount() != 4 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected shadow shards to replace the old shards")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// events dispatched after the swap are not buffered anymore
+	shard("3/4").dispatch(gateway.EventTypeTypingStart, gateway.EventTypingStart{ChannelID: 3})
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := fmt.Sprint([]string{
+		"1/2:TYPING_START:1", "1/2:TYPING_START:5", "1/2:TYPING_START:6",
+		"0/4:READY", "1/4:READY", "1/4:TYPING_START:2", "2/4:READY", "3/4:READY", "0/4:TYPING_START:7",
+		"0/4:GUILD_CREATE", "1/4:GUILD_CREATE", "2/4:GUILD_CREATE", "3/4:GUILD_CREATE",
+		"3/4:TYPING_START:3",
+	})
+	if actual := fmt.Sprint(dispatched); actual != expected {
+		t.Errorf("expected dispatched events %s, got %s", expected, actual)
+	}
+}
+
+func TestShardManager_ShardCountCheck(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		shards = map[string]struct{}{}
+	)
+	createFunc := func(_ string, eventHandlerFunc gateway.EventHandlerFunc, closeHandlerFunc gateway.CloseHandlerFunc, opts ...gateway.ConfigOpt) gateway.Gateway {
+		g := &eventTestGateway{
+			Gateway:          gateway.New("", nil, nil, opts...),
+			eventHandlerFunc: eventHandlerFunc,
+			closeHandlerFunc: closeHandlerFunc,
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		shards[fmt.Sprintf("%d/%d", g.ShardID(), g.ShardCount())] = struct{}{}
+		return g
+	}
+
+	m := New("", func(gateway.Gateway, gateway.EventType, int, gateway.EventData) {},
+		WithShardIDs(0, 1),
+		WithShardCount(2),
+		WithAutoScaling(true),
+		WithShadowResharding(true, time.Minute),
+		WithShardCountFunc(func(context.Context) (int, error) {
+			return 3, nil
+		}),
+		WithShardCountCheckInterval(10*time.Millisecond),
+		WithGatewayCreateFunc(createFunc),
+	)
+	m.Open(context.Background())
+	defer m.Close(context.Background())
+
+	// the recommended shard count is rounded up to a multiple of the current one
+	deadline := time.Now().Add(time.Second)
+	for {
+		mu.Lock()
+		_, ok := shards["3/4"]
+		mu.Unlock()
+		if ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected shadow shards with shard count 4 to be opened")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+type eventTestGateway struct {
+	gateway.Gateway
+	eventHandlerFunc gateway.EventHandlerFunc
+	closeHandlerFunc gateway.CloseHandlerFunc
+}
+
+func (g *eventTestGateway) dispatch(eventType gateway.EventType, event gateway.EventData) {
+	g.eventHandlerFunc(g, eventType, 0, event)
+}
+
+func (g *eventTestGateway) Open(_ context.Context) error { return nil }
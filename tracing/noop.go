@@ -0,0 +1,24 @@
+package tracing
+
+import (
+	"context"
+)
+
+var _ Tracer = (*noopTracer)(nil)
+
+// Noop is a Tracer which records nothing.
+var Noop Tracer = noopTracer{}
+
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, _ string, _ ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(_ ...Attribute) {}
+
+func (noopSpan) RecordError(_ error) {}
+
+func (noopSpan) End() {}
@@ -0,0 +1,49 @@
+// Package tracing provides a small, exporter-agnostic tracing interface which is used to trace events, interaction handlers & rest requests.
+//
+// By default, all packages use Noop which records nothing.
+// To export spans, implement Tracer as a thin adapter around your tracing library of choice (e.g. OpenTelemetry).
+package tracing
+
+import (
+	"context"
+)
+
+// Tracer starts new Span(s).
+type Tracer interface {
+	// Start starts a new Span with the given name & attributes as a child of the Span in the given context.Context, if any.
+	// The returned context.Context contains the new Span and should be passed to all operations the Span covers.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is a single timed operation.
+type Span interface {
+	// SetAttributes sets the given attributes on the Span.
+	SetAttributes(attrs ...Attribute)
+
+	// RecordError records the given error on the Span and marks it as failed.
+	RecordError(err error)
+
+	// End ends the Span. No methods should be called after End.
+	End()
+}
+
+// Attribute is a key value pair describing a Span.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// String returns a new string Attribute.
+func String(key string, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns a new int Attribute.
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool returns a new bool Attribute.
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}